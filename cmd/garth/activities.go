@@ -4,9 +4,12 @@ import (
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -15,6 +18,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/sstent/go-garth-cli/pkg/export"
 	"github.com/sstent/go-garth-cli/pkg/garmin"
 )
 
@@ -43,14 +47,48 @@ var (
 	downloadActivitiesCmd = &cobra.Command{
 		Use:   "download [activityID]",
 		Short: "Download activity data",
-		Args:  cobra.RangeArgs(0, 1), RunE: runDownloadActivity,
+		Long: `Download activity data from Garmin Connect. Multisport activities such as
+triathlons are exported as one file per leg; their original FIT file holds
+every leg.`,
+		Args: cobra.RangeArgs(0, 1), RunE: runDownloadActivity,
+	}
+
+	samplesActivitiesCmd = &cobra.Command{
+		Use:   "samples [activityID]",
+		Short: "Export activity time-series samples",
+		Long: `Export the per-sample streams recorded during an activity: timestamp, position,
+distance, heart rate, speed, power, cadence, elevation and temperature.
+Use --output csv or --output json for columnar output suitable for analysis tools.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runActivitySamples,
+	}
+
+	exportActivitiesCmd = &cobra.Command{
+		Use:   "export [activityID]",
+		Short: "Generate a GPX, TCX or GeoJSON file from activity samples",
+		Long: `Generate a GPX, TCX or GeoJSON file locally from the activity details and
+time-series samples, rather than using Garmin's export service. This also works
+for manual and indoor activities, which Garmin does not export.
+
+GPX carries heart rate, cadence and temperature in Garmin's TrackPointExtension,
+TCX carries laps and power, and GeoJSON holds the track with summary properties.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runExportActivity,
 	}
 
 	searchActivitiesCmd = &cobra.Command{
 		Use:   "search",
 		Short: "Search activities",
-		Long:  `Search Garmin Connect activities by a query string.`,
-		RunE:  runSearchActivities,
+		Long: `Search Garmin Connect activities with a query such as:
+
+  type:running distance>10km date:2024-05.. name~"tempo" hr_avg<150
+
+Terms must all match. Fields: type, name, date, distance, duration, hr_avg,
+hr_max, calories and elevation; operators: : = != > >= < <= and ~ (contains).
+Dates are YYYY, YYYY-MM or YYYY-MM-DD, or ranges A..B, A.. and ..B.
+Distances take m, km or mi (default km); durations take s, min or h, or h:mm:ss
+(default minutes). A bare word matches activity names.`,
+		RunE: runSearchActivities,
 	}
 
 	// Flags for listActivitiesCmd
@@ -59,36 +97,50 @@ var (
 	activityType     string
 	activityDateFrom string
 	activityDateTo   string
+	activityMulti    string
+	activityWeather  bool
 
 	// Flags for downloadActivitiesCmd
 	downloadFormat   string
 	outputDir        string
 	downloadOriginal bool
 	downloadAll      bool
+
+	// Flags for exportActivitiesCmd
+	exportFormat string
+	exportFile   string
 )
 
 func init() {
 	rootCmd.AddCommand(activitiesCmd)
 
 	activitiesCmd.AddCommand(listActivitiesCmd)
-	listActivitiesCmd.Flags().IntVar(&activityLimit, "limit", 20, "Maximum number of activities to retrieve")
+	listActivitiesCmd.Flags().IntVar(&activityLimit, "limit", 20, "Maximum number of activities to retrieve (0 for all)")
 	listActivitiesCmd.Flags().IntVar(&activityOffset, "offset", 0, "Offset for activities list")
 	listActivitiesCmd.Flags().StringVar(&activityType, "type", "", "Filter activities by type (e.g., running, cycling)")
 	listActivitiesCmd.Flags().StringVar(&activityDateFrom, "from", "", "Start date for filtering activities (YYYY-MM-DD)")
 	listActivitiesCmd.Flags().StringVar(&activityDateTo, "to", "", "End date for filtering activities (YYYY-MM-DD)")
+	listActivitiesCmd.Flags().BoolVar(&activityWeather, "with-weather", false, "Add the weather of each activity (one extra request per activity)")
+	listActivitiesCmd.Flags().StringVar(&activityMulti, "multisport", "", "Show the legs of multisport activities grouped under them (group) or in their place (flatten)")
 
 	activitiesCmd.AddCommand(getActivitiesCmd)
 
 	activitiesCmd.AddCommand(downloadActivitiesCmd)
-	downloadActivitiesCmd.Flags().StringVar(&downloadFormat, "format", "gpx", "Download format (gpx, tcx, fit, csv)")
+	downloadActivitiesCmd.Flags().StringVar(&downloadFormat, "format", "gpx", "Download format (gpx, tcx, kml, fit, csv)")
 	downloadActivitiesCmd.Flags().StringVar(&outputDir, "output-dir", ".", "Output directory for downloaded files")
-	downloadActivitiesCmd.Flags().BoolVar(&downloadOriginal, "original", false, "Download original uploaded file")
+	downloadActivitiesCmd.Flags().BoolVar(&downloadOriginal, "original", false, "Download original uploaded file (FIT, extracted from the zip archive)")
 
 	downloadActivitiesCmd.Flags().BoolVar(&downloadAll, "all", false, "Download all activities matching filters")
 	downloadActivitiesCmd.Flags().StringVar(&activityType, "type", "", "Filter activities by type (e.g., running, cycling)")
 	downloadActivitiesCmd.Flags().StringVar(&activityDateFrom, "from", "", "Start date for filtering activities (YYYY-MM-DD)")
 	downloadActivitiesCmd.Flags().StringVar(&activityDateTo, "to", "", "End date for filtering activities (YYYY-MM-DD)")
 
+	activitiesCmd.AddCommand(samplesActivitiesCmd)
+
+	activitiesCmd.AddCommand(exportActivitiesCmd)
+	exportActivitiesCmd.Flags().StringVar(&exportFormat, "format", "gpx", "Export format ("+strings.Join(export.Formats(), ", ")+")")
+	exportActivitiesCmd.Flags().StringVarP(&exportFile, "file", "f", "", "Output file (default <activityID>.<format>, - for stdout)")
+
 	activitiesCmd.AddCommand(searchActivitiesCmd)
 	searchActivitiesCmd.Flags().StringP("query", "q", "", "Query string to search for activities")
 }
@@ -108,6 +160,7 @@ func runListActivities(cmd *cobra.Command, args []string) error {
 		Limit:        activityLimit,
 		Offset:       activityOffset,
 		ActivityType: activityType,
+		Multisport:   garmin.MultisportMode(activityMulti),
 	}
 
 	if activityDateFrom != "" {
@@ -134,11 +187,55 @@ func runListActivities(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	outputFormat := viper.GetString("output")
+	if !activityWeather {
+		return printActivities(activities)
+	}
+	return printActivityList(activities, fetchWeather(garminClient, activities))
+}
+
+// fetchWeather gets the weather of each activity. The weather is auxiliary, so
+// a failed request is reported as a warning and leaves that activity without it
+func fetchWeather(garminClient *garmin.Client, activities []garmin.Activity) map[int64]*garmin.Weather {
+	weather := make(map[int64]*garmin.Weather, len(activities))
+	for _, activity := range activities {
+		w, err := garminClient.GetWeather(int(activity.ActivityID))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to get weather for activity %d: %v\n", activity.ActivityID, err)
+			continue
+		}
+		weather[activity.ActivityID] = w
+	}
+	return weather
+}
+
+// printActivities prints an activity list in the configured output format
+func printActivities(activities []garmin.Activity) error {
+	return printActivityList(activities, nil)
+}
+
+// printActivityList prints an activity list in the configured output format,
+// with weather columns when weather is not nil
+func printActivityList(activities []garmin.Activity, weather map[int64]*garmin.Weather) error {
+	outputFormat := viper.GetString("output.format")
 
 	switch outputFormat {
-	case "json":
-		data, err := json.MarshalIndent(activities, "", "  ")
+	case "json", "yaml":
+		var result interface{} = activities
+		if weather != nil {
+			type activityWithWeather struct {
+				garmin.Activity
+				Weather *garmin.Weather `json:"weather,omitempty"`
+			}
+			withWeather := make([]activityWithWeather, len(activities))
+			for i, activity := range activities {
+				withWeather[i] = activityWithWeather{activity, weather[activity.ActivityID]}
+			}
+			result = withWeather
+		}
+		if outputFormat == "yaml" {
+			return printYAML(result)
+		}
+		data, err := json.MarshalIndent(result, "", "  ")
 		if err != nil {
 			return fmt.Errorf("failed to marshal activities to JSON: %w", err)
 		}
@@ -147,28 +244,70 @@ func runListActivities(cmd *cobra.Command, args []string) error {
 		writer := csv.NewWriter(os.Stdout)
 		defer writer.Flush()
 
-		writer.Write([]string{"ActivityID", "ActivityName", "ActivityType", "StartTime", "Distance(km)", "Duration(s)"})
+		header := []string{"ActivityID", "ActivityName", "ActivityType", "StartTime", "Distance(km)", "Duration(s)"}
+		if weather != nil {
+			header = append(header, "Temperature(C)", "ApparentTemperature(C)", "Humidity(%)", "WindSpeed(km/h)", "WindDirection", "Conditions")
+		}
+		writer.Write(header)
 		for _, activity := range activities {
-			writer.Write([]string{
+			record := []string{
 				fmt.Sprintf("%d", activity.ActivityID),
 				activity.ActivityName,
 				activity.ActivityType.TypeKey,
 				activity.StartTimeLocal.Format("2006-01-02 15:04:05"),
 				fmt.Sprintf("%.2f", activity.Distance/1000),
 				fmt.Sprintf("%.0f", activity.Duration),
-			})
+			}
+			if weather != nil {
+				w := weather[activity.ActivityID]
+				if w == nil {
+					w = &garmin.Weather{}
+				}
+				record = append(record,
+					formatWeatherValue(w.Temperature, "%.1f", 1, ""),
+					formatWeatherValue(w.ApparentTemperature, "%.1f", 1, ""),
+					formatWeatherValue(w.Humidity, "%.0f", 1, ""),
+					formatWeatherValue(w.WindSpeed, "%.1f", 3.6, ""),
+					w.WindCompass,
+					w.Conditions,
+				)
+			}
+			writer.Write(record)
 		}
 	case "table":
-		tbl := table.New("ID", "Name", "Type", "Date", "Distance (km)", "Duration (s)")
+		header := []interface{}{"ID", "Name", "Type", "Date", "Distance (km)", "Duration (s)"}
+		if weather != nil {
+			header = append(header, "Temp", "Feels Like", "Humidity", "Wind", "Conditions")
+		}
+		tbl := table.New(header...)
 		for _, activity := range activities {
-			tbl.AddRow(
+			name := activity.ActivityName
+			if activity.ParentID != nil {
+				// Legs of a multisport activity listed after their parent
+				name = "  " + name
+			}
+			row := []interface{}{
 				fmt.Sprintf("%d", activity.ActivityID),
-				activity.ActivityName,
+				name,
 				activity.ActivityType.TypeKey,
 				activity.StartTimeLocal.Format("2006-01-02 15:04:05"),
 				fmt.Sprintf("%.2f", activity.Distance/1000),
 				fmt.Sprintf("%.0f", activity.Duration),
-			)
+			}
+			if weather != nil {
+				w := weather[activity.ActivityID]
+				if w == nil {
+					w = &garmin.Weather{}
+				}
+				row = append(row,
+					formatWeatherValue(w.Temperature, "%.0f °C", 1, "-"),
+					formatWeatherValue(w.ApparentTemperature, "%.0f °C", 1, "-"),
+					formatWeatherValue(w.Humidity, "%.0f%%", 1, "-"),
+					strings.TrimSpace(formatWeatherValue(w.WindSpeed, "%.0f km/h", 3.6, "-")+" "+w.WindCompass),
+					w.Conditions,
+				)
+			}
+			tbl.AddRow(row...)
 		}
 		tbl.Print()
 	default:
@@ -200,17 +339,246 @@ func runGetActivity(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get activity details: %w", err)
 	}
 
-	fmt.Printf("Activity Details (ID: %d):\n", activityDetail.ActivityID)
-	fmt.Printf("  Name: %s\n", activityDetail.ActivityName)
-	fmt.Printf("  Type: %s\n", activityDetail.ActivityType.TypeKey)
-	fmt.Printf("  Date: %s\n", activityDetail.StartTimeLocal.Format("2006-01-02 15:04:05"))
-	fmt.Printf("  Distance: %.2f km\n", activityDetail.Distance/1000)
-	fmt.Printf("  Duration: %.0f s\n", activityDetail.Duration)
-	fmt.Printf("  Description: %s\n", activityDetail.Description)
+	switch outputFormat := viper.GetString("output.format"); outputFormat {
+	case "json":
+		return printJSON(activityDetail)
+	case "yaml":
+		return printYAML(activityDetail)
+	case "table":
+		printActivityDetail(activityDetail)
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+}
+
+// printActivityDetail renders the summary, metrics and laps of an activity as tables
+func printActivityDetail(detail *garmin.ActivityDetail) {
+	fmt.Printf("Activity Details (ID: %d):\n", detail.ActivityID)
+	summary := table.New("Field", "Value")
+	summary.AddRow("Name", detail.ActivityName)
+	summary.AddRow("Type", detail.ActivityType.TypeKey)
+	summary.AddRow("Date", detail.StartTimeLocal.Format("2006-01-02 15:04:05"))
+	if detail.LocationName != "" {
+		summary.AddRow("Location", detail.LocationName)
+	}
+	summary.AddRow("Distance", fmt.Sprintf("%.2f km", detail.Distance/1000))
+	summary.AddRow("Duration", formatDuration(detail.Duration))
+	summary.AddRow("Moving Time", formatDuration(detail.MovingDuration))
+	summary.AddRow("Elevation", fmt.Sprintf("+%.0f / -%.0f m", detail.ElevationGain, detail.ElevationLoss))
+	summary.AddRow("Calories", fmt.Sprintf("%.0f", detail.Calories))
+	if detail.Device.Manufacturer != "" || detail.Device.DeviceID != "" {
+		summary.AddRow("Device", strings.TrimSpace(detail.Device.Manufacturer+" "+detail.Device.DeviceID))
+	}
+	if detail.TrainingEffect.Aerobic != 0 || detail.TrainingEffect.Anaerobic != 0 {
+		summary.AddRow("Training Effect", fmt.Sprintf("%.1f aerobic / %.1f anaerobic %s",
+			detail.TrainingEffect.Aerobic, detail.TrainingEffect.Anaerobic, detail.TrainingEffect.Label))
+	}
+	if detail.TrainingEffect.Load != 0 {
+		summary.AddRow("Training Load", fmt.Sprintf("%.0f", detail.TrainingEffect.Load))
+	}
+	if detail.Description != "" {
+		summary.AddRow("Description", detail.Description)
+	}
+	if w := detail.Weather; w != nil {
+		conditions := formatWeatherValue(w.Temperature, "%.0f °C", 1, "")
+		if w.ApparentTemperature != nil {
+			conditions += formatWeatherValue(w.ApparentTemperature, " (feels like %.0f °C)", 1, "")
+		}
+		if w.Humidity != nil {
+			conditions += formatWeatherValue(w.Humidity, ", %.0f%% humidity", 1, "")
+		}
+		if w.Conditions != "" {
+			conditions += ", " + w.Conditions
+		}
+		summary.AddRow("Weather", strings.TrimPrefix(conditions, ", "))
+		if w.WindSpeed != nil {
+			wind := strings.TrimSpace(formatWeatherValue(w.WindSpeed, "%.0f km/h", 3.6, "") + " " + w.WindCompass)
+			if w.WindGust != nil {
+				wind += formatWeatherValue(w.WindGust, ", gusts %.0f km/h", 3.6, "")
+			}
+			summary.AddRow("Wind", wind)
+		}
+	}
+	summary.Print()
+
+	fmt.Println()
+	metrics := table.New("Metric", "Average", "Max", "Min")
+	addMetricRow(metrics, "Heart Rate (bpm)", detail.HeartRate, "%.0f")
+	addMetricRow(metrics, "Speed (km/h)", scaleMetric(detail.Speed, 3.6), "%.1f")
+	addMetricRow(metrics, "Power (W)", detail.Power, "%.0f")
+	addMetricRow(metrics, "Cadence (spm/rpm)", detail.Cadence, "%.0f")
+	metrics.Print()
+
+	if len(detail.Children) > 0 {
+		fmt.Println()
+		legs := table.New("Leg", "ID", "Type", "Distance (km)", "Time", "Avg HR")
+		for _, leg := range detail.Children {
+			legs.AddRow(
+				leg.Leg,
+				leg.ActivityID,
+				leg.ActivityType.TypeKey,
+				fmt.Sprintf("%.2f", leg.Distance/1000),
+				formatDuration(leg.Duration),
+				fmt.Sprintf("%.0f", leg.HeartRate.Average),
+			)
+		}
+		legs.Print()
+	}
+
+	if len(detail.Laps) == 0 {
+		return
+	}
+	fmt.Println()
+	laps := table.New("Lap", "Distance (km)", "Time", "Avg Speed (km/h)", "Avg HR", "Max HR", "Avg Power", "Avg Cadence", "Elev Gain (m)")
+	for _, lap := range detail.Laps {
+		laps.AddRow(
+			lap.Index,
+			fmt.Sprintf("%.2f", lap.Distance/1000),
+			formatDuration(lap.Duration),
+			fmt.Sprintf("%.1f", lap.Speed.Average*3.6),
+			fmt.Sprintf("%.0f", lap.HeartRate.Average),
+			fmt.Sprintf("%.0f", lap.HeartRate.Max),
+			fmt.Sprintf("%.0f", lap.Power.Average),
+			fmt.Sprintf("%.0f", lap.Cadence.Average),
+			fmt.Sprintf("%.0f", lap.ElevationGain),
+		)
+	}
+	laps.Print()
+}
+
+// addMetricRow adds a metric to the table, skipping metrics that were not recorded
+func addMetricRow(tbl table.Table, name string, m garmin.Metric, format string) {
+	if m.Average == 0 && m.Max == 0 {
+		return
+	}
+	minValue := "-"
+	if m.Min != 0 {
+		minValue = fmt.Sprintf(format, m.Min)
+	}
+	tbl.AddRow(name, fmt.Sprintf(format, m.Average), fmt.Sprintf(format, m.Max), minValue)
+}
+
+// formatWeatherValue formats a weather value multiplied by scale, or returns
+// missing when it was not reported
+func formatWeatherValue(value *float64, format string, scale float64, missing string) string {
+	if value == nil {
+		return missing
+	}
+	return fmt.Sprintf(format, *value*scale)
+}
+
+func scaleMetric(m garmin.Metric, factor float64) garmin.Metric {
+	return garmin.Metric{Average: m.Average * factor, Max: m.Max * factor, Min: m.Min * factor}
+}
+
+// formatDuration formats a duration in seconds as h:mm:ss
+func formatDuration(seconds float64) string {
+	d := time.Duration(seconds * float64(time.Second)).Round(time.Second)
+	h := int(d.Hours())
+	m := int(d.Minutes()) % 60
+	s := int(d.Seconds()) % 60
+	return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+}
+
+func runActivitySamples(cmd *cobra.Command, args []string) error {
+	activityID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid activity ID: %w", err)
+	}
+
+	garminClient, err := newGarminClient()
+	if err != nil {
+		return err
+	}
+
+	samples, err := garminClient.GetActivitySamples(activityID)
+	if err != nil {
+		return fmt.Errorf("failed to get activity samples: %w", err)
+	}
+
+	columns := []struct {
+		name   string
+		series garmin.Series
+	}{
+		{"latitude", samples.Latitude},
+		{"longitude", samples.Longitude},
+		{"distance", samples.Distance},
+		{"heart_rate", samples.HeartRate},
+		{"speed", samples.Speed},
+		{"power", samples.Power},
+		{"cadence", samples.Cadence},
+		{"elevation", samples.Elevation},
+		{"temperature", samples.Temperature},
+	}
+	header := []string{"timestamp"}
+	for _, col := range columns {
+		header = append(header, col.name)
+	}
+	row := func(i int) []string {
+		values := []string{""}
+		if !samples.Timestamps[i].IsZero() {
+			values[0] = samples.Timestamps[i].Format(time.RFC3339)
+		}
+		for _, col := range columns {
+			if math.IsNaN(col.series[i]) {
+				values = append(values, "")
+			} else {
+				values = append(values, strconv.FormatFloat(col.series[i], 'f', -1, 64))
+			}
+		}
+		return values
+	}
+
+	switch outputFormat := viper.GetString("output.format"); outputFormat {
+	case "json":
+		return printJSON(samples)
+	case "csv":
+		writer := csv.NewWriter(os.Stdout)
+		defer writer.Flush()
+
+		writer.Write(header)
+		for i := 0; i < samples.Len(); i++ {
+			writer.Write(row(i))
+		}
+	case "table":
+		headerArgs := make([]interface{}, len(header))
+		for i, h := range header {
+			headerArgs[i] = h
+		}
+		tbl := table.New(headerArgs...)
+		for i := 0; i < samples.Len(); i++ {
+			values := row(i)
+			rowArgs := make([]interface{}, len(values))
+			for j, v := range values {
+				rowArgs[j] = v
+			}
+			tbl.AddRow(rowArgs...)
+		}
+		tbl.Print()
+	default:
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
 
 	return nil
 }
 
+// newProgressBar creates the progress bar shown by batch commands
+func newProgressBar(total int, description string) *progressbar.ProgressBar {
+	return progressbar.NewOptions(total,
+		progressbar.OptionEnableColorCodes(true),
+		progressbar.OptionShowBytes(false),
+		progressbar.OptionSetWidth(15),
+		progressbar.OptionSetDescription(description),
+		progressbar.OptionSetTheme(progressbar.Theme{
+			Saucer:        "[green]=[reset]",
+			SaucerPadding: " ",
+			BarStart:      "[ ",
+			BarEnd:        " ]",
+		}),
+	)
+}
+
 func runDownloadActivity(cmd *cobra.Command, args []string) error {
 	var wg sync.WaitGroup
 	const concurrencyLimit = 5 // Limit concurrent downloads
@@ -228,10 +596,17 @@ func runDownloadActivity(cmd *cobra.Command, args []string) error {
 
 	var activitiesToDownload []garmin.Activity
 
+	// The original FIT file of a multisport activity holds every leg; exports
+	// are made per leg
+	perLeg := !downloadOriginal && downloadFormat != "fit"
+
 	if downloadAll || len(args) == 0 {
 		opts := garmin.ActivityOptions{
 			ActivityType: activityType,
 		}
+		if perLeg {
+			opts.Multisport = garmin.MultisportFlatten
+		}
 
 		if activityDateFrom != "" {
 			opts.DateFrom, err = time.Parse("2006-01-02", activityDateFrom)
@@ -268,24 +643,19 @@ func runDownloadActivity(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("failed to get activity details for download: %w", err)
 		}
 		activitiesToDownload = []garmin.Activity{activityDetail.Activity}
+		if perLeg && len(activityDetail.Children) > 0 {
+			activitiesToDownload = activitiesToDownload[:0]
+			for _, leg := range activityDetail.Children {
+				activitiesToDownload = append(activitiesToDownload, leg.Activity)
+			}
+		}
 	} else {
 		return fmt.Errorf("invalid arguments: specify an activity ID or use --all with filters")
 	}
 
 	fmt.Printf("Starting download of %d activities...\n", len(activitiesToDownload))
 
-	bar := progressbar.NewOptions(len(activitiesToDownload),
-		progressbar.OptionEnableColorCodes(true),
-		progressbar.OptionShowBytes(false),
-		progressbar.OptionSetWidth(15),
-		progressbar.OptionSetDescription("Downloading activities..."),
-		progressbar.OptionSetTheme(progressbar.Theme{
-			Saucer:        "[green]=[reset]",
-			SaucerPadding: " ",
-			BarStart:      "[ ",
-			BarEnd:        " ]",
-		}),
-	)
+	bar := newProgressBar(len(activitiesToDownload), "Downloading activities...")
 
 	for _, activity := range activitiesToDownload {
 		wg.Add(1)
@@ -335,19 +705,24 @@ func runDownloadActivity(cmd *cobra.Command, args []string) error {
 
 				fmt.Printf("Activity %d summary exported to %s\n", activity.ActivityID, outputPath)
 			} else {
+				// Original files are named after the file inside Garmin's zip archive
+				// (e.g. 12345_ACTIVITY.fit), so existing downloads are matched by prefix
 				filename := fmt.Sprintf("%d.%s", activity.ActivityID, downloadFormat)
-				if downloadOriginal {
-					filename = fmt.Sprintf("%d_original.fit", activity.ActivityID) // Assuming original is .fit
+				pattern := filepath.Join(outputDir, filename)
+				if downloadOriginal || downloadFormat == "fit" {
+					filename = ""
+					pattern = filepath.Join(outputDir, fmt.Sprintf("%d_*", activity.ActivityID))
 				}
-				outputPath := filepath.Join(outputDir, filename)
 
 				// Check if file already exists
-				if _, err := os.Stat(outputPath); err == nil {
-					fmt.Printf("Skipping activity %d: file already exists at %s\n", activity.ActivityID, outputPath)
+				existing, err := filepath.Glob(pattern)
+				if err != nil {
+					fmt.Printf("Warning: Failed to check for existing files for activity %d: %v\n", activity.ActivityID, err)
 					bar.Add(1)
 					return
-				} else if !os.IsNotExist(err) {
-					fmt.Printf("Warning: Failed to check existence of file %s for activity %d: %v\n", outputPath, activity.ActivityID, err)
+				}
+				if len(existing) > 0 {
+					fmt.Printf("Skipping activity %d: file already exists at %s\n", activity.ActivityID, existing[0])
 					bar.Add(1)
 					return
 				}
@@ -356,16 +731,20 @@ func runDownloadActivity(cmd *cobra.Command, args []string) error {
 					Format:    downloadFormat,
 					OutputDir: outputDir,
 					Original:  downloadOriginal,
-					Filename:  filename, // Pass filename to opts
+					Filename:  filename,
 				}
 
-				fmt.Printf("Downloading activity %d in %s format to %s...\n", activity.ActivityID, downloadFormat, outputPath)
-				if err := garminClient.DownloadActivity(int(activity.ActivityID), opts); err != nil {
+				fmt.Printf("Downloading activity %d in %s format to %s...\n", activity.ActivityID, downloadFormat, outputDir)
+				paths, err := garminClient.DownloadActivityFiles(int(activity.ActivityID), opts)
+				if err != nil {
 					fmt.Printf("Warning: Failed to download activity %d: %v\n", activity.ActivityID, err)
 					bar.Add(1)
 					return
 				}
 
+				for _, path := range paths {
+					fmt.Printf("  wrote %s\n", path)
+				}
 				fmt.Printf("Activity %d downloaded successfully.\n", activity.ActivityID)
 			}
 			bar.Add(1)
@@ -379,6 +758,57 @@ func runDownloadActivity(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runExportActivity(cmd *cobra.Command, args []string) error {
+	activityID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid activity ID: %w", err)
+	}
+
+	format := strings.ToLower(exportFormat)
+	if !slices.Contains(export.Formats(), format) {
+		return fmt.Errorf("unsupported export format %q (supported: %s)", exportFormat, strings.Join(export.Formats(), ", "))
+	}
+
+	garminClient, err := newGarminClient()
+	if err != nil {
+		return err
+	}
+
+	detail, err := garminClient.GetActivity(activityID)
+	if err != nil {
+		return fmt.Errorf("failed to get activity details: %w", err)
+	}
+	samples, err := garminClient.GetActivitySamples(activityID)
+	if err != nil {
+		// Manual and indoor activities may have no samples; export the summary
+		fmt.Fprintf(os.Stderr, "Warning: failed to get activity samples, exporting the summary only: %v\n", err)
+		samples = &garmin.ActivitySamples{ActivityID: int64(activityID)}
+	}
+
+	if exportFile == "-" {
+		return export.Write(os.Stdout, format, detail, samples)
+	}
+
+	path := exportFile
+	if path == "" {
+		path = fmt.Sprintf("%d.%s", activityID, format)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	if err := export.Write(f, format, detail, samples); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	fmt.Printf("Exported activity %d to %s\n", activityID, path)
+	return nil
+}
+
 func runSearchActivities(cmd *cobra.Command, args []string) error {
 	query, err := cmd.Flags().GetString("query")
 	if err != nil || query == "" {
@@ -405,12 +835,5 @@ func runSearchActivities(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	fmt.Printf("Activities matching '%s':\n", query)
-	for _, activity := range activities {
-		fmt.Printf("- ID: %d, Name: %s, Type: %s, Date: %s\n",
-			activity.ActivityID, activity.ActivityName, activity.ActivityType.TypeKey,
-			activity.StartTimeLocal.Format("2006-01-02"))
-	}
-
-	return nil
+	return printActivities(activities)
 }
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+var (
+	compareActivitiesCmd = &cobra.Command{
+		Use:   "compare [activityID] [activityID...]",
+		Short: "Compare activities side by side",
+		Long: `Compare attempts at the same route or workout. The first activity is the
+baseline: the summary shows each activity's pace, heart rate, power, elevation
+and training effect with its difference from the baseline, followed by
+per-split differences.
+
+Activities are aligned by distance (--align distance, with splits of one --unit
+by default) or by elapsed time (--align time, with 5-minute splits by default);
+--split sets another split length, such as 400m or 2m. The JSON output also
+holds the activities resampled onto a common axis, for plotting.`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: runCompareActivities,
+	}
+
+	// Flags for compareActivitiesCmd
+	compareAlign string
+	compareSplit string
+	compareUnit  string
+)
+
+func init() {
+	activitiesCmd.AddCommand(compareActivitiesCmd)
+	compareActivitiesCmd.Flags().StringVar(&compareAlign, "align", garmin.AlignByDistance, "Align activities by distance or time")
+	compareActivitiesCmd.Flags().StringVar(&compareSplit, "split", "", "Split length (e.g., 1km, 1mi, 5m)")
+	compareActivitiesCmd.Flags().StringVar(&compareUnit, "unit", "km", "Pace and distance unit (km, mi)")
+}
+
+func runCompareActivities(cmd *cobra.Command, args []string) error {
+	meters, ok := distanceUnits[compareUnit]
+	if !ok {
+		return fmt.Errorf("unsupported unit %q (use km or mi)", compareUnit)
+	}
+
+	activityIDs := make([]int, len(args))
+	for i, arg := range args {
+		id, err := strconv.Atoi(arg)
+		if err != nil {
+			return fmt.Errorf("invalid activity ID %q: %w", arg, err)
+		}
+		activityIDs[i] = id
+	}
+
+	opts := garmin.CompareOptions{AlignBy: compareAlign}
+	if compareSplit != "" {
+		switch compareAlign {
+		case garmin.AlignByTime:
+			d, err := garmin.ParseDuration(compareSplit)
+			if err != nil || d <= 0 {
+				return fmt.Errorf("invalid --split %q", compareSplit)
+			}
+			opts.SplitLength = d.Seconds()
+		default:
+			length, err := garmin.ParseDistance(compareSplit)
+			if err != nil || length <= 0 {
+				return fmt.Errorf("invalid --split %q", compareSplit)
+			}
+			opts.SplitLength = length
+		}
+	} else if compareAlign == garmin.AlignByDistance {
+		opts.SplitLength = meters
+	}
+
+	garminClient, err := newGarminClient()
+	if err != nil {
+		return err
+	}
+
+	comparison, err := garminClient.CompareActivities(activityIDs, opts)
+	if err != nil {
+		return fmt.Errorf("failed to compare activities: %w", err)
+	}
+
+	switch outputFormat := viper.GetString("output.format"); outputFormat {
+	case "json":
+		return printJSON(comparison)
+	case "yaml":
+		return printYAML(comparison)
+	case "table":
+		printComparison(comparison, meters)
+	default:
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+	return nil
+}
+
+func printComparison(comparison *garmin.Comparison, meters float64) {
+	headers := []interface{}{""}
+	for _, activity := range comparison.Activities {
+		headers = append(headers, fmt.Sprintf("%d", activity.ActivityID))
+	}
+
+	// withDelta formats a value followed by its difference from the baseline
+	withDelta := func(value string, delta *float64, format string) string {
+		if delta == nil {
+			return value
+		}
+		return fmt.Sprintf("%s (%s)", value, fmt.Sprintf(format, *delta))
+	}
+	row := func(label string, cell func(garmin.ComparedActivity) string) []interface{} {
+		cells := []interface{}{label}
+		for _, activity := range comparison.Activities {
+			cells = append(cells, cell(activity))
+		}
+		return cells
+	}
+	delta := func(activity garmin.ComparedActivity, field func(*garmin.ActivityDelta) float64) *float64 {
+		if activity.Delta == nil {
+			return nil
+		}
+		d := field(activity.Delta)
+		return &d
+	}
+
+	summary := table.New(headers...)
+	summary.AddRow(row("Date", func(a garmin.ComparedActivity) string { return a.StartTime.Format("2006-01-02") })...)
+	summary.AddRow(row("Time", func(a garmin.ComparedActivity) string {
+		return withDelta(formatDuration(a.Summary.Seconds), delta(a, func(d *garmin.ActivityDelta) float64 { return d.Seconds }), "%+.0fs")
+	})...)
+	summary.AddRow(row("Distance", func(a garmin.ComparedActivity) string {
+		return withDelta(fmt.Sprintf("%.2f %s", a.Summary.Distance/meters, compareUnit),
+			delta(a, func(d *garmin.ActivityDelta) float64 { return d.Distance / meters }), "%+.2f")
+	})...)
+	summary.AddRow(row("Pace", func(a garmin.ComparedActivity) string {
+		var pace *float64
+		if a.Delta != nil && a.Delta.Pace != nil {
+			// Seconds per kilometer to seconds per unit
+			perUnit := *a.Delta.Pace * meters / 1000
+			pace = &perUnit
+		}
+		return withDelta(formatPace(a.Summary.AverageSpeed, meters, compareUnit), pace, "%+.0fs")
+	})...)
+	summary.AddRow(row("Avg HR", func(a garmin.ComparedActivity) string {
+		return withDelta(formatOptional(a.Summary.AverageHR, "%.0f"), delta(a, func(d *garmin.ActivityDelta) float64 { return d.AverageHR }), "%+.0f")
+	})...)
+	summary.AddRow(row("Avg Power", func(a garmin.ComparedActivity) string {
+		return withDelta(formatOptional(a.Summary.AveragePower, "%.0f W"), delta(a, func(d *garmin.ActivityDelta) float64 { return d.AveragePower }), "%+.0f")
+	})...)
+	summary.AddRow(row("Elevation Gain", func(a garmin.ComparedActivity) string {
+		return withDelta(fmt.Sprintf("%.0f m", a.Summary.ElevationGain), delta(a, func(d *garmin.ActivityDelta) float64 { return d.ElevationGain }), "%+.0f")
+	})...)
+	summary.AddRow(row("Aerobic TE", func(a garmin.ComparedActivity) string {
+		return withDelta(fmt.Sprintf("%.1f", a.TrainingEffect.Aerobic), delta(a, func(d *garmin.ActivityDelta) float64 { return d.AerobicEffect }), "%+.1f")
+	})...)
+	summary.AddRow(row("Anaerobic TE", func(a garmin.ComparedActivity) string {
+		return withDelta(fmt.Sprintf("%.1f", a.TrainingEffect.Anaerobic), delta(a, func(d *garmin.ActivityDelta) float64 { return d.AnaerobicEffect }), "%+.1f")
+	})...)
+	summary.Print()
+
+	if len(comparison.Splits) == 0 {
+		return
+	}
+	fmt.Println()
+	splitHeaders := append([]interface{}{"Split"}, headers[1:]...)
+	splits := table.New(splitHeaders...)
+	for _, split := range comparison.Splits {
+		cells := []interface{}{split.Index}
+		for i, stats := range split.Splits {
+			switch {
+			case stats == nil:
+				cells = append(cells, "-")
+			case comparison.AlignBy == garmin.AlignByTime:
+				cells = append(cells, withDelta(fmt.Sprintf("%.2f %s", stats.Distance/meters, compareUnit), split.Deltas[i], "%+.0fm"))
+			default:
+				cells = append(cells, withDelta(formatDuration(stats.Seconds), split.Deltas[i], "%+.0fs"))
+			}
+		}
+		splits.AddRow(cells...)
+	}
+	splits.Print()
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+var (
+	createActivitiesCmd = &cobra.Command{
+		Use:   "create",
+		Short: "Create a manual activity",
+		Long: `Create an activity that was not recorded by a Garmin device, such as a gym
+session or a race recorded on another device:
+
+  garth activities create --type strength_training --start "2024-05-01 18:30" --duration 45m --calories 320
+
+Durations take Go units (45m, 1h30m), min, or h:mm:ss; distances take m, km or
+mi (default km). The start time is local to --timezone, by default the local
+time zone. Besides the common types listed for --type, any activity type offered by
+Garmin Connect (see "garth api activity-types") is accepted.`,
+		Args: cobra.NoArgs,
+		RunE: runCreateActivity,
+	}
+
+	importCSVActivitiesCmd = &cobra.Command{
+		Use:   "import-csv [file]",
+		Short: "Create manual activities from a CSV file",
+		Long: `Create manual activities from a CSV file with a header row. The columns are
+type, start and duration (required) and name, distance, calories, description
+and privacy (optional), with the same formats as "activities create":
+
+  type,name,start,duration,distance,calories
+  strength_training,Leg day,2024-05-01 18:30,45m,,320
+  open_water_swimming,Lake swim,2024-05-02 07:00,0:40:00,1.5km,
+
+Every row is validated before any activity is created.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runImportCSVActivities,
+	}
+
+	// Flags for createActivitiesCmd
+	createType        string
+	createName        string
+	createStart       string
+	createDuration    string
+	createDistance    string
+	createCalories    float64
+	createDescription string
+	createPrivacy     string
+
+	// Flags for createActivitiesCmd and importCSVActivitiesCmd
+	manualTimezone string
+	importDryRun   bool
+)
+
+func init() {
+	activitiesCmd.AddCommand(createActivitiesCmd)
+	createActivitiesCmd.Flags().StringVar(&createType, "type", "", "Activity type (e.g. "+strings.Join(garmin.ActivityTypeKeys(), ", ")+")")
+	createActivitiesCmd.Flags().StringVar(&createName, "name", "", "Activity name (default is the activity type)")
+	createActivitiesCmd.Flags().StringVar(&createStart, "start", "", "Start time (YYYY-MM-DD HH:MM, default is now)")
+	createActivitiesCmd.Flags().StringVar(&createDuration, "duration", "", "Duration (e.g. 45m, 1h30m, 1:02:03)")
+	createActivitiesCmd.Flags().StringVar(&createDistance, "distance", "", "Distance (e.g. 10km, 5mi, 800m)")
+	createActivitiesCmd.Flags().Float64Var(&createCalories, "calories", 0, "Calories in kcal (default is estimated by Garmin)")
+	createActivitiesCmd.Flags().StringVar(&createDescription, "description", "", "Activity description")
+	createActivitiesCmd.Flags().StringVar(&createPrivacy, "privacy", "", "Privacy setting ("+strings.Join(garmin.PrivacyKeys(), ", ")+")")
+	createActivitiesCmd.Flags().StringVar(&manualTimezone, "timezone", "", "IANA time zone of the start time (default is the local time zone)")
+	_ = createActivitiesCmd.MarkFlagRequired("type")
+	_ = createActivitiesCmd.MarkFlagRequired("duration")
+
+	activitiesCmd.AddCommand(importCSVActivitiesCmd)
+	importCSVActivitiesCmd.Flags().StringVar(&manualTimezone, "timezone", "", "IANA time zone of the start times (default is the local time zone)")
+	importCSVActivitiesCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Validate the file without creating activities")
+}
+
+// manualLocation returns the time zone of --timezone, or the local time zone
+func manualLocation() (*time.Location, error) {
+	if manualTimezone == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(manualTimezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone: %w", err)
+	}
+	return loc, nil
+}
+
+func runCreateActivity(cmd *cobra.Command, args []string) error {
+	loc, err := manualLocation()
+	if err != nil {
+		return err
+	}
+
+	activity := garmin.ManualActivity{
+		Name:        createName,
+		Type:        createType,
+		Calories:    createCalories,
+		Description: createDescription,
+		Privacy:     createPrivacy,
+		Start:       time.Now().In(loc).Truncate(time.Second),
+	}
+	if createStart != "" {
+		if activity.Start, err = garmin.ParseStartTime(createStart, loc); err != nil {
+			return err
+		}
+	}
+	if activity.Duration, err = garmin.ParseDuration(createDuration); err != nil {
+		return fmt.Errorf("invalid duration: %w", err)
+	}
+	if createDistance != "" {
+		if activity.Distance, err = garmin.ParseDistance(createDistance); err != nil {
+			return fmt.Errorf("invalid distance: %w", err)
+		}
+	}
+
+	garminClient, err := newGarminClient()
+	if err != nil {
+		return err
+	}
+
+	created, err := garminClient.CreateManualActivity(activity)
+	if err != nil {
+		return fmt.Errorf("failed to create activity: %w", err)
+	}
+
+	fmt.Printf("Created activity %d (%s)\n", created.ActivityID, created.ActivityName)
+	return nil
+}
+
+func runImportCSVActivities(cmd *cobra.Command, args []string) error {
+	loc, err := manualLocation()
+	if err != nil {
+		return err
+	}
+
+	// Rows are validated against every Garmin Connect activity type when
+	// logged in; a dry run without a session uses the common types
+	garminClient, clientErr := newGarminClient()
+	if clientErr == nil {
+		if err := garminClient.LoadActivityTypes(); err != nil {
+			fmt.Printf("Warning: %v; only the common activity types are accepted\n", err)
+		}
+	} else if !importDryRun {
+		return clientErr
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", args[0], err)
+	}
+	defer f.Close()
+
+	activities, err := garmin.ReadManualActivitiesCSV(f, loc)
+	if err != nil {
+		return fmt.Errorf("invalid CSV file %s:\n%w", args[0], err)
+	}
+	if len(activities) == 0 {
+		fmt.Println("No activities found in the file.")
+		return nil
+	}
+
+	if importDryRun {
+		fmt.Printf("%d activities are valid and would be created.\n", len(activities))
+		return nil
+	}
+
+	var failed int
+	for i, activity := range activities {
+		created, err := garminClient.CreateManualActivity(activity)
+		if err != nil {
+			fmt.Printf("Failed to create activity %d (%s on %s): %v\n", i+1, activity.Type, activity.Start.Format("2006-01-02 15:04"), err)
+			failed++
+			continue
+		}
+		fmt.Printf("Created activity %d (%s)\n", created.ActivityID, created.ActivityName)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to create %d of %d activities", failed, len(activities))
+	}
+	return nil
+}
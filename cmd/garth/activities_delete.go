@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+var (
+	deleteActivitiesCmd = &cobra.Command{
+		Use:   "delete [activityID...]",
+		Short: "Delete activities",
+		Long: `Permanently delete activities from Garmin Connect.
+
+Activities are given by ID, or with --all every activity matching --filter-type,
+--from and --to is deleted; --all requires at least one of these filters. The activities are listed and must be confirmed
+interactively unless --yes is given. Before each deletion the original FIT file
+is downloaded to the backup directory; activities without one (such as manual
+entries) are backed up as JSON. If the backup fails for any other reason the
+activity is not deleted. Every deletion is recorded in the audit log (audit.log
+in the config directory).`,
+		RunE: runDeleteActivities,
+	}
+
+	// Flags for deleteActivitiesCmd
+	deleteAll       bool
+	deleteYes       bool
+	deleteDryRun    bool
+	deleteNoBackup  bool
+	deleteBackupDir string
+)
+
+func init() {
+	activitiesCmd.AddCommand(deleteActivitiesCmd)
+	deleteActivitiesCmd.Flags().BoolVar(&deleteAll, "all", false, "Delete all activities matching filters")
+	deleteActivitiesCmd.Flags().StringVar(&activityType, "filter-type", "", "Filter activities by type when using --all")
+	deleteActivitiesCmd.Flags().StringVar(&activityDateFrom, "from", "", "Start date for filtering activities (YYYY-MM-DD)")
+	deleteActivitiesCmd.Flags().StringVar(&activityDateTo, "to", "", "End date for filtering activities (YYYY-MM-DD)")
+	deleteActivitiesCmd.Flags().BoolVarP(&deleteYes, "yes", "y", false, "Delete without asking for confirmation")
+	deleteActivitiesCmd.Flags().BoolVar(&deleteDryRun, "dry-run", false, "Show the activities that would be deleted without deleting them")
+	deleteActivitiesCmd.Flags().BoolVar(&deleteNoBackup, "no-backup", false, "Do not back up activities before deleting them")
+	deleteActivitiesCmd.Flags().StringVar(&deleteBackupDir, "backup-dir", "", "Backup directory (default is backups in the config directory)")
+}
+
+func runDeleteActivities(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 && deleteAll {
+		return fmt.Errorf("invalid arguments: specify activity IDs or use --all with filters, not both")
+	}
+	if len(args) == 0 && !deleteAll {
+		return fmt.Errorf("invalid arguments: specify activity IDs or use --all with filters")
+	}
+	if deleteAll && activityType == "" && activityDateFrom == "" && activityDateTo == "" {
+		return fmt.Errorf("invalid arguments: --all requires at least one of --filter-type, --from or --to")
+	}
+
+	garminClient, err := newGarminClient()
+	if err != nil {
+		return err
+	}
+
+	var activities []garmin.Activity
+	if deleteAll {
+		opts, err := activityFilterOptions()
+		if err != nil {
+			return err
+		}
+		activities, err = garminClient.ListActivities(opts)
+		if err != nil {
+			return fmt.Errorf("failed to list activities: %w", err)
+		}
+	} else {
+		for _, arg := range args {
+			activityID, err := strconv.Atoi(arg)
+			if err != nil {
+				return fmt.Errorf("invalid activity ID %q: %w", arg, err)
+			}
+			activity, err := garminClient.GetActivitySummary(activityID)
+			if err != nil {
+				return fmt.Errorf("failed to get activity %d: %w", activityID, err)
+			}
+			activities = append(activities, *activity)
+		}
+	}
+
+	if len(activities) == 0 {
+		fmt.Println("No activities found matching the filters.")
+		return nil
+	}
+
+	if deleteDryRun {
+		fmt.Printf("Would delete %d activities:\n", len(activities))
+		return printActivities(activities)
+	}
+
+	return deleteActivities(garminClient, activities)
+}
+
+// deleteActivities asks for confirmation unless --yes was given, then backs up,
+// deletes and records each activity in the audit log. An activity whose backup
+// fails is not deleted.
+func deleteActivities(garminClient *garmin.Client, activities []garmin.Activity) error {
+	fmt.Printf("The following %d activities will be deleted:\n", len(activities))
+	if err := printActivities(activities); err != nil {
+		return err
+	}
+	if !deleteYes {
+		confirmed, err := confirm(fmt.Sprintf("Delete %d activities? [y/N] ", len(activities)))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("Aborted.")
+			return nil
+		}
+	}
+
+	backupDir := deleteBackupDir
+	if backupDir == "" {
+		backupDir = filepath.Join(userConfigDir, "backups")
+	}
+
+	var failed int
+	for _, activity := range activities {
+		var backup []string
+		if !deleteNoBackup {
+			var err error
+			backup, err = backupActivity(garminClient, activity, backupDir)
+			if err != nil {
+				fmt.Printf("Skipping activity %d: backup failed: %v\n", activity.ActivityID, err)
+				failed++
+				continue
+			}
+		}
+
+		if err := garminClient.DeleteActivity(int(activity.ActivityID)); err != nil {
+			fmt.Printf("Failed to delete activity %d: %v\n", activity.ActivityID, err)
+			failed++
+			continue
+		}
+
+		if err := appendAuditLog(auditEntry{
+			Action:       "delete",
+			ActivityID:   activity.ActivityID,
+			ActivityName: activity.ActivityName,
+			ActivityType: activity.ActivityType.TypeKey,
+			StartTime:    activity.StartTimeLocal.Format("2006-01-02 15:04:05"),
+			Backup:       backup,
+		}); err != nil {
+			fmt.Printf("Warning: %v\n", err)
+		}
+		fmt.Printf("Deleted activity %d (%s)\n", activity.ActivityID, activity.ActivityName)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d of %d activities", failed, len(activities))
+	}
+	return nil
+}
+
+// backupActivity downloads the original file of an activity, falling back to
+// its details as JSON only when there is no original (e.g. manual activities)
+func backupActivity(garminClient *garmin.Client, activity garmin.Activity, dir string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	paths, err := garminClient.DownloadActivityFiles(int(activity.ActivityID), garmin.DownloadOptions{
+		Original:  true,
+		OutputDir: dir,
+	})
+	if err == nil {
+		return paths, nil
+	}
+	if !stderrors.Is(err, garmin.ErrNoOriginalFile) {
+		return nil, err
+	}
+
+	detail, err := garminClient.GetActivity(int(activity.ActivityID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get activity details: %w", err)
+	}
+	data, err := json.MarshalIndent(detail, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%d.json", activity.ActivityID))
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write backup: %w", err)
+	}
+	return []string{path}, nil
+}
+
+// confirm asks a yes/no question on the terminal. Without a terminal it
+// fails, so destructive commands in scripts need --yes. It is a variable so
+// tests can answer for the user.
+var confirm = func(prompt string) (bool, error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return false, fmt.Errorf("confirmation required: run interactively or pass --yes")
+	}
+
+	fmt.Print(prompt)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
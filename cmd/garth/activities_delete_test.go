@@ -0,0 +1,169 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+// deleteServer serves activity 1 with an original FIT file, manual activity 2
+// without one and activity 3 whose download fails, and records deletions
+type deleteServer struct {
+	*httptest.Server
+	mu      sync.Mutex
+	deleted []string
+}
+
+func newDeleteServer(t *testing.T) *deleteServer {
+	fit := append([]byte{14, 0x10, 0, 0, 0, 0, 0, 0}, []byte(".FIT")...)
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	w, err := zw.Create("1_ACTIVITY.fit")
+	require.NoError(t, err)
+	w.Write(fit)
+	require.NoError(t, zw.Close())
+
+	s := &deleteServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			s.mu.Lock()
+			s.deleted = append(s.deleted, strings.TrimPrefix(r.URL.Path, "/activity-service/activity/"))
+			s.mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		switch r.URL.Path {
+		case "/download-service/files/activity/1":
+			w.Header().Set("Content-Type", "application/x-zip-compressed")
+			w.Write(archive.Bytes())
+		case "/download-service/files/activity/3":
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		case "/activity-service/activity/2":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"activityId": 2, "activityName": "Treadmill", "activityTypeDTO": {"typeKey": "running"}}`))
+		case "/activity-service/activity/2/splits":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"activityId": 2, "lapDTOs": []}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *deleteServer) client(t *testing.T) *garmin.Client {
+	u, _ := url.Parse(s.URL)
+	c, err := garmin.NewClient(u.Host)
+	require.NoError(t, err)
+	c.Client.Domain = u.Host
+	c.Client.AuthToken = "Bearer testtoken"
+	return c
+}
+
+// setDeleteFlags sets the delete flags and config directory for one test
+func setDeleteFlags(t *testing.T, yes bool) string {
+	dir := t.TempDir()
+	oldConfigDir, oldYes, oldNoBackup, oldBackupDir := userConfigDir, deleteYes, deleteNoBackup, deleteBackupDir
+	oldFormat := viper.GetString("output.format")
+	userConfigDir, deleteYes, deleteNoBackup, deleteBackupDir = dir, yes, false, ""
+	viper.Set("output.format", "json")
+	t.Cleanup(func() {
+		userConfigDir, deleteYes, deleteNoBackup, deleteBackupDir = oldConfigDir, oldYes, oldNoBackup, oldBackupDir
+		viper.Set("output.format", oldFormat)
+	})
+	return dir
+}
+
+func activityWithID(id int64) garmin.Activity {
+	var activity garmin.Activity
+	activity.ActivityID = id
+	activity.ActivityName = "Activity"
+	return activity
+}
+
+func TestDeleteActivities_BacksUpAndAudits(t *testing.T) {
+	server := newDeleteServer(t)
+	dir := setDeleteFlags(t, true)
+
+	err := deleteActivities(server.client(t), []garmin.Activity{activityWithID(1)})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1"}, server.deleted)
+
+	// The backup directory does not exist beforehand
+	backup := filepath.Join(dir, "backups", "1_ACTIVITY.fit")
+	assert.FileExists(t, backup)
+
+	data, err := os.ReadFile(filepath.Join(dir, "audit.log"))
+	require.NoError(t, err)
+	var entry auditEntry
+	require.NoError(t, json.Unmarshal(bytes.TrimSpace(data), &entry))
+	assert.Equal(t, "delete", entry.Action)
+	assert.Equal(t, int64(1), entry.ActivityID)
+	assert.Equal(t, []string{backup}, entry.Backup)
+}
+
+func TestDeleteActivities_JSONFallbackOnlyWithoutOriginal(t *testing.T) {
+	server := newDeleteServer(t)
+	dir := setDeleteFlags(t, true)
+
+	err := deleteActivities(server.client(t), []garmin.Activity{activityWithID(2), activityWithID(3)})
+	assert.ErrorContains(t, err, "failed to delete 1 of 2 activities")
+
+	// The manual activity is backed up as JSON; the failed download is not
+	// replaced by a JSON dump and the activity is kept
+	assert.Equal(t, []string{"2"}, server.deleted)
+	assert.FileExists(t, filepath.Join(dir, "backups", "2.json"))
+	assert.NoFileExists(t, filepath.Join(dir, "backups", "3.json"))
+
+	data, err := os.ReadFile(filepath.Join(dir, "audit.log"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, bytes.Count(data, []byte("\n")))
+}
+
+func TestDeleteActivities_RequiresConfirmation(t *testing.T) {
+	server := newDeleteServer(t)
+	setDeleteFlags(t, false)
+
+	oldConfirm := confirm
+	t.Cleanup(func() { confirm = oldConfirm })
+
+	var prompts []string
+	confirm = func(prompt string) (bool, error) {
+		prompts = append(prompts, prompt)
+		return false, nil
+	}
+	require.NoError(t, deleteActivities(server.client(t), []garmin.Activity{activityWithID(1)}))
+	assert.Equal(t, []string{"Delete 1 activities? [y/N] "}, prompts)
+	assert.Empty(t, server.deleted)
+
+	confirm = func(string) (bool, error) { return true, nil }
+	require.NoError(t, deleteActivities(server.client(t), []garmin.Activity{activityWithID(1)}))
+	assert.Equal(t, []string{"1"}, server.deleted)
+}
+
+func TestRunDeleteActivities_AllRequiresFilter(t *testing.T) {
+	setDeleteFlags(t, true)
+	oldAll, oldType, oldFrom, oldTo := deleteAll, activityType, activityDateFrom, activityDateTo
+	deleteAll, activityType, activityDateFrom, activityDateTo = true, "", "", ""
+	t.Cleanup(func() {
+		deleteAll, activityType, activityDateFrom, activityDateTo = oldAll, oldType, oldFrom, oldTo
+	})
+
+	err := runDeleteActivities(deleteActivitiesCmd, nil)
+	assert.ErrorContains(t, err, "--all requires at least one of --filter-type, --from or --to")
+}
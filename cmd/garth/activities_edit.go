@@ -0,0 +1,183 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+var (
+	editActivitiesCmd = &cobra.Command{
+		Use:   "edit [activityID]",
+		Short: "Edit activity name, type, description, privacy and gear",
+		Long: `Edit the metadata of an activity. Only the fields given as flags are changed:
+
+  garth activities edit 123456789 --name "Tempo Run" --type trail_running --privacy private
+
+Use --all instead of an activity ID to apply the same changes to every activity
+matching --filter-type, --from and --to, and --dry-run to preview the activities
+that would be changed. Bulk edits ask for confirmation unless --yes is given.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runEditActivity,
+	}
+
+	// Flags for editActivitiesCmd
+	editName        string
+	editDescription string
+	editType        string
+	editEventType   string
+	editPrivacy     string
+	editLinkGear    []string
+	editUnlinkGear  []string
+	editAll         bool
+	editDryRun      bool
+	editYes         bool
+)
+
+func init() {
+	activitiesCmd.AddCommand(editActivitiesCmd)
+	editActivitiesCmd.Flags().StringVar(&editName, "name", "", "New activity name")
+	editActivitiesCmd.Flags().StringVar(&editDescription, "description", "", "New description (empty to clear)")
+	editActivitiesCmd.Flags().StringVar(&editType, "type", "", "New activity type (e.g. "+strings.Join(garmin.ActivityTypeKeys(), ", ")+")")
+	editActivitiesCmd.Flags().StringVar(&editEventType, "event-type", "", "New event type ("+strings.Join(garmin.EventTypeKeys(), ", ")+")")
+	editActivitiesCmd.Flags().StringVar(&editPrivacy, "privacy", "", "New privacy setting ("+strings.Join(garmin.PrivacyKeys(), ", ")+")")
+	editActivitiesCmd.Flags().StringSliceVar(&editLinkGear, "gear", nil, "Link gear by UUID (repeatable)")
+	editActivitiesCmd.Flags().StringSliceVar(&editUnlinkGear, "remove-gear", nil, "Unlink gear by UUID (repeatable)")
+
+	editActivitiesCmd.Flags().BoolVar(&editAll, "all", false, "Edit all activities matching filters")
+	editActivitiesCmd.Flags().StringVar(&activityType, "filter-type", "", "Filter activities by type when using --all")
+	editActivitiesCmd.Flags().StringVar(&activityDateFrom, "from", "", "Start date for filtering activities (YYYY-MM-DD)")
+	editActivitiesCmd.Flags().StringVar(&activityDateTo, "to", "", "End date for filtering activities (YYYY-MM-DD)")
+	editActivitiesCmd.Flags().BoolVar(&editDryRun, "dry-run", false, "Show the activities that would be changed without changing them")
+	editActivitiesCmd.Flags().BoolVarP(&editYes, "yes", "y", false, "Apply bulk edits without asking for confirmation")
+}
+
+// activityFilterOptions builds list options from the --filter-type/--type,
+// --from and --to flags
+func activityFilterOptions() (garmin.ActivityOptions, error) {
+	opts := garmin.ActivityOptions{ActivityType: activityType}
+
+	var err error
+	if activityDateFrom != "" {
+		opts.DateFrom, err = time.Parse("2006-01-02", activityDateFrom)
+		if err != nil {
+			return opts, fmt.Errorf("invalid date format for --from: %w", err)
+		}
+	}
+	if activityDateTo != "" {
+		opts.DateTo, err = time.Parse("2006-01-02", activityDateTo)
+		if err != nil {
+			return opts, fmt.Errorf("invalid date format for --to: %w", err)
+		}
+	}
+	return opts, nil
+}
+
+func runEditActivity(cmd *cobra.Command, args []string) error {
+	flags := cmd.Flags()
+	patch := garmin.ActivityPatch{
+		LinkGear:   editLinkGear,
+		UnlinkGear: editUnlinkGear,
+	}
+	if flags.Changed("name") {
+		patch.Name = &editName
+	}
+	if flags.Changed("description") {
+		patch.Description = &editDescription
+	}
+	if flags.Changed("type") {
+		patch.ActivityType = &editType
+	}
+	if flags.Changed("event-type") {
+		patch.EventType = &editEventType
+	}
+	if flags.Changed("privacy") {
+		patch.Privacy = &editPrivacy
+	}
+	if patch.IsEmpty() {
+		return fmt.Errorf("nothing to change: specify at least one of --name, --description, --type, --event-type, --privacy, --gear or --remove-gear")
+	}
+
+	garminClient, err := newGarminClient()
+	if err != nil {
+		return err
+	}
+	if patch.ActivityType != nil {
+		if err := garminClient.LoadActivityTypes(); err != nil {
+			return err
+		}
+	}
+	if err := patch.Validate(); err != nil {
+		return err
+	}
+
+	var activities []garmin.Activity
+	switch {
+	case len(args) == 1 && !editAll:
+		activityID, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid activity ID: %w", err)
+		}
+		activity, err := garminClient.GetActivitySummary(activityID)
+		if err != nil {
+			return fmt.Errorf("failed to get activity details: %w", err)
+		}
+		activities = []garmin.Activity{*activity}
+	case len(args) == 0 && editAll:
+		opts, err := activityFilterOptions()
+		if err != nil {
+			return err
+		}
+		activities, err = garminClient.ListActivities(opts)
+		if err != nil {
+			return fmt.Errorf("failed to list activities: %w", err)
+		}
+	default:
+		return fmt.Errorf("invalid arguments: specify an activity ID or use --all with filters")
+	}
+
+	if len(activities) == 0 {
+		fmt.Println("No activities found matching the filters.")
+		return nil
+	}
+
+	if editDryRun {
+		fmt.Printf("Would apply %s to %d activities:\n", patch, len(activities))
+		return printActivities(activities)
+	}
+
+	if editAll && !editYes {
+		fmt.Printf("The following %d activities will be changed (%s):\n", len(activities), patch)
+		if err := printActivities(activities); err != nil {
+			return err
+		}
+		confirmed, err := confirm(fmt.Sprintf("Apply changes to %d activities? [y/N] ", len(activities)))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("Aborted.")
+			return nil
+		}
+	}
+
+	var failed int
+	for _, activity := range activities {
+		if err := garminClient.UpdateActivity(int(activity.ActivityID), patch); err != nil {
+			fmt.Printf("Failed to update activity %d: %v\n", activity.ActivityID, err)
+			failed++
+			continue
+		}
+		fmt.Printf("Updated activity %d (%s)\n", activity.ActivityID, activity.ActivityName)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to update %d of %d activities", failed, len(activities))
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+var (
+	intervalsActivitiesCmd = &cobra.Command{
+		Use:   "intervals [activityID]",
+		Short: "Detect work and rest intervals in an activity",
+		Long: `Detect work and rest intervals from changes in power or pace, independently
+of the laps recorded on the device, and summarise each interval. Power is used
+when the activity has power samples; use --metric to choose.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runActivityIntervals,
+	}
+
+	// Flags for intervalsActivitiesCmd
+	intervalsMetric      string
+	intervalsMinDuration time.Duration
+	intervalsUnit        string
+)
+
+func init() {
+	activitiesCmd.AddCommand(intervalsActivitiesCmd)
+	intervalsActivitiesCmd.Flags().StringVar(&intervalsMetric, "metric", "", "Metric to detect intervals on (power, speed)")
+	intervalsActivitiesCmd.Flags().DurationVar(&intervalsMinDuration, "min-duration", 30*time.Second, "Shortest interval to keep")
+	intervalsActivitiesCmd.Flags().StringVar(&intervalsUnit, "unit", "km", "Pace distance unit (km, mi)")
+}
+
+func runActivityIntervals(cmd *cobra.Command, args []string) error {
+	meters, ok := distanceUnits[intervalsUnit]
+	if !ok {
+		return fmt.Errorf("unsupported unit %q (use km or mi)", intervalsUnit)
+	}
+
+	activityID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid activity ID: %w", err)
+	}
+
+	garminClient, err := newGarminClient()
+	if err != nil {
+		return err
+	}
+
+	samples, err := garminClient.GetActivitySamples(activityID)
+	if err != nil {
+		return fmt.Errorf("failed to get activity samples: %w", err)
+	}
+	analysis, err := garmin.DetectIntervals(samples, garmin.IntervalOptions{
+		Metric:      intervalsMetric,
+		MinDuration: intervalsMinDuration,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to detect intervals: %w", err)
+	}
+
+	switch outputFormat := viper.GetString("output.format"); outputFormat {
+	case "json":
+		return printJSON(analysis)
+	case "yaml":
+		return printYAML(analysis)
+	case "table":
+		tbl := table.New("#", "Kind", "Start", "Time", "Distance", "Pace", "Avg Power", "Avg HR", "Cadence")
+		for _, interval := range analysis.Intervals {
+			tbl.AddRow(interval.Index, interval.Kind,
+				interval.Start.Local().Format("15:04:05"),
+				formatDuration(interval.Seconds),
+				fmt.Sprintf("%.2f %s", interval.Distance/meters, intervalsUnit),
+				formatPace(interval.AverageSpeed, meters, intervalsUnit),
+				formatOptional(interval.AveragePower, "%.0f W"),
+				formatOptional(interval.AverageHR, "%.0f"),
+				formatOptional(interval.AverageCadence, "%.0f"))
+		}
+		tbl.Print()
+
+		threshold := fmt.Sprintf("%.0f W", analysis.Threshold)
+		if analysis.Metric == "speed" {
+			threshold = formatPace(analysis.Threshold, meters, intervalsUnit)
+		}
+		fmt.Printf("\nWork/rest threshold: %s\n", threshold)
+	default:
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+	return nil
+}
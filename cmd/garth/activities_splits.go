@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+var (
+	splitsActivitiesCmd = &cobra.Command{
+		Use:   "splits [activityID]",
+		Short: "Show automatic distance splits of an activity",
+		Long: `Divide an activity into kilometer or mile splits computed from its samples,
+independently of the laps recorded on the device, with the pace, heart rate,
+elevation and cadence of each split.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runActivitySplits,
+	}
+
+	// Flags for splitsActivitiesCmd
+	splitsUnit string
+)
+
+// distanceUnits maps distance unit names to meters
+var distanceUnits = map[string]float64{
+	"km": 1000,
+	"mi": 1609.344,
+}
+
+func init() {
+	activitiesCmd.AddCommand(splitsActivitiesCmd)
+	splitsActivitiesCmd.Flags().StringVar(&splitsUnit, "unit", "km", "Split distance unit (km, mi)")
+}
+
+func runActivitySplits(cmd *cobra.Command, args []string) error {
+	meters, ok := distanceUnits[splitsUnit]
+	if !ok {
+		return fmt.Errorf("unsupported unit %q (use km or mi)", splitsUnit)
+	}
+
+	activityID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid activity ID: %w", err)
+	}
+
+	garminClient, err := newGarminClient()
+	if err != nil {
+		return err
+	}
+
+	samples, err := garminClient.GetActivitySamples(activityID)
+	if err != nil {
+		return fmt.Errorf("failed to get activity samples: %w", err)
+	}
+	splits := garmin.Splits(samples, meters)
+
+	switch outputFormat := viper.GetString("output.format"); outputFormat {
+	case "json":
+		return printJSON(splits)
+	case "yaml":
+		return printYAML(splits)
+	case "table":
+		if len(splits) == 0 {
+			fmt.Println("No distance samples found.")
+			return nil
+		}
+		tbl := table.New("Split", "Distance", "Time", "Pace", "Avg HR", "Cadence", "Gain", "Loss")
+		for _, split := range splits {
+			tbl.AddRow(split.Index,
+				fmt.Sprintf("%.2f %s", split.Distance/meters, splitsUnit),
+				formatDuration(split.Seconds),
+				formatPace(split.AverageSpeed, meters, splitsUnit),
+				formatOptional(split.AverageHR, "%.0f"),
+				formatOptional(split.AverageCadence, "%.0f"),
+				fmt.Sprintf("%.0f m", split.ElevationGain),
+				fmt.Sprintf("%.0f m", split.ElevationLoss))
+		}
+		tbl.Print()
+	default:
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+	return nil
+}
+
+// formatPace formats a speed in meters per second as a pace per unit
+func formatPace(speed, meters float64, unit string) string {
+	if speed <= 0 {
+		return "-"
+	}
+	seconds := int(meters/speed + 0.5)
+	return fmt.Sprintf("%d:%02d /%s", seconds/60, seconds%60, unit)
+}
+
+// formatOptional formats a value that is zero when it was not recorded
+func formatOptional(value float64, format string) string {
+	if value == 0 {
+		return "-"
+	}
+	return fmt.Sprintf(format, value)
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+var (
+	syncActivitiesCmd = &cobra.Command{
+		Use:   "sync",
+		Short: "Mirror activities into a local directory",
+		Long: `Keep a local directory in sync with your Garmin Connect activities.
+
+A manifest in the directory (` + garmin.MirrorManifestFile + `) records each activity's files
+with their checksums and modification times, so each run only downloads
+activities that are new, were edited on Garmin Connect, or whose local files are
+missing or modified. With --prune, activities deleted on Garmin Connect are
+removed from the mirror.
+
+The layout is set with --template using the placeholders {id}, {year}, {month},
+{day}, {date}, {type}, {name} and {ext}, for example:
+
+  garth activities sync --dir ~/activities --format fit,gpx --template "{year}/{date}_{name}_{id}.{ext}"`,
+		Args: cobra.NoArgs,
+		RunE: runSyncActivities,
+	}
+
+	// Flags for syncActivitiesCmd
+	syncDir      string
+	syncFormats  []string
+	syncTemplate string
+	syncPrune    bool
+)
+
+func init() {
+	activitiesCmd.AddCommand(syncActivitiesCmd)
+	syncActivitiesCmd.Flags().StringVar(&syncDir, "dir", "", "Mirror directory")
+	syncActivitiesCmd.Flags().StringSliceVar(&syncFormats, "format", []string{"fit"}, "Formats to download (fit, gpx, tcx, kml, csv)")
+	syncActivitiesCmd.Flags().StringVar(&syncTemplate, "template", garmin.DefaultMirrorTemplate, "Path template for activity files")
+	syncActivitiesCmd.Flags().BoolVar(&syncPrune, "prune", false, "Remove activities deleted on Garmin Connect from the mirror")
+	syncActivitiesCmd.Flags().StringVar(&activityType, "type", "", "Filter activities by type (e.g., running, cycling)")
+	syncActivitiesCmd.Flags().StringVar(&activityDateFrom, "from", "", "Start date for filtering activities (YYYY-MM-DD)")
+	syncActivitiesCmd.Flags().StringVar(&activityDateTo, "to", "", "End date for filtering activities (YYYY-MM-DD)")
+	_ = syncActivitiesCmd.MarkFlagRequired("dir")
+}
+
+func runSyncActivities(cmd *cobra.Command, args []string) error {
+	filters, err := activityFilterOptions()
+	if err != nil {
+		return err
+	}
+
+	garminClient, err := newGarminClient()
+	if err != nil {
+		return err
+	}
+
+	formats := make([]string, len(syncFormats))
+	for i, format := range syncFormats {
+		formats[i] = strings.ToLower(strings.TrimSpace(format))
+	}
+
+	outputFormat := viper.GetString("output.format")
+	opts := garmin.MirrorOptions{
+		Dir:      syncDir,
+		Formats:  formats,
+		Template: syncTemplate,
+		Filters:  filters,
+		Prune:    syncPrune,
+	}
+	if outputFormat == "table" {
+		opts.OnResult = func(result garmin.MirrorResult) {
+			switch result.Status {
+			case garmin.MirrorUnchanged:
+			case garmin.MirrorFailed:
+				fmt.Printf("%-8s %d %s: %s\n", result.Status, result.ActivityID, result.Name, result.Reason)
+			default:
+				fmt.Printf("%-8s %d %s\n", result.Status, result.ActivityID, strings.Join(result.Files, ", "))
+			}
+		}
+	}
+
+	results, err := garminClient.Mirror(context.Background(), opts)
+	if err != nil {
+		return fmt.Errorf("failed to sync activities: %w", err)
+	}
+
+	counts := map[garmin.MirrorStatus]int{}
+	for _, result := range results {
+		counts[result.Status]++
+	}
+
+	switch outputFormat {
+	case "json":
+		if err := printJSON(results); err != nil {
+			return err
+		}
+	case "yaml":
+		if err := printYAML(results); err != nil {
+			return err
+		}
+	case "table":
+		fmt.Printf("%d new, %d updated, %d unchanged, %d pruned, %d failed\n",
+			counts[garmin.MirrorNew], counts[garmin.MirrorUpdated], counts[garmin.MirrorUnchanged],
+			counts[garmin.MirrorPruned], counts[garmin.MirrorFailed])
+	default:
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+
+	if counts[garmin.MirrorFailed] > 0 {
+		return fmt.Errorf("%d activities failed to sync", counts[garmin.MirrorFailed])
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+func TestFetchWeather_SkipsFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/activity-service/activity/1/weather":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"temp": 50, "windDirectionCompassPoint": "ssw"}`))
+		case "/activity-service/activity/2/weather":
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	garminClient, err := garmin.NewClient(u.Host)
+	require.NoError(t, err)
+	garminClient.Client.Domain = u.Host
+	garminClient.Client.AuthToken = "Bearer testtoken"
+
+	weather := fetchWeather(garminClient, []garmin.Activity{{ActivityID: 1}, {ActivityID: 2}, {ActivityID: 3}})
+
+	require.NotNil(t, weather[1])
+	assert.Equal(t, "SSW", weather[1].WindCompass)
+	assert.Nil(t, weather[2])
+	assert.Nil(t, weather[3])
+	assert.NoError(t, printActivityList([]garmin.Activity{{ActivityID: 1}, {ActivityID: 2}}, weather))
+}
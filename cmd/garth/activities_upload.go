@@ -0,0 +1,210 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+var (
+	uploadActivitiesCmd = &cobra.Command{
+		Use:   "upload [path...]",
+		Short: "Upload FIT, GPX and TCX files",
+		Long: `Upload activity files to Garmin Connect. Paths may be files or directories;
+use --recursive to include subdirectories.
+
+Uploaded files are recorded by content hash in a manifest (uploads.json in the
+config directory), so files that were already uploaded are skipped and an
+interrupted upload can simply be run again. Files Garmin reports as duplicates
+are recorded as well. A report of created, duplicate and failed files is
+printed at the end.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: runUploadActivities,
+	}
+
+	// Flags for uploadActivitiesCmd
+	uploadRecursive bool
+	uploadWorkers   int
+	uploadManifest  string
+)
+
+func init() {
+	activitiesCmd.AddCommand(uploadActivitiesCmd)
+	uploadActivitiesCmd.Flags().BoolVarP(&uploadRecursive, "recursive", "r", false, "Include files in subdirectories")
+	uploadActivitiesCmd.Flags().IntVar(&uploadWorkers, "workers", 4, "Number of concurrent uploads")
+	uploadActivitiesCmd.Flags().StringVar(&uploadManifest, "manifest", "", "Upload manifest (default is uploads.json in the config directory)")
+}
+
+func runUploadActivities(cmd *cobra.Command, args []string) error {
+	files, err := collectUploadFiles(args, uploadRecursive)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		fmt.Printf("No %s files found.\n", strings.Join(garmin.UploadExtensions, ", "))
+		return nil
+	}
+
+	manifestPath := uploadManifest
+	if manifestPath == "" {
+		manifestPath = filepath.Join(userConfigDir, "uploads.json")
+	}
+	manifest, err := garmin.LoadUploadManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+
+	garminClient, err := newGarminClient()
+	if err != nil {
+		return err
+	}
+
+	workers := uploadWorkers
+	if workers < 1 {
+		workers = 1
+	}
+
+	fmt.Printf("Uploading %d files...\n", len(files))
+	bar := newProgressBar(len(files), "Uploading activities...")
+
+	results := make([]garmin.UploadResult, len(files))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = uploadFile(garminClient, manifest, files[i])
+				bar.Add(1)
+			}
+		}()
+	}
+	for i := range files {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	bar.Finish()
+	fmt.Println()
+
+	return printUploadReport(results)
+}
+
+// uploadFile uploads one file unless the manifest already records its content
+func uploadFile(garminClient *garmin.Client, manifest *garmin.UploadManifest, path string) garmin.UploadResult {
+	hash, err := garmin.HashFile(path)
+	if err != nil {
+		return garmin.UploadResult{Path: path, Status: garmin.UploadFailed, Reason: err.Error()}
+	}
+
+	if previous, ok := manifest.Lookup(hash); ok {
+		return garmin.UploadResult{
+			Path:       path,
+			SHA256:     hash,
+			Status:     garmin.UploadDuplicate,
+			ActivityID: previous.ActivityID,
+			Reason:     fmt.Sprintf("already uploaded from %s", previous.Path),
+		}
+	}
+
+	result, err := garminClient.UploadActivityFile(path)
+	if err != nil {
+		return garmin.UploadResult{Path: path, SHA256: hash, Status: garmin.UploadFailed, Reason: err.Error()}
+	}
+	if err := manifest.Record(*result); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	return *result
+}
+
+// collectUploadFiles returns the uploadable files among paths, expanding
+// directories, in a stable order
+func collectUploadFiles(paths []string, recursive bool) ([]string, error) {
+	isUploadable := func(path string) bool {
+		return slices.Contains(garmin.UploadExtensions, strings.ToLower(filepath.Ext(path)))
+	}
+
+	var files []string
+	for _, root := range paths {
+		info, err := os.Stat(root)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			if !isUploadable(root) {
+				return nil, fmt.Errorf("%s is not a %s file", root, strings.Join(garmin.UploadExtensions, ", "))
+			}
+			files = append(files, root)
+			continue
+		}
+
+		err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if path != root && !recursive {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if isUploadable(path) {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", root, err)
+		}
+	}
+
+	sort.Strings(files)
+	return slices.Compact(files), nil
+}
+
+func printUploadReport(results []garmin.UploadResult) error {
+	counts := map[garmin.UploadStatus]int{}
+	for _, result := range results {
+		counts[result.Status]++
+	}
+
+	switch outputFormat := viper.GetString("output.format"); outputFormat {
+	case "json":
+		if err := printJSON(results); err != nil {
+			return err
+		}
+	case "yaml":
+		if err := printYAML(results); err != nil {
+			return err
+		}
+	default:
+		tbl := table.New("Status", "File", "Activity ID", "Reason")
+		for _, result := range results {
+			activityID := ""
+			if result.ActivityID > 0 {
+				activityID = fmt.Sprintf("%d", result.ActivityID)
+			}
+			tbl.AddRow(result.Status, result.Path, activityID, result.Reason)
+		}
+		tbl.Print()
+		fmt.Printf("\n%d created, %d duplicate, %d failed\n",
+			counts[garmin.UploadCreated], counts[garmin.UploadDuplicate], counts[garmin.UploadFailed])
+	}
+
+	if counts[garmin.UploadFailed] > 0 {
+		return fmt.Errorf("%d of %d files failed to upload", counts[garmin.UploadFailed], len(results))
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+	"github.com/sstent/go-garth/config"
+)
+
+var (
+	zonesActivitiesCmd = &cobra.Command{
+		Use:   "zones [activityID]",
+		Short: "Show time in heart rate, power and pace zones",
+		Long: `Show the time an activity spent in each training zone, computed from its
+samples. Heart rate zones are your zones on Garmin Connect. Power and pace zones
+are used when configured in the config file:
+
+  zones:
+    power:
+      - {name: Recovery, max: 150}
+      - {name: Endurance, min: 150, max: 210}
+      - {name: Threshold, min: 210}
+    pace:
+      - {name: Easy, max: "5:15/km"}
+      - {name: Tempo, min: "5:15/km", max: "4:30/km"}
+      - {name: Fast, min: "4:30/km"}
+
+Use --period week or --period month instead of an activity ID to sum the time
+in zone of every activity in the current week or month, or in the one
+containing --from, for example to check a polarized training distribution.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runActivityZones,
+	}
+
+	// Flags for zonesActivitiesCmd
+	zonesPeriod string
+)
+
+func init() {
+	activitiesCmd.AddCommand(zonesActivitiesCmd)
+	zonesActivitiesCmd.Flags().StringVar(&zonesPeriod, "period", "", "Sum time in zone over a period (week, month)")
+	zonesActivitiesCmd.Flags().StringVar(&activityDateFrom, "from", "", "Date within the period (YYYY-MM-DD, default today)")
+	zonesActivitiesCmd.Flags().StringVar(&activityType, "type", "", "Filter activities by type when using --period")
+}
+
+func runActivityZones(cmd *cobra.Command, args []string) error {
+	if (len(args) == 1) == (zonesPeriod != "") {
+		return fmt.Errorf("specify either an activity ID or --period")
+	}
+
+	zoneSet, err := configuredZones(cfg)
+	if err != nil {
+		return err
+	}
+
+	garminClient, err := newGarminClient()
+	if err != nil {
+		return err
+	}
+
+	hrZones, err := garminClient.GetHeartRateZones()
+	if err != nil {
+		return fmt.Errorf("failed to get heart rate zones: %w", err)
+	}
+	zoneSet.HeartRate = garmin.HeartRateZoneSet(hrZones)
+
+	if len(args) == 1 {
+		activityID, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid activity ID: %w", err)
+		}
+		zones, err := garminClient.GetActivityZones(activityID, zoneSet)
+		if err != nil {
+			return fmt.Errorf("failed to get activity zones: %w", err)
+		}
+		return printActivityZones(zones)
+	}
+
+	from, to, err := zonePeriod(zonesPeriod, activityDateFrom)
+	if err != nil {
+		return err
+	}
+	activities, err := garminClient.ListActivities(garmin.ActivityOptions{
+		ActivityType: activityType,
+		DateFrom:     from,
+		DateTo:       to,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list activities: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Analysing %d activities from %s to %s...\n",
+		len(activities), from.Format("2006-01-02"), to.Format("2006-01-02"))
+	var all []*garmin.ActivityZones
+	skipped := 0
+	for _, activity := range activities {
+		zones, err := garminClient.GetActivityZones(int(activity.ActivityID), zoneSet)
+		if err != nil {
+			// Manual activities have no samples to analyse
+			fmt.Fprintf(os.Stderr, "Warning: failed to get zones of activity %d: %v\n", activity.ActivityID, err)
+			skipped++
+			continue
+		}
+		all = append(all, zones)
+	}
+	if skipped > 0 {
+		fmt.Fprintf(os.Stderr, "Skipped %d of %d activities\n", skipped, len(activities))
+	}
+	return printActivityZones(garmin.SumActivityZones(all))
+}
+
+// zonePeriod returns the first and last day of the week (Monday to Sunday) or
+// month containing date, or today when date is empty
+func zonePeriod(period, date string) (time.Time, time.Time, error) {
+	day := time.Now()
+	if date != "" {
+		var err error
+		day, err = time.Parse("2006-01-02", date)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid date format for --from: %w", err)
+		}
+	}
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch strings.ToLower(period) {
+	case "week":
+		from := day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+		return from, from.AddDate(0, 0, 6), nil
+	case "month":
+		from := day.AddDate(0, 0, 1-day.Day())
+		return from, from.AddDate(0, 1, -1), nil
+	default:
+		return time.Time{}, time.Time{}, fmt.Errorf("unsupported period %q (use week or month)", period)
+	}
+}
+
+// configuredZones builds the power and pace zones from the config file
+func configuredZones(cfg *config.Config) (garmin.ZoneSet, error) {
+	var zoneSet garmin.ZoneSet
+	if cfg == nil {
+		return zoneSet, nil
+	}
+
+	for i, zc := range cfg.Zones.Power {
+		zone := garmin.Zone{Name: zoneName(zc.Name, i)}
+		var err error
+		if zc.Min != "" {
+			if zone.Min, err = strconv.ParseFloat(zc.Min, 64); err != nil {
+				return zoneSet, fmt.Errorf("invalid power zone %q: %w", zone.Name, err)
+			}
+		}
+		if zc.Max != "" {
+			if zone.Max, err = strconv.ParseFloat(zc.Max, 64); err != nil {
+				return zoneSet, fmt.Errorf("invalid power zone %q: %w", zone.Name, err)
+			}
+		}
+		zoneSet.Power = append(zoneSet.Power, zone)
+	}
+
+	// Pace zones are analysed as speeds, so the slower pace is the lower bound
+	for i, zc := range cfg.Zones.Pace {
+		zone := garmin.Zone{Name: zoneName(zc.Name, i)}
+		var err error
+		if zc.Min != "" {
+			if zone.Min, err = garmin.ParsePace(zc.Min); err != nil {
+				return zoneSet, fmt.Errorf("invalid pace zone %q: %w", zone.Name, err)
+			}
+		}
+		if zc.Max != "" {
+			if zone.Max, err = garmin.ParsePace(zc.Max); err != nil {
+				return zoneSet, fmt.Errorf("invalid pace zone %q: %w", zone.Name, err)
+			}
+		}
+		zoneSet.Pace = append(zoneSet.Pace, zone)
+	}
+	return zoneSet, nil
+}
+
+func zoneName(name string, i int) string {
+	if name == "" {
+		return fmt.Sprintf("Zone %d", i+1)
+	}
+	return name
+}
+
+func printActivityZones(zones *garmin.ActivityZones) error {
+	switch outputFormat := viper.GetString("output.format"); outputFormat {
+	case "json":
+		return printJSON(zones)
+	case "yaml":
+		return printYAML(zones)
+	case "table":
+		sections := []struct {
+			title string
+			times []garmin.ZoneTime
+		}{
+			{"Heart rate", zones.HeartRate},
+			{"Power", zones.Power},
+			{"Pace", zones.Pace},
+		}
+		printed := false
+		for _, section := range sections {
+			if len(section.times) == 0 {
+				continue
+			}
+			if printed {
+				fmt.Println()
+			}
+			printed = true
+
+			fmt.Printf("%s zones:\n", section.title)
+			tbl := table.New("Zone", "Time", "Percent")
+			for _, t := range section.times {
+				tbl.AddRow(t.Zone.Name, formatDuration(t.Seconds), fmt.Sprintf("%.1f%%", t.Percent))
+			}
+			tbl.Print()
+		}
+		if !printed {
+			fmt.Println("No zone data found.")
+			return nil
+		}
+
+		if low, threshold, high, ok := garmin.PolarizedDistribution(zones.HeartRate); ok {
+			fmt.Printf("\nIntensity distribution: %.0f%% low, %.0f%% threshold, %.0f%% high\n", low, threshold, high)
+		}
+		if zones.Activities > 1 {
+			fmt.Printf("%d activities\n", zones.Activities)
+		}
+	default:
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// apiCmd is the parent of the generated endpoint catalog subcommands in api_gen.go.
+var apiCmd = &cobra.Command{
+	Use:   "api",
+	Short: "Call Garmin Connect endpoints from the endpoint catalog",
+	Long: `Provides one subcommand per endpoint declared in pkg/garmin/endpoints.yaml.
+Responses are printed as JSON, or as YAML with --output yaml.`,
+}
+
+func init() {
+	rootCmd.AddCommand(apiCmd)
+}
+
+// parseAPIDate parses an optional YYYY-MM-DD flag value.
+func parseAPIDate(flag, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date format for --%s: %w", flag, err)
+	}
+	return date, nil
+}
+
+// readAPIBody reads a JSON request body from a file, or from stdin if path is "-".
+func readAPIBody(path string) (json.RawMessage, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("request body in %s is not valid JSON", path)
+	}
+	return json.RawMessage(data), nil
+}
+
+// printAPIResult prints a decoded endpoint response in the configured output format.
+func printAPIResult(result interface{}) error {
+	if viper.GetString("output.format") == "yaml" {
+		return printYAML(result)
+	}
+	return printJSON(result)
+}
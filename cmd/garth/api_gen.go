@@ -0,0 +1,932 @@
+// Code generated by endpointgen from pkg/garmin/endpoints.yaml. DO NOT EDIT.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "devices",
+		Short: "List the devices registered to the user's account",
+		Long:  "List the devices registered to the user's account.\n\nEndpoint: GET /device-service/deviceregistration/devices",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.GetDevices()
+			if err != nil {
+				return fmt.Errorf("failed to get devices: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	apiCmd.AddCommand(cmd)
+}
+
+func init() {
+	var (
+		deviceID int64
+	)
+	cmd := &cobra.Command{
+		Use:   "device-settings",
+		Short: "Get the settings of a registered device",
+		Long:  "Get the settings of a registered device.\n\nEndpoint: GET /device-service/deviceservice/device-info/settings/{deviceId}",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.GetDeviceSettings(deviceID)
+			if err != nil {
+				return fmt.Errorf("failed to get device settings: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	cmd.Flags().Int64Var(&deviceID, "device-id", 0, "Device ID as returned by the devices endpoint")
+	_ = cmd.MarkFlagRequired("device-id")
+	apiCmd.AddCommand(cmd)
+}
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "device-sync-status",
+		Short: "Get the synchronization status of the user's devices",
+		Long:  "Get the synchronization status of the user's devices.\n\nEndpoint: GET /device-service/deviceservice/device-info/sync-status",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.GetDeviceSyncStatus()
+			if err != nil {
+				return fmt.Errorf("failed to get device sync status: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	apiCmd.AddCommand(cmd)
+}
+
+func init() {
+	var (
+		displayName string
+	)
+	cmd := &cobra.Command{
+		Use:   "social-profile",
+		Short: "Get the public profile of a user by display name",
+		Long:  "Get the public profile of a user by display name.\n\nEndpoint: GET /userprofile-service/socialProfile/{displayName}",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.GetSocialProfile(displayName)
+			if err != nil {
+				return fmt.Errorf("failed to get social profile: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	cmd.Flags().StringVar(&displayName, "display-name", "", "Display name of the user")
+	_ = cmd.MarkFlagRequired("display-name")
+	apiCmd.AddCommand(cmd)
+}
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "connections",
+		Short: "List the user's connections",
+		Long:  "List the user's connections.\n\nEndpoint: GET /userprofile-service/connection-service/connections",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.GetConnections()
+			if err != nil {
+				return fmt.Errorf("failed to get connections: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	apiCmd.AddCommand(cmd)
+}
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "goals",
+		Short: "Get the user's fitness goals",
+		Long:  "Get the user's fitness goals.\n\nEndpoint: GET /userprofile-service/userprofile/personal-information/goals",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.GetGoals()
+			if err != nil {
+				return fmt.Errorf("failed to get goals: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	apiCmd.AddCommand(cmd)
+}
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "badges",
+		Short: "List the badges earned by the user",
+		Long:  "List the badges earned by the user.\n\nEndpoint: GET /badge-service/badge/earned",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.GetEarnedBadges()
+			if err != nil {
+				return fmt.Errorf("failed to get earned badges: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	apiCmd.AddCommand(cmd)
+}
+
+func init() {
+	var (
+		displayName string
+	)
+	cmd := &cobra.Command{
+		Use:   "personal-records",
+		Short: "List the personal records of a user",
+		Long:  "List the personal records of a user.\n\nEndpoint: GET /personalrecord-service/personalrecord/prs/{displayName}",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.GetPersonalRecords(displayName)
+			if err != nil {
+				return fmt.Errorf("failed to get personal records: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	cmd.Flags().StringVar(&displayName, "display-name", "", "Display name of the user")
+	_ = cmd.MarkFlagRequired("display-name")
+	apiCmd.AddCommand(cmd)
+}
+
+func init() {
+	var (
+		activityID int64
+	)
+	cmd := &cobra.Command{
+		Use:   "activity-weather",
+		Short: "Get the weather report of an activity",
+		Long:  "Get the weather report of an activity.\n\nEndpoint: GET /activity-service/activity/{activityId}/weather",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.GetActivityWeather(activityID)
+			if err != nil {
+				return fmt.Errorf("failed to get activity weather: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	cmd.Flags().Int64Var(&activityID, "activity-id", 0, "Activity ID")
+	_ = cmd.MarkFlagRequired("activity-id")
+	apiCmd.AddCommand(cmd)
+}
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "activity-types",
+		Short: "List every activity type with its ID and parent type",
+		Long:  "List every activity type with its ID and parent type.\n\nEndpoint: GET /activity-service/activity/activityTypes",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.GetActivityTypes()
+			if err != nil {
+				return fmt.Errorf("failed to get activity types: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	apiCmd.AddCommand(cmd)
+}
+
+func init() {
+	var (
+		userProfilePk int64
+	)
+	cmd := &cobra.Command{
+		Use:   "gear",
+		Short: "List the gear of a user, active and retired",
+		Long:  "List the gear of a user, active and retired.\n\nEndpoint: GET /gear-service/gear/filterGear",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.GetUserGear(userProfilePk)
+			if err != nil {
+				return fmt.Errorf("failed to get user gear: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	cmd.Flags().Int64Var(&userProfilePk, "user-profile-pk", 0, "Profile ID of the user")
+	apiCmd.AddCommand(cmd)
+}
+
+func init() {
+	var (
+		activityID int64
+	)
+	cmd := &cobra.Command{
+		Use:   "activity-gear",
+		Short: "List the gear linked to an activity",
+		Long:  "List the gear linked to an activity.\n\nEndpoint: GET /gear-service/gear/filterGear",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.GetActivityGear(activityID)
+			if err != nil {
+				return fmt.Errorf("failed to get activity gear: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	cmd.Flags().Int64Var(&activityID, "activity-id", 0, "Activity ID")
+	apiCmd.AddCommand(cmd)
+}
+
+func init() {
+	var (
+		uuid string
+	)
+	cmd := &cobra.Command{
+		Use:   "gear-stats",
+		Short: "Get the total distance and activity count of a piece of gear",
+		Long:  "Get the total distance and activity count of a piece of gear.\n\nEndpoint: GET /gear-service/gear/stats/{uuid}",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.GetGearStats(uuid)
+			if err != nil {
+				return fmt.Errorf("failed to get gear stats: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	cmd.Flags().StringVar(&uuid, "uuid", "", "Gear UUID")
+	_ = cmd.MarkFlagRequired("uuid")
+	apiCmd.AddCommand(cmd)
+}
+
+func init() {
+	var (
+		uuid  string
+		start int
+		limit int
+	)
+	cmd := &cobra.Command{
+		Use:   "gear-activities",
+		Short: "List the activities a piece of gear was used for",
+		Long:  "List the activities a piece of gear was used for.\n\nEndpoint: GET /activitylist-service/activities/{uuid}/gear",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.GetGearActivities(uuid, start, limit)
+			if err != nil {
+				return fmt.Errorf("failed to get gear activities: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	cmd.Flags().StringVar(&uuid, "uuid", "", "Gear UUID")
+	_ = cmd.MarkFlagRequired("uuid")
+	cmd.Flags().IntVar(&start, "start", 0, "Index of the first activity")
+	cmd.Flags().IntVar(&limit, "limit", 0, "Maximum number of activities to return")
+	apiCmd.AddCommand(cmd)
+}
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "courses",
+		Short: "List the user's courses",
+		Long:  "List the user's courses.\n\nEndpoint: GET /course-service/course",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.GetCourses()
+			if err != nil {
+				return fmt.Errorf("failed to get courses: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	apiCmd.AddCommand(cmd)
+}
+
+func init() {
+	var (
+		courseID int64
+	)
+	cmd := &cobra.Command{
+		Use:   "course",
+		Short: "Get a course with its track points",
+		Long:  "Get a course with its track points.\n\nEndpoint: GET /course-service/course/{courseId}",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.GetCourse(courseID)
+			if err != nil {
+				return fmt.Errorf("failed to get course: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	cmd.Flags().Int64Var(&courseID, "course-id", 0, "Course ID")
+	_ = cmd.MarkFlagRequired("course-id")
+	apiCmd.AddCommand(cmd)
+}
+
+func init() {
+	var (
+		bodyFile string
+	)
+	cmd := &cobra.Command{
+		Use:   "create-course",
+		Short: "Create a course",
+		Long:  "Create a course.\n\nEndpoint: POST /course-service/course",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			body, err := readAPIBody(bodyFile)
+			if err != nil {
+				return err
+			}
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.CreateCourse(body)
+			if err != nil {
+				return fmt.Errorf("failed to create course: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	cmd.Flags().StringVar(&bodyFile, "body", "", "JSON file with the request body (- for stdin)")
+	_ = cmd.MarkFlagRequired("body")
+	apiCmd.AddCommand(cmd)
+}
+
+func init() {
+	var (
+		courseID int64
+	)
+	cmd := &cobra.Command{
+		Use:   "delete-course",
+		Short: "Delete a course",
+		Long:  "Delete a course.\n\nEndpoint: DELETE /course-service/course/{courseId}",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.DeleteCourse(courseID)
+			if err != nil {
+				return fmt.Errorf("failed to delete course: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	cmd.Flags().Int64Var(&courseID, "course-id", 0, "Course ID")
+	_ = cmd.MarkFlagRequired("course-id")
+	apiCmd.AddCommand(cmd)
+}
+
+func init() {
+	var (
+		start int
+		limit int
+	)
+	cmd := &cobra.Command{
+		Use:   "workouts",
+		Short: "List the user's workouts",
+		Long:  "List the user's workouts.\n\nEndpoint: GET /workout-service/workouts",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.GetWorkouts(start, limit)
+			if err != nil {
+				return fmt.Errorf("failed to get workouts: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	cmd.Flags().IntVar(&start, "start", 0, "Index of the first workout")
+	cmd.Flags().IntVar(&limit, "limit", 0, "Maximum number of workouts to return")
+	apiCmd.AddCommand(cmd)
+}
+
+func init() {
+	var (
+		workoutID int64
+	)
+	cmd := &cobra.Command{
+		Use:   "workout",
+		Short: "Get a workout with its steps",
+		Long:  "Get a workout with its steps.\n\nEndpoint: GET /workout-service/workout/{workoutId}",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.GetWorkout(workoutID)
+			if err != nil {
+				return fmt.Errorf("failed to get workout: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	cmd.Flags().Int64Var(&workoutID, "workout-id", 0, "Workout ID")
+	_ = cmd.MarkFlagRequired("workout-id")
+	apiCmd.AddCommand(cmd)
+}
+
+func init() {
+	var (
+		bodyFile string
+	)
+	cmd := &cobra.Command{
+		Use:   "create-workout",
+		Short: "Create a workout",
+		Long:  "Create a workout.\n\nEndpoint: POST /workout-service/workout",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			body, err := readAPIBody(bodyFile)
+			if err != nil {
+				return err
+			}
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.CreateWorkout(body)
+			if err != nil {
+				return fmt.Errorf("failed to create workout: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	cmd.Flags().StringVar(&bodyFile, "body", "", "JSON file with the request body (- for stdin)")
+	_ = cmd.MarkFlagRequired("body")
+	apiCmd.AddCommand(cmd)
+}
+
+func init() {
+	var (
+		workoutID int64
+	)
+	cmd := &cobra.Command{
+		Use:   "delete-workout",
+		Short: "Delete a workout",
+		Long:  "Delete a workout.\n\nEndpoint: DELETE /workout-service/workout/{workoutId}",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.DeleteWorkout(workoutID)
+			if err != nil {
+				return fmt.Errorf("failed to delete workout: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	cmd.Flags().Int64Var(&workoutID, "workout-id", 0, "Workout ID")
+	_ = cmd.MarkFlagRequired("workout-id")
+	apiCmd.AddCommand(cmd)
+}
+
+func init() {
+	var (
+		workoutID int64
+		bodyFile  string
+	)
+	cmd := &cobra.Command{
+		Use:   "schedule-workout",
+		Short: "Schedule a workout on a calendar date",
+		Long:  "Schedule a workout on a calendar date.\n\nEndpoint: POST /workout-service/schedule/{workoutId}",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			body, err := readAPIBody(bodyFile)
+			if err != nil {
+				return err
+			}
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.CreateWorkoutSchedule(workoutID, body)
+			if err != nil {
+				return fmt.Errorf("failed to create workout schedule: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	cmd.Flags().Int64Var(&workoutID, "workout-id", 0, "Workout ID")
+	_ = cmd.MarkFlagRequired("workout-id")
+	cmd.Flags().StringVar(&bodyFile, "body", "", "JSON file with the request body (- for stdin)")
+	_ = cmd.MarkFlagRequired("body")
+	apiCmd.AddCommand(cmd)
+}
+
+func init() {
+	var (
+		year  int
+		month int
+	)
+	cmd := &cobra.Command{
+		Use:   "calendar-month",
+		Short: "Get the calendar items of a month",
+		Long:  "Get the calendar items of a month.\n\nEndpoint: GET /calendar-service/year/{year}/month/{month}",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.GetCalendarMonth(year, month)
+			if err != nil {
+				return fmt.Errorf("failed to get calendar month: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	cmd.Flags().IntVar(&year, "year", 0, "Year")
+	_ = cmd.MarkFlagRequired("year")
+	cmd.Flags().IntVar(&month, "month", 0, "Month, counted from 0 for January")
+	_ = cmd.MarkFlagRequired("month")
+	apiCmd.AddCommand(cmd)
+}
+
+func init() {
+	var (
+		year           int
+		month          int
+		day            int
+		firstDayOfWeek int
+	)
+	cmd := &cobra.Command{
+		Use:   "calendar-week",
+		Short: "Get the calendar items of the week containing a day",
+		Long:  "Get the calendar items of the week containing a day.\n\nEndpoint: GET /calendar-service/year/{year}/month/{month}/day/{day}/start/{firstDayOfWeek}",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.GetCalendarWeek(year, month, day, firstDayOfWeek)
+			if err != nil {
+				return fmt.Errorf("failed to get calendar week: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	cmd.Flags().IntVar(&year, "year", 0, "Year")
+	_ = cmd.MarkFlagRequired("year")
+	cmd.Flags().IntVar(&month, "month", 0, "Month, counted from 0 for January")
+	_ = cmd.MarkFlagRequired("month")
+	cmd.Flags().IntVar(&day, "day", 0, "Day of the month")
+	_ = cmd.MarkFlagRequired("day")
+	cmd.Flags().IntVar(&firstDayOfWeek, "first-day-of-week", 0, "First day of the week (1 for Sunday, 2 for Monday)")
+	_ = cmd.MarkFlagRequired("first-day-of-week")
+	apiCmd.AddCommand(cmd)
+}
+
+func init() {
+	var (
+		displayName  string
+		calendarDate string
+	)
+	cmd := &cobra.Command{
+		Use:   "daily-summary",
+		Short: "Get the daily activity summary for a user and date",
+		Long:  "Get the daily activity summary for a user and date.\n\nEndpoint: GET /usersummary-service/usersummary/daily/{displayName}",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			calendarDateValue, err := parseAPIDate("calendar-date", calendarDate)
+			if err != nil {
+				return err
+			}
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.GetDailySummary(displayName, calendarDateValue)
+			if err != nil {
+				return fmt.Errorf("failed to get daily summary: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	cmd.Flags().StringVar(&displayName, "display-name", "", "Display name of the user")
+	_ = cmd.MarkFlagRequired("display-name")
+	cmd.Flags().StringVar(&calendarDate, "calendar-date", "", "Date of the summary (YYYY-MM-DD)")
+	apiCmd.AddCommand(cmd)
+}
+
+func init() {
+	var (
+		date string
+	)
+	cmd := &cobra.Command{
+		Use:   "hydration",
+		Short: "Get the hydration log for a date",
+		Long:  "Get the hydration log for a date.\n\nEndpoint: GET /usersummary-service/usersummary/hydration/daily/{date}",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dateValue, err := parseAPIDate("date", date)
+			if err != nil {
+				return err
+			}
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.GetDailyHydration(dateValue)
+			if err != nil {
+				return fmt.Errorf("failed to get daily hydration: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	cmd.Flags().StringVar(&date, "date", "", "Date of the hydration log (YYYY-MM-DD)")
+	_ = cmd.MarkFlagRequired("date")
+	apiCmd.AddCommand(cmd)
+}
+
+func init() {
+	var (
+		bodyFile string
+	)
+	cmd := &cobra.Command{
+		Use:   "log-hydration",
+		Short: "Add a hydration entry",
+		Long:  "Add a hydration entry.\n\nEndpoint: PUT /usersummary-service/usersummary/hydration/log",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			body, err := readAPIBody(bodyFile)
+			if err != nil {
+				return err
+			}
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.LogHydration(body)
+			if err != nil {
+				return fmt.Errorf("failed to log hydration: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	cmd.Flags().StringVar(&bodyFile, "body", "", "JSON file with the request body (- for stdin)")
+	_ = cmd.MarkFlagRequired("body")
+	apiCmd.AddCommand(cmd)
+}
+
+func init() {
+	var (
+		date string
+	)
+	cmd := &cobra.Command{
+		Use:   "wellness-dashboard",
+		Short: "Get the wellness dashboard for a date",
+		Long:  "Get the wellness dashboard for a date.\n\nEndpoint: GET /wellness-service/wellness/wellness-dashboard/{date}",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dateValue, err := parseAPIDate("date", date)
+			if err != nil {
+				return err
+			}
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.GetWellnessDashboard(dateValue)
+			if err != nil {
+				return fmt.Errorf("failed to get wellness dashboard: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	cmd.Flags().StringVar(&date, "date", "", "Date of the dashboard (YYYY-MM-DD)")
+	_ = cmd.MarkFlagRequired("date")
+	apiCmd.AddCommand(cmd)
+}
+
+func init() {
+	var (
+		date string
+	)
+	cmd := &cobra.Command{
+		Use:   "respiration",
+		Short: "Get respiration data for a date",
+		Long:  "Get respiration data for a date.\n\nEndpoint: GET /wellness-service/wellness/daily/respiration/{date}",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dateValue, err := parseAPIDate("date", date)
+			if err != nil {
+				return err
+			}
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.GetRespiration(dateValue)
+			if err != nil {
+				return fmt.Errorf("failed to get respiration: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	cmd.Flags().StringVar(&date, "date", "", "Date of the respiration data (YYYY-MM-DD)")
+	_ = cmd.MarkFlagRequired("date")
+	apiCmd.AddCommand(cmd)
+}
+
+func init() {
+	var (
+		date string
+	)
+	cmd := &cobra.Command{
+		Use:   "spo2",
+		Short: "Get pulse oximetry data for a date",
+		Long:  "Get pulse oximetry data for a date.\n\nEndpoint: GET /wellness-service/wellness/daily/spo2/{date}",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dateValue, err := parseAPIDate("date", date)
+			if err != nil {
+				return err
+			}
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.GetSpO2(dateValue)
+			if err != nil {
+				return fmt.Errorf("failed to get sp o2: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	cmd.Flags().StringVar(&date, "date", "", "Date of the SpO2 data (YYYY-MM-DD)")
+	_ = cmd.MarkFlagRequired("date")
+	apiCmd.AddCommand(cmd)
+}
+
+func init() {
+	var (
+		date string
+	)
+	cmd := &cobra.Command{
+		Use:   "floors",
+		Short: "Get floors climbed and descended for a date",
+		Long:  "Get floors climbed and descended for a date.\n\nEndpoint: GET /wellness-service/wellness/floorsChartData/daily/{date}",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dateValue, err := parseAPIDate("date", date)
+			if err != nil {
+				return err
+			}
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.GetFloors(dateValue)
+			if err != nil {
+				return fmt.Errorf("failed to get floors: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	cmd.Flags().StringVar(&date, "date", "", "Date of the floors data (YYYY-MM-DD)")
+	_ = cmd.MarkFlagRequired("date")
+	apiCmd.AddCommand(cmd)
+}
+
+func init() {
+	var (
+		displayName string
+		fromDate    string
+		untilDate   string
+		metricID    int
+	)
+	cmd := &cobra.Command{
+		Use:   "resting-hr",
+		Short: "Get resting heart rate values for a date range",
+		Long:  "Get resting heart rate values for a date range.\n\nEndpoint: GET /userstats-service/wellness/daily/{displayName}",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fromDateValue, err := parseAPIDate("from-date", fromDate)
+			if err != nil {
+				return err
+			}
+			untilDateValue, err := parseAPIDate("until-date", untilDate)
+			if err != nil {
+				return err
+			}
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.GetRestingHeartRate(displayName, fromDateValue, untilDateValue, metricID)
+			if err != nil {
+				return fmt.Errorf("failed to get resting heart rate: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	cmd.Flags().StringVar(&displayName, "display-name", "", "Display name of the user")
+	_ = cmd.MarkFlagRequired("display-name")
+	cmd.Flags().StringVar(&fromDate, "from-date", "", "First date of the range (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&untilDate, "until-date", "", "Last date of the range (YYYY-MM-DD)")
+	cmd.Flags().IntVar(&metricID, "metric-id", 0, "Wellness metric ID (60 for resting heart rate)")
+	apiCmd.AddCommand(cmd)
+}
+
+func init() {
+	var (
+		scorecardID int64
+	)
+	cmd := &cobra.Command{
+		Use:   "golf-scorecard",
+		Short: "Get the details of a golf scorecard",
+		Long:  "Get the details of a golf scorecard.\n\nEndpoint: GET /golf-service/golf/scorecard/{scorecardId}",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			garminClient, err := newGarminClient()
+			if err != nil {
+				return err
+			}
+			result, err := garminClient.GetGolfScorecard(scorecardID)
+			if err != nil {
+				return fmt.Errorf("failed to get golf scorecard: %w", err)
+			}
+			return printAPIResult(result)
+		},
+	}
+	cmd.Flags().Int64Var(&scorecardID, "scorecard-id", 0, "Scorecard ID")
+	_ = cmd.MarkFlagRequired("scorecard-id")
+	apiCmd.AddCommand(cmd)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// auditEntry is one line of the audit log
+type auditEntry struct {
+	Time         time.Time `json:"time"`
+	Action       string    `json:"action"`
+	ActivityID   int64     `json:"activityId"`
+	ActivityName string    `json:"activityName,omitempty"`
+	ActivityType string    `json:"activityType,omitempty"`
+	StartTime    string    `json:"startTime,omitempty"`
+	Backup       []string  `json:"backup,omitempty"`
+}
+
+// auditLogPath returns the location of the audit log in the config directory
+func auditLogPath() string {
+	return filepath.Join(userConfigDir, "audit.log")
+}
+
+// appendAuditLog records a destructive change as a JSON line in the audit log
+func appendAuditLog(entry auditEntry) error {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().UTC()
+	}
+
+	path := auditLogPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+var (
+	calendarCmd = &cobra.Command{
+		Use:   "calendar",
+		Short: "Show the training calendar",
+		Long: `Show scheduled workouts, completed activities, races and other events, and
+notes for a month or a week, as a month grid followed by an agenda:
+
+  garth calendar --month 2026-10
+  garth calendar --week 2026-10-12 --view agenda
+
+In the grid, E marks events, W scheduled workouts, A activities and N notes,
+followed by a count when there are several. Today is marked with *.`,
+		Args: cobra.NoArgs,
+		RunE: runCalendar,
+	}
+
+	// Flags for calendarCmd
+	calendarMonth string
+	calendarWeek  string
+	calendarView  string
+)
+
+// calendarMarkers are the grid markers of the calendar entry kinds, in the
+// order they are shown
+var calendarMarkers = []struct {
+	kind   garmin.CalendarKind
+	marker string
+}{
+	{garmin.CalendarEvent, "E"},
+	{garmin.CalendarWorkout, "W"},
+	{garmin.CalendarActivity, "A"},
+	{garmin.CalendarNote, "N"},
+}
+
+func init() {
+	rootCmd.AddCommand(calendarCmd)
+	calendarCmd.Flags().StringVar(&calendarMonth, "month", "", "Month to show (YYYY-MM, default is the current month)")
+	calendarCmd.Flags().StringVar(&calendarWeek, "week", "", "Show the week containing this date (YYYY-MM-DD)")
+	calendarCmd.Flags().StringVar(&calendarView, "view", "all", "Table view (grid, agenda, all)")
+}
+
+func runCalendar(cmd *cobra.Command, args []string) error {
+	if calendarMonth != "" && calendarWeek != "" {
+		return fmt.Errorf("invalid arguments: specify --month or --week, not both")
+	}
+	switch calendarView {
+	case "grid", "agenda", "all":
+	default:
+		return fmt.Errorf("unsupported calendar view: %s (use grid, agenda or all)", calendarView)
+	}
+
+	garminClient, err := newGarminClient()
+	if err != nil {
+		return err
+	}
+
+	var calendar *garmin.Calendar
+	if calendarWeek != "" {
+		day, err := time.Parse("2006-01-02", calendarWeek)
+		if err != nil {
+			return fmt.Errorf("invalid week date: %w", err)
+		}
+		calendar, err = garminClient.GetWeekCalendar(day)
+		if err != nil {
+			return fmt.Errorf("failed to get calendar: %w", err)
+		}
+	} else {
+		month := time.Now()
+		if calendarMonth != "" {
+			month, err = time.Parse("2006-01", calendarMonth)
+			if err != nil {
+				return fmt.Errorf("invalid month (use YYYY-MM): %w", err)
+			}
+		}
+		calendar, err = garminClient.GetMonthCalendar(month.Year(), month.Month())
+		if err != nil {
+			return fmt.Errorf("failed to get calendar: %w", err)
+		}
+	}
+
+	switch outputFormat := viper.GetString("output.format"); outputFormat {
+	case "json":
+		return printJSON(calendar)
+	case "yaml":
+		return printYAML(calendar)
+	case "table":
+		if calendarView != "agenda" {
+			printCalendarGrid(calendar, time.Now())
+		}
+		if calendarView == "all" {
+			fmt.Println()
+		}
+		if calendarView != "grid" {
+			printCalendarAgenda(calendar)
+		}
+	default:
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+	return nil
+}
+
+// printCalendarGrid prints the calendar as a grid of weeks starting on
+// Monday, with the markers of each day's entries below the day number
+func printCalendarGrid(calendar *garmin.Calendar, today time.Time) {
+	const width = 9
+
+	title := calendar.From.Format("January 2006")
+	if calendar.To.Sub(calendar.From) < 7*24*time.Hour {
+		title = fmt.Sprintf("Week of %s", calendar.From.Format("Mon 2 Jan 2006"))
+	}
+	fmt.Printf("%*s\n", (7*width+len(title))/2, title)
+	for _, name := range []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"} {
+		fmt.Printf("%-*s", width, name)
+	}
+	fmt.Println()
+
+	start := calendar.From.AddDate(0, 0, -((int(calendar.From.Weekday()) + 6) % 7))
+	for week := start; !week.After(calendar.To); week = week.AddDate(0, 0, 7) {
+		var days, markers strings.Builder
+		for i := 0; i < 7; i++ {
+			day := week.AddDate(0, 0, i)
+			if day.Before(calendar.From) || day.After(calendar.To) {
+				fmt.Fprintf(&days, "%-*s", width, "")
+				fmt.Fprintf(&markers, "%-*s", width, "")
+				continue
+			}
+			number := fmt.Sprintf("%2d", day.Day())
+			if day.Year() == today.Year() && day.YearDay() == today.YearDay() {
+				number += "*"
+			}
+			fmt.Fprintf(&days, "%-*s", width, number)
+			fmt.Fprintf(&markers, "%-*s", width, dayMarkers(calendar.Day(day)))
+		}
+		fmt.Println(strings.TrimRight(days.String(), " "))
+		fmt.Println(strings.TrimRight(markers.String(), " "))
+	}
+}
+
+// dayMarkers returns the grid markers of a day's entries, e.g. "W A2"
+func dayMarkers(entries []garmin.CalendarEntry) string {
+	var markers []string
+	for _, m := range calendarMarkers {
+		count := 0
+		for _, entry := range entries {
+			if entry.Kind == m.kind {
+				count++
+			}
+		}
+		switch {
+		case count == 1:
+			markers = append(markers, m.marker)
+		case count > 1:
+			markers = append(markers, fmt.Sprintf("%s%d", m.marker, count))
+		}
+	}
+	return strings.Join(markers, " ")
+}
+
+// printCalendarAgenda lists the calendar entries day by day
+func printCalendarAgenda(calendar *garmin.Calendar) {
+	if len(calendar.Entries) == 0 {
+		fmt.Println("No calendar entries found.")
+		return
+	}
+	tbl := table.New("Date", "Kind", "Title", "Type", "Time", "Duration", "Distance")
+	for _, entry := range calendar.Entries {
+		title := entry.Title
+		if entry.Race {
+			title += " (race)"
+		}
+		start, duration, distance := "", "", ""
+		if entry.Start != nil {
+			start = entry.Start.Format("15:04")
+		}
+		if entry.Seconds > 0 {
+			duration = formatDuration(entry.Seconds)
+		}
+		if entry.Distance > 0 {
+			distance = fmt.Sprintf("%.2f km", entry.Distance/1000)
+		}
+		tbl.AddRow(entry.Date.Format("Mon 2006-01-02"), entry.Kind, title, entry.ActivityType, start, duration, distance)
+	}
+	tbl.Print()
+}
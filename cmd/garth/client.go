@@ -0,0 +1,22 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+// newGarminClient creates a Garmin Connect client and loads the saved session.
+func newGarminClient() (*garmin.Client, error) {
+	garminClient, err := garmin.NewClient("www.garmin.com") // TODO: Domain should be configurable
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	sessionFile := "garmin_session.json" // TODO: Make session file configurable
+	if err := garminClient.LoadSession(sessionFile); err != nil {
+		return nil, fmt.Errorf("not logged in: %w", err)
+	}
+
+	return garminClient, nil
+}
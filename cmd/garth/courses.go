@@ -0,0 +1,288 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+var (
+	coursesCmd = &cobra.Command{
+		Use:   "courses",
+		Short: "Manage courses",
+		Long:  `List, download, create and delete courses on Garmin Connect.`,
+	}
+
+	listCoursesCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List courses",
+		Args:  cobra.NoArgs,
+		RunE:  runListCourses,
+	}
+
+	showCourseCmd = &cobra.Command{
+		Use:   "show [courseID]",
+		Short: "Show a course",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runShowCourse,
+	}
+
+	downloadCourseCmd = &cobra.Command{
+		Use:   "download [courseID...]",
+		Short: "Download courses as GPX or FIT",
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  runDownloadCourses,
+	}
+
+	createCourseCmd = &cobra.Command{
+		Use:   "create [file.gpx]",
+		Short: "Create a course from a GPX file",
+		Long: `Create a course from the tracks or routes of a local GPX file. The distance and
+the elevation gain and loss are computed from the track points:
+
+  garth courses create loop.gpx --name "Sunday Loop" --type cycling`,
+		Args: cobra.ExactArgs(1),
+		RunE: runCreateCourse,
+	}
+
+	deleteCourseCmd = &cobra.Command{
+		Use:   "delete [courseID...]",
+		Short: "Delete courses",
+		Long: `Permanently delete courses from Garmin Connect. The courses must be confirmed
+interactively unless --yes is given.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: runDeleteCourses,
+	}
+
+	// Flags for downloadCourseCmd
+	courseFormat    string
+	courseOutputDir string
+
+	// Flags for createCourseCmd
+	courseName        string
+	courseDescription string
+	courseType        string
+	coursePrivacy     string
+
+	// Flags for deleteCourseCmd
+	courseDeleteYes bool
+)
+
+func init() {
+	rootCmd.AddCommand(coursesCmd)
+
+	coursesCmd.AddCommand(listCoursesCmd)
+	coursesCmd.AddCommand(showCourseCmd)
+
+	coursesCmd.AddCommand(downloadCourseCmd)
+	downloadCourseCmd.Flags().StringVar(&courseFormat, "format", "gpx", "Download format (gpx, fit)")
+	downloadCourseCmd.Flags().StringVar(&courseOutputDir, "output-dir", ".", "Output directory for downloaded files")
+
+	coursesCmd.AddCommand(createCourseCmd)
+	createCourseCmd.Flags().StringVar(&courseName, "name", "", "Course name (default is the name in the GPX file)")
+	createCourseCmd.Flags().StringVar(&courseDescription, "description", "", "Course description")
+	createCourseCmd.Flags().StringVar(&courseType, "type", "other", "Activity type (e.g. "+strings.Join(garmin.ActivityTypeKeys(), ", ")+")")
+	createCourseCmd.Flags().StringVar(&coursePrivacy, "privacy", "private", "Privacy setting ("+strings.Join(garmin.PrivacyKeys(), ", ")+")")
+
+	coursesCmd.AddCommand(deleteCourseCmd)
+	deleteCourseCmd.Flags().BoolVarP(&courseDeleteYes, "yes", "y", false, "Delete without asking for confirmation")
+}
+
+func runListCourses(cmd *cobra.Command, args []string) error {
+	garminClient, err := newGarminClient()
+	if err != nil {
+		return err
+	}
+
+	courses, err := garminClient.GetCourses()
+	if err != nil {
+		return fmt.Errorf("failed to list courses: %w", err)
+	}
+
+	switch outputFormat := viper.GetString("output.format"); outputFormat {
+	case "json":
+		return printJSON(courses)
+	case "yaml":
+		return printYAML(courses)
+	case "table":
+		if len(courses) == 0 {
+			fmt.Println("No courses found.")
+			return nil
+		}
+		tbl := table.New("ID", "Name", "Type", "Distance", "Ascent", "Descent")
+		for _, course := range courses {
+			tbl.AddRow(course.CourseID, course.CourseName, garminClient.ActivityTypeKey(course.ActivityTypePK),
+				fmt.Sprintf("%.2f km", course.DistanceMeter/1000),
+				fmt.Sprintf("%.0f m", course.ElevationGainMeter),
+				fmt.Sprintf("%.0f m", course.ElevationLossMeter))
+		}
+		tbl.Print()
+	default:
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+	return nil
+}
+
+func runShowCourse(cmd *cobra.Command, args []string) error {
+	courseID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid course ID %q: %w", args[0], err)
+	}
+
+	garminClient, err := newGarminClient()
+	if err != nil {
+		return err
+	}
+
+	course, err := garminClient.GetCourse(courseID)
+	if err != nil {
+		return fmt.Errorf("failed to get course %d: %w", courseID, err)
+	}
+
+	switch outputFormat := viper.GetString("output.format"); outputFormat {
+	case "json":
+		return printJSON(course)
+	case "yaml":
+		return printYAML(course)
+	case "table":
+		printCourse(garminClient, course)
+	default:
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+	return nil
+}
+
+func runDownloadCourses(cmd *cobra.Command, args []string) error {
+	courseIDs, err := parseCourseIDs(args)
+	if err != nil {
+		return err
+	}
+
+	garminClient, err := newGarminClient()
+	if err != nil {
+		return err
+	}
+
+	for _, courseID := range courseIDs {
+		path, err := garminClient.DownloadCourse(courseID, garmin.DownloadOptions{
+			Format:    courseFormat,
+			OutputDir: courseOutputDir,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to download course %d: %w", courseID, err)
+		}
+		fmt.Printf("Downloaded course %d to %s\n", courseID, path)
+	}
+	return nil
+}
+
+func runCreateCourse(cmd *cobra.Command, args []string) error {
+	garminClient, err := newGarminClient()
+	if err != nil {
+		return err
+	}
+
+	course, err := garminClient.CreateCourseFromGPX(args[0], garmin.CourseOptions{
+		Name:         courseName,
+		Description:  courseDescription,
+		ActivityType: courseType,
+		Privacy:      coursePrivacy,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create course: %w", err)
+	}
+
+	switch outputFormat := viper.GetString("output.format"); outputFormat {
+	case "json":
+		return printJSON(course)
+	case "yaml":
+		return printYAML(course)
+	case "table":
+		fmt.Printf("Created course %d\n", course.CourseID)
+		printCourse(garminClient, course)
+	default:
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+	return nil
+}
+
+func runDeleteCourses(cmd *cobra.Command, args []string) error {
+	courseIDs, err := parseCourseIDs(args)
+	if err != nil {
+		return err
+	}
+
+	garminClient, err := newGarminClient()
+	if err != nil {
+		return err
+	}
+
+	var courses []*garmin.Course
+	for _, courseID := range courseIDs {
+		course, err := garminClient.GetCourse(courseID)
+		if err != nil {
+			return fmt.Errorf("failed to get course %d: %w", courseID, err)
+		}
+		courses = append(courses, course)
+	}
+
+	fmt.Printf("The following %d courses will be deleted:\n", len(courses))
+	for _, course := range courses {
+		fmt.Printf("  %d  %s (%.2f km)\n", course.CourseID, course.CourseName, course.DistanceMeter/1000)
+	}
+	if !courseDeleteYes {
+		confirmed, err := confirm(fmt.Sprintf("Delete %d courses? [y/N] ", len(courses)))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("Aborted.")
+			return nil
+		}
+	}
+
+	for _, course := range courses {
+		if _, err := garminClient.DeleteCourse(course.CourseID); err != nil {
+			return fmt.Errorf("failed to delete course %d: %w", course.CourseID, err)
+		}
+		fmt.Printf("Deleted course %d (%s)\n", course.CourseID, course.CourseName)
+	}
+	return nil
+}
+
+// printCourse prints the summary of a course as a table
+func printCourse(garminClient *garmin.Client, course *garmin.Course) {
+	tbl := table.New("Field", "Value")
+	tbl.AddRow("ID", course.CourseID)
+	tbl.AddRow("Name", course.CourseName)
+	if course.Description != "" {
+		tbl.AddRow("Description", course.Description)
+	}
+	tbl.AddRow("Type", garminClient.ActivityTypeKey(course.ActivityTypePK))
+	tbl.AddRow("Distance", fmt.Sprintf("%.2f km", course.DistanceMeter/1000))
+	tbl.AddRow("Ascent", fmt.Sprintf("%.0f m", course.ElevationGainMeter))
+	tbl.AddRow("Descent", fmt.Sprintf("%.0f m", course.ElevationLossMeter))
+	tbl.AddRow("Start", fmt.Sprintf("%.5f, %.5f", course.StartLatitude, course.StartLongitude))
+	if len(course.GeoPoints) > 0 {
+		tbl.AddRow("Points", len(course.GeoPoints))
+	}
+	tbl.Print()
+}
+
+func parseCourseIDs(args []string) ([]int64, error) {
+	courseIDs := make([]int64, 0, len(args))
+	for _, arg := range args {
+		courseID, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid course ID %q: %w", arg, err)
+		}
+		courseIDs = append(courseIDs, courseID)
+	}
+	return courseIDs, nil
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/sstent/go-garth-cli/pkg/fit"
+)
+
+var (
+	fitCmd = &cobra.Command{
+		Use:   "fit",
+		Short: "Work with FIT activity files",
+		Long:  `Decode and inspect FIT files downloaded from Garmin Connect or exported from a device.`,
+	}
+
+	fitInspectCmd = &cobra.Command{
+		Use:   "inspect [file]",
+		Short: "Inspect a FIT file",
+		Long: `Decode a FIT file and print a summary of its sessions, laps, devices and records.
+
+With --output csv the record stream is written as CSV; with --output json or
+--output yaml the decoded file is printed in full.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runFitInspect,
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(fitCmd)
+	fitCmd.AddCommand(fitInspectCmd)
+}
+
+func runFitInspect(cmd *cobra.Command, args []string) error {
+	file, err := fit.DecodeFile(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to decode %s: %w", args[0], err)
+	}
+
+	switch outputFormat := viper.GetString("output.format"); outputFormat {
+	case "json":
+		return printJSON(file)
+	case "yaml":
+		return printYAML(file)
+	case "csv":
+		return writeFitRecordsCSV(file.Records)
+	case "table":
+		printFitSummary(file)
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+}
+
+// printFitSummary renders the file header, sessions, laps and devices as tables
+func printFitSummary(file *fit.File) {
+	summary := table.New("Field", "Value")
+	summary.AddRow("Protocol Version", fmt.Sprintf("%d.%d", file.Header.ProtocolVersion>>4, file.Header.ProtocolVersion&0x0F))
+	summary.AddRow("Profile Version", fmt.Sprintf("%.2f", float64(file.Header.ProfileVersion)/100))
+	if file.FileID != nil {
+		summary.AddRow("File Type", file.FileID.Type)
+		summary.AddRow("Manufacturer", file.FileID.Manufacturer)
+		summary.AddRow("Product", file.FileID.Product)
+		summary.AddRow("Created", formatFitTime(file.FileID.TimeCreated))
+	}
+	summary.AddRow("Messages", len(file.Messages))
+	summary.AddRow("Records", len(file.Records))
+	if len(file.Records) > 0 {
+		summary.AddRow("First Record", formatFitTime(file.Records[0].Timestamp))
+		summary.AddRow("Last Record", formatFitTime(file.Records[len(file.Records)-1].Timestamp))
+	}
+	summary.AddRow("Events", len(file.Events))
+	summary.Print()
+
+	if len(file.Sessions) > 0 {
+		fmt.Println()
+		sessions := table.New("Start", "Sport", "Distance (km)", "Time", "Avg HR", "Max HR", "Avg Power", "Ascent (m)", "Calories")
+		for _, s := range file.Sessions {
+			sessions.AddRow(
+				formatFitTime(s.StartTime),
+				s.Sport,
+				fmt.Sprintf("%.2f", s.TotalDistance/1000),
+				formatDuration(s.TotalTimerTime),
+				s.AvgHeartRate,
+				s.MaxHeartRate,
+				s.AvgPower,
+				s.TotalAscent,
+				s.TotalCalories,
+			)
+		}
+		sessions.Print()
+	}
+
+	if len(file.Laps) > 0 {
+		fmt.Println()
+		laps := table.New("Lap", "Start", "Distance (km)", "Time", "Avg Speed (km/h)", "Avg HR", "Avg Power")
+		for i, l := range file.Laps {
+			laps.AddRow(
+				i+1,
+				formatFitTime(l.StartTime),
+				fmt.Sprintf("%.2f", l.TotalDistance/1000),
+				formatDuration(l.TotalTimerTime),
+				fmt.Sprintf("%.1f", l.AvgSpeed*3.6),
+				l.AvgHeartRate,
+				l.AvgPower,
+			)
+		}
+		laps.Print()
+	}
+
+	if len(file.DeviceInfos) > 0 {
+		fmt.Println()
+		devices := table.New("Index", "Type", "Manufacturer", "Product", "Name", "Software", "Serial")
+		for _, d := range file.DeviceInfos {
+			devices.AddRow(d.DeviceIndex, d.DeviceType, d.Manufacturer, d.Product, d.ProductName,
+				fmt.Sprintf("%.2f", d.SoftwareVersion), d.SerialNumber)
+		}
+		devices.Print()
+	}
+}
+
+// writeFitRecordsCSV writes the record stream as CSV, leaving missing values empty
+func writeFitRecordsCSV(records []fit.Record) error {
+	writer := csv.NewWriter(os.Stdout)
+	defer writer.Flush()
+
+	writer.Write([]string{"timestamp", "latitude", "longitude", "altitude", "heart_rate", "cadence", "distance", "speed", "power", "temperature"})
+	for _, r := range records {
+		row := []string{formatFitTime(r.Timestamp), optFloat(r.Latitude), optFloat(r.Longitude), optFloat(r.Altitude), "",
+			optFloat(r.Cadence), optFloat(r.Distance), optFloat(r.Speed), "", ""}
+		if r.HeartRate != nil {
+			row[4] = strconv.Itoa(int(*r.HeartRate))
+		}
+		if r.Power != nil {
+			row[8] = strconv.Itoa(int(*r.Power))
+		}
+		if r.Temperature != nil {
+			row[9] = strconv.Itoa(int(*r.Temperature))
+		}
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+	return nil
+}
+
+func optFloat(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v, 'f', -1, 64)
+}
+
+func formatFitTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
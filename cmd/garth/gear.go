@@ -0,0 +1,347 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+	"github.com/sstent/go-garth/config"
+)
+
+var (
+	gearCmd = &cobra.Command{
+		Use:   "gear",
+		Short: "Manage gear such as shoes and bikes",
+		Long: `List gear, show its usage and link it to activities. Gear is referred to by
+its UUID or its name.
+
+Gear that has covered its distance limit is flagged in the stats. The limit is
+taken from the limits in the config file, else the maximum distance set on
+Garmin Connect, else the alert distance in the config file:
+
+  gear:
+    alert_distance: 800km
+    limits:
+      "Road Bike": 10000km`,
+	}
+
+	gearListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List gear",
+		Args:  cobra.NoArgs,
+		RunE:  runGearList,
+	}
+
+	gearShowCmd = &cobra.Command{
+		Use:   "show [gear]",
+		Short: "Show a piece of gear and its usage",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runGearShow,
+	}
+
+	gearLinkCmd = &cobra.Command{
+		Use:   "link [gear] [activityID...]",
+		Short: "Link gear to activities",
+		Args:  cobra.MinimumNArgs(2),
+		RunE:  runGearLink,
+	}
+
+	gearUnlinkCmd = &cobra.Command{
+		Use:   "unlink [gear] [activityID...]",
+		Short: "Remove gear from activities",
+		Args:  cobra.MinimumNArgs(2),
+		RunE:  runGearLink,
+	}
+
+	gearStatsCmd = &cobra.Command{
+		Use:   "stats [gear]",
+		Short: "Show distance and time per gear",
+		Long: `Show the activity count, total distance and total time of a piece of gear, or
+of all active gear, flagging gear past its distance limit.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runGearStats,
+	}
+
+	// Flags for gear commands
+	gearAll      bool
+	gearActivity int
+)
+
+func init() {
+	rootCmd.AddCommand(gearCmd)
+
+	gearCmd.AddCommand(gearListCmd)
+	gearListCmd.Flags().BoolVar(&gearAll, "all", false, "Include retired gear")
+	gearListCmd.Flags().IntVar(&gearActivity, "activity", 0, "List the gear linked to an activity")
+
+	gearCmd.AddCommand(gearShowCmd)
+	gearCmd.AddCommand(gearLinkCmd)
+	gearCmd.AddCommand(gearUnlinkCmd)
+
+	gearCmd.AddCommand(gearStatsCmd)
+	gearStatsCmd.Flags().BoolVar(&gearAll, "all", false, "Include retired gear")
+}
+
+func runGearList(cmd *cobra.Command, args []string) error {
+	garminClient, err := newGarminClient()
+	if err != nil {
+		return err
+	}
+
+	var gear []garmin.Gear
+	if gearActivity > 0 {
+		gear, err = garminClient.GetActivityGear(int64(gearActivity))
+	} else {
+		gear, err = garminClient.ListGear()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list gear: %w", err)
+	}
+	if !gearAll && gearActivity == 0 {
+		gear = activeGear(gear)
+	}
+
+	switch outputFormat := viper.GetString("output.format"); outputFormat {
+	case "json":
+		return printJSON(gear)
+	case "yaml":
+		return printYAML(gear)
+	case "table":
+		if len(gear) == 0 {
+			fmt.Println("No gear found.")
+			return nil
+		}
+		tbl := table.New("UUID", "Name", "Type", "Status", "Since")
+		for _, g := range gear {
+			since := ""
+			if g.DateBegin != nil {
+				since = strings.SplitN(*g.DateBegin, "T", 2)[0]
+			}
+			tbl.AddRow(g.UUID, garmin.GearName(g), g.GearTypeName, g.GearStatusName, since)
+		}
+		tbl.Print()
+	default:
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+	return nil
+}
+
+func runGearShow(cmd *cobra.Command, args []string) error {
+	garminClient, err := newGarminClient()
+	if err != nil {
+		return err
+	}
+
+	gear, err := findGear(garminClient, args[0])
+	if err != nil {
+		return err
+	}
+	limit, err := gearLimit(cfg, gear)
+	if err != nil {
+		return err
+	}
+	usage, err := garminClient.GetGearUsage(gear, limit)
+	if err != nil {
+		return fmt.Errorf("failed to get gear usage: %w", err)
+	}
+
+	switch outputFormat := viper.GetString("output.format"); outputFormat {
+	case "json":
+		return printJSON(struct {
+			garmin.Gear
+			Usage *garmin.GearUsage `json:"usage"`
+		}{gear, usage})
+	case "yaml":
+		return printYAML(struct {
+			garmin.Gear
+			Usage *garmin.GearUsage `json:"usage"`
+		}{gear, usage})
+	case "table":
+		tbl := table.New("Field", "Value")
+		tbl.AddRow("UUID", gear.UUID)
+		tbl.AddRow("Name", garmin.GearName(gear))
+		tbl.AddRow("Make", gear.GearMakeName)
+		tbl.AddRow("Model", gear.GearModelName)
+		tbl.AddRow("Type", gear.GearTypeName)
+		tbl.AddRow("Status", gear.GearStatusName)
+		if gear.DateBegin != nil {
+			tbl.AddRow("In use since", strings.SplitN(*gear.DateBegin, "T", 2)[0])
+		}
+		if gear.DateEnd != nil {
+			tbl.AddRow("Retired on", strings.SplitN(*gear.DateEnd, "T", 2)[0])
+		}
+		tbl.AddRow("Activities", usage.Activities)
+		tbl.AddRow("Distance", fmt.Sprintf("%.1f km", usage.Distance/1000))
+		tbl.AddRow("Time", formatDuration(usage.Seconds))
+		if usage.Limit > 0 {
+			tbl.AddRow("Limit", fmt.Sprintf("%.0f km", usage.Limit/1000))
+		}
+		tbl.Print()
+		if usage.Alert {
+			fmt.Printf("\n%s has passed its %.0f km limit.\n", usage.Name, usage.Limit/1000)
+		}
+	default:
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+	return nil
+}
+
+// runGearLink links or unlinks gear, depending on the command
+func runGearLink(cmd *cobra.Command, args []string) error {
+	activityIDs := make([]int, 0, len(args)-1)
+	for _, arg := range args[1:] {
+		id, err := strconv.Atoi(arg)
+		if err != nil {
+			return fmt.Errorf("invalid activity ID %q: %w", arg, err)
+		}
+		activityIDs = append(activityIDs, id)
+	}
+
+	garminClient, err := newGarminClient()
+	if err != nil {
+		return err
+	}
+
+	gear, err := findGear(garminClient, args[0])
+	if err != nil {
+		return err
+	}
+
+	unlink := cmd.Name() == "unlink"
+	for _, id := range activityIDs {
+		if unlink {
+			err = garminClient.UnlinkGear(gear.UUID, id)
+		} else {
+			err = garminClient.LinkGear(gear.UUID, id)
+		}
+		if err != nil {
+			return err
+		}
+		if unlink {
+			fmt.Printf("Removed %s from activity %d\n", garmin.GearName(gear), id)
+		} else {
+			fmt.Printf("Linked %s to activity %d\n", garmin.GearName(gear), id)
+		}
+	}
+	return nil
+}
+
+func runGearStats(cmd *cobra.Command, args []string) error {
+	garminClient, err := newGarminClient()
+	if err != nil {
+		return err
+	}
+
+	allGear, err := garminClient.ListGear()
+	if err != nil {
+		return fmt.Errorf("failed to list gear: %w", err)
+	}
+	gear := allGear
+	if len(args) == 1 {
+		g, err := garmin.FindGear(allGear, args[0])
+		if err != nil {
+			return err
+		}
+		gear = []garmin.Gear{g}
+	} else if !gearAll {
+		gear = activeGear(allGear)
+	}
+
+	var usages []*garmin.GearUsage
+	for _, g := range gear {
+		limit, err := gearLimit(cfg, g)
+		if err != nil {
+			return err
+		}
+		usage, err := garminClient.GetGearUsage(g, limit)
+		if err != nil {
+			return fmt.Errorf("failed to get usage of %s: %w", garmin.GearName(g), err)
+		}
+		usages = append(usages, usage)
+	}
+
+	switch outputFormat := viper.GetString("output.format"); outputFormat {
+	case "json":
+		return printJSON(usages)
+	case "yaml":
+		return printYAML(usages)
+	case "table":
+		if len(usages) == 0 {
+			fmt.Println("No gear found.")
+			return nil
+		}
+		tbl := table.New("Name", "Type", "Status", "Activities", "Distance", "Time", "Limit", "Alert")
+		for _, usage := range usages {
+			limit, alert := "", ""
+			if usage.Limit > 0 {
+				limit = fmt.Sprintf("%.0f km", usage.Limit/1000)
+			}
+			if usage.Alert {
+				alert = "REPLACE"
+			}
+			tbl.AddRow(usage.Name, usage.Type, usage.Status, usage.Activities,
+				fmt.Sprintf("%.1f km", usage.Distance/1000), formatDuration(usage.Seconds), limit, alert)
+		}
+		tbl.Print()
+	default:
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+	return nil
+}
+
+// findGear looks up a piece of gear by UUID or name
+func findGear(garminClient *garmin.Client, ref string) (garmin.Gear, error) {
+	gear, err := garminClient.ListGear()
+	if err != nil {
+		return garmin.Gear{}, fmt.Errorf("failed to list gear: %w", err)
+	}
+	return garmin.FindGear(gear, ref)
+}
+
+// activeGear filters out retired gear
+func activeGear(gear []garmin.Gear) []garmin.Gear {
+	var active []garmin.Gear
+	for _, g := range gear {
+		if !strings.EqualFold(g.GearStatusName, garmin.GearStatusRetired) {
+			active = append(active, g)
+		}
+	}
+	return active
+}
+
+// gearLimit returns the distance limit of a piece of gear in meters: its
+// entry in the configured limits, else the maximum distance set on Garmin
+// Connect, else the configured alert distance. Zero means no limit.
+func gearLimit(cfg *config.Config, gear garmin.Gear) (float64, error) {
+	var value string
+	if cfg != nil {
+		for key, limit := range cfg.Gear.Limits {
+			// Config keys may have been lowercased
+			if strings.EqualFold(key, gear.UUID) || strings.EqualFold(key, garmin.GearName(gear)) {
+				value = limit
+				break
+			}
+		}
+	}
+	if value == "" {
+		if gear.MaximumMeters > 0 {
+			return gear.MaximumMeters, nil
+		}
+		if cfg != nil {
+			value = cfg.Gear.AlertDistance
+		}
+	}
+	if value == "" {
+		return 0, nil
+	}
+	meters, err := garmin.ParseDistance(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid gear distance limit %q: %w", value, err)
+	}
+	return meters, nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+	"github.com/sstent/go-garth/config"
+)
+
+func TestGearLimit_Precedence(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Gear.AlertDistance = "800km"
+	cfg.Gear.Limits = map[string]string{"road bike": "10000km"}
+
+	var shoes, bike, roadBike garmin.Gear
+	shoes.DisplayName = "Pegasus"
+	bike.DisplayName = "Gravel Bike"
+	bike.MaximumMeters = 20000000
+	roadBike.DisplayName = "Road Bike"
+	roadBike.MaximumMeters = 20000000
+
+	// The global alert distance only applies without a Garmin limit
+	limit, err := gearLimit(cfg, shoes)
+	require.NoError(t, err)
+	assert.Equal(t, 800000.0, limit)
+
+	limit, err = gearLimit(cfg, bike)
+	require.NoError(t, err)
+	assert.Equal(t, 20000000.0, limit)
+
+	// A per-gear limit overrides the Garmin limit
+	limit, err = gearLimit(cfg, roadBike)
+	require.NoError(t, err)
+	assert.Equal(t, 10000000.0, limit)
+
+	limit, err = gearLimit(nil, bike)
+	require.NoError(t, err)
+	assert.Equal(t, 20000000.0, limit)
+}
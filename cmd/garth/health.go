@@ -451,7 +451,7 @@ func runStress(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	outputFormat := viper.GetString("output")
+	outputFormat := viper.GetString("output.format")
 
 	switch outputFormat {
 	case "json":
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// printJSON prints v as indented JSON.
+func printJSON(v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal result to JSON: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+// printYAML prints v as YAML. The value is round-tripped through JSON so YAML
+// keys match the JSON field names.
+func printYAML(v interface{}) error {
+	jsonData, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal result: %w", err)
+	}
+	var generic interface{}
+	if err := json.Unmarshal(jsonData, &generic); err != nil {
+		return fmt.Errorf("failed to convert result: %w", err)
+	}
+	data, err := yaml.Marshal(generic)
+	if err != nil {
+		return fmt.Errorf("failed to marshal result to YAML: %w", err)
+	}
+	fmt.Print(string(data))
+	return nil
+}
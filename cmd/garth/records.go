@@ -0,0 +1,235 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+var (
+	recordsCmd = &cobra.Command{
+		Use:   "records",
+		Short: "List personal records",
+		Long: `List your personal records from Garmin Connect, such as your fastest 5K or
+longest ride, with the date and activity of each.`,
+		Args: cobra.NoArgs,
+		RunE: runRecords,
+	}
+
+	bestEffortsCmd = &cobra.Command{
+		Use:   "best",
+		Short: "Compute best efforts from activity samples",
+		Long: `Compute the best effort of each activity over a distance or a duration from
+its samples, oldest first, marking each effort that set a new record:
+
+  garth records best --distance 400m --type running --from 2024-01-01
+  garth records best --duration 20m --metric power --type cycling
+
+Distance efforts are the fastest time over the distance; duration efforts are
+the highest average power, heart rate or speed over the duration.`,
+		Args: cobra.NoArgs,
+		RunE: runBestEfforts,
+	}
+
+	// Flags for bestEffortsCmd
+	bestDistance string
+	bestDuration string
+	bestMetric   string
+)
+
+func init() {
+	rootCmd.AddCommand(recordsCmd)
+
+	recordsCmd.AddCommand(bestEffortsCmd)
+	bestEffortsCmd.Flags().StringVar(&bestDistance, "distance", "", "Effort distance (e.g., 400m, 5km, 1mi)")
+	bestEffortsCmd.Flags().StringVar(&bestDuration, "duration", "", "Effort duration (e.g., 20m, 1h)")
+	bestEffortsCmd.Flags().StringVar(&bestMetric, "metric", "power", "Metric averaged over a duration (power, heart-rate, speed)")
+	bestEffortsCmd.Flags().StringVar(&activityType, "type", "", "Filter activities by type (e.g., running, cycling)")
+	bestEffortsCmd.Flags().StringVar(&activityDateFrom, "from", "", "Start date for filtering activities (YYYY-MM-DD)")
+	bestEffortsCmd.Flags().StringVar(&activityDateTo, "to", "", "End date for filtering activities (YYYY-MM-DD)")
+}
+
+func runRecords(cmd *cobra.Command, args []string) error {
+	garminClient, err := newGarminClient()
+	if err != nil {
+		return err
+	}
+
+	records, err := garminClient.GetRecords()
+	if err != nil {
+		return fmt.Errorf("failed to get personal records: %w", err)
+	}
+
+	switch outputFormat := viper.GetString("output.format"); outputFormat {
+	case "json":
+		return printJSON(records)
+	case "yaml":
+		return printYAML(records)
+	case "table":
+		if len(records) == 0 {
+			fmt.Println("No personal records found.")
+			return nil
+		}
+		tbl := table.New("Record", "Value", "Date", "Activity ID", "Activity")
+		for _, record := range records {
+			activityID := ""
+			if record.ActivityID > 0 {
+				activityID = fmt.Sprintf("%d", record.ActivityID)
+			}
+			date := ""
+			if !record.Date.IsZero() {
+				date = record.Date.Format("2006-01-02")
+			}
+			tbl.AddRow(record.Name, formatRecordValue(record), date, activityID, record.ActivityName)
+		}
+		tbl.Print()
+	default:
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+	return nil
+}
+
+// formatRecordValue formats a record's value in the unit of its kind
+func formatRecordValue(record garmin.Record) string {
+	switch record.Kind {
+	case garmin.RecordTime:
+		return formatDuration(record.Value)
+	case garmin.RecordDistance:
+		return fmt.Sprintf("%.2f km", record.Value/1000)
+	case garmin.RecordElevation:
+		return fmt.Sprintf("%.0f m", record.Value)
+	case garmin.RecordPower:
+		return fmt.Sprintf("%.0f W", record.Value)
+	default:
+		return fmt.Sprintf("%.0f", record.Value)
+	}
+}
+
+// bestEffort is a row of the best efforts report
+type bestEffort struct {
+	garmin.Effort
+	Date   time.Time `json:"date"`
+	Record bool      `json:"record"`
+}
+
+func runBestEfforts(cmd *cobra.Command, args []string) error {
+	if (bestDistance == "") == (bestDuration == "") {
+		return fmt.Errorf("specify either --distance or --duration")
+	}
+
+	var meters float64
+	var duration time.Duration
+	var err error
+	if bestDistance != "" {
+		if meters, err = garmin.ParseDistance(bestDistance); err != nil || meters <= 0 {
+			return fmt.Errorf("invalid --distance %q", bestDistance)
+		}
+	} else {
+		if duration, err = garmin.ParseDuration(bestDuration); err != nil || duration <= 0 {
+			return fmt.Errorf("invalid --duration %q", bestDuration)
+		}
+	}
+
+	var metric func(*garmin.ActivitySamples) garmin.Series
+	unit := ""
+	switch strings.ToLower(bestMetric) {
+	case "power":
+		metric, unit = func(s *garmin.ActivitySamples) garmin.Series { return s.Power }, "W"
+	case "heart-rate", "hr":
+		metric, unit = func(s *garmin.ActivitySamples) garmin.Series { return s.HeartRate }, "bpm"
+	case "speed":
+		metric, unit = func(s *garmin.ActivitySamples) garmin.Series { return s.Speed }, "m/s"
+	default:
+		return fmt.Errorf("unsupported metric %q (use power, heart-rate or speed)", bestMetric)
+	}
+
+	filters, err := activityFilterOptions()
+	if err != nil {
+		return err
+	}
+
+	garminClient, err := newGarminClient()
+	if err != nil {
+		return err
+	}
+
+	activities, err := garminClient.ListActivities(filters)
+	if err != nil {
+		return fmt.Errorf("failed to list activities: %w", err)
+	}
+	// Oldest first, so that each record improves on the ones before it
+	slices.Reverse(activities)
+
+	var efforts []bestEffort
+	for _, activity := range activities {
+		if meters > 0 && activity.Distance < meters {
+			continue
+		}
+		if duration > 0 && activity.Duration < duration.Seconds() {
+			continue
+		}
+
+		samples, err := garminClient.GetActivitySamples(int(activity.ActivityID))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to get samples of activity %d: %v\n", activity.ActivityID, err)
+			continue
+		}
+
+		var effort garmin.Effort
+		var ok bool
+		if meters > 0 {
+			effort, ok = garmin.BestDistanceEffort(samples, meters)
+		} else {
+			effort, ok = garmin.BestDurationEffort(samples, metric(samples), duration)
+		}
+		if !ok {
+			continue
+		}
+		effort.ActivityName = activity.ActivityName
+
+		row := bestEffort{Effort: effort, Date: activity.StartTimeLocal.Time, Record: true}
+		for _, previous := range efforts {
+			if meters > 0 && previous.Seconds <= effort.Seconds || duration > 0 && previous.Average >= effort.Average {
+				row.Record = false
+				break
+			}
+		}
+		efforts = append(efforts, row)
+	}
+
+	switch outputFormat := viper.GetString("output.format"); outputFormat {
+	case "json":
+		return printJSON(efforts)
+	case "yaml":
+		return printYAML(efforts)
+	case "table":
+		if len(efforts) == 0 {
+			fmt.Println("No matching efforts found.")
+			return nil
+		}
+		tbl := table.New("Date", "Activity ID", "Activity", "Effort", "Record")
+		for _, effort := range efforts {
+			value := formatDuration(effort.Seconds)
+			if duration > 0 {
+				value = fmt.Sprintf("%.1f %s", effort.Average, unit)
+			}
+			record := ""
+			if effort.Record {
+				record = "PR"
+			}
+			tbl.AddRow(effort.Date.Format("2006-01-02"), effort.ActivityID, effort.ActivityName, value, record)
+		}
+		tbl.Print()
+	default:
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+	return nil
+}
@@ -50,7 +50,7 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.config/garth/config.yaml)")
 	rootCmd.PersistentFlags().StringVar(&userConfigDir, "config-dir", "", "config directory (default is $HOME/.config/garth)")
 
-	rootCmd.PersistentFlags().String("output", "table", "output format (json, table, csv)")
+	rootCmd.PersistentFlags().String("output", "table", "output format (json, table, csv, yaml)")
 	rootCmd.PersistentFlags().Bool("verbose", false, "enable verbose output")
 	rootCmd.PersistentFlags().String("date-from", "", "start date for data fetching (YYYY-MM-DD)")
 	rootCmd.PersistentFlags().String("date-to", "", "end date for data fetching (YYYY-MM-DD)")
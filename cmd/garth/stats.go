@@ -16,11 +16,43 @@ import (
 )
 
 var (
+	statsCmd = &cobra.Command{
+		Use:   "stats",
+		Short: "Get daily activity statistics",
+		Long:  `Provides commands to fetch daily statistics such as distance covered and calories burned.`,
+	}
+
+	distanceCmd = &cobra.Command{
+		Use:   "distance",
+		Short: "Get distance data",
+		Long:  `Fetch the distance covered each day, for today or the current year.`,
+		RunE:  runDistance,
+	}
+
+	caloriesCmd = &cobra.Command{
+		Use:   "calories",
+		Short: "Get calories data",
+		Long:  `Fetch the active calories burned each day, for today or from a given date.`,
+		RunE:  runCalories,
+	}
+
 	statsYear      bool
 	statsAggregate string
 	statsFrom      string
 )
 
+func init() {
+	rootCmd.AddCommand(statsCmd)
+
+	statsCmd.AddCommand(distanceCmd)
+	distanceCmd.Flags().BoolVar(&statsYear, "year", false, "Fetch data for the current year")
+	distanceCmd.Flags().StringVar(&statsAggregate, "aggregate", "", "Aggregate data by (day, week, month, year)")
+
+	statsCmd.AddCommand(caloriesCmd)
+	caloriesCmd.Flags().StringVar(&statsFrom, "from", "", "Start date for data fetching (YYYY-MM-DD)")
+	caloriesCmd.Flags().StringVar(&statsAggregate, "aggregate", "", "Aggregate data by (day, week, month, year)")
+}
+
 func runDistance(cmd *cobra.Command, args []string) error {
 	garminClient, err := garmin.NewClient("www.garmin.com") // TODO: Domain should be configurable
 	if err != nil {
@@ -36,7 +68,7 @@ func runDistance(cmd *cobra.Command, args []string) error {
 	if statsYear {
 		now := time.Now()
 		startDate = time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, now.Location())
-		endDate = time.Date(now.Year(), time.December, 31, 0, 0, 0, 0, now.Location()) // Last day of the year
+		endDate = now // Days after today have no data yet
 	} else {
 		// Default to today if no specific range or year is given
 		startDate = time.Now()
@@ -92,7 +124,7 @@ func runDistance(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	outputFormat := viper.GetString("output")
+	outputFormat := viper.GetString("output.format")
 
 	switch outputFormat {
 	case "json":
@@ -201,7 +233,7 @@ func runCalories(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	outputFormat := viper.GetString("output")
+	outputFormat := viper.GetString("output.format")
 
 	switch outputFormat {
 	case "json":
@@ -0,0 +1,385 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+var (
+	workoutsCmd = &cobra.Command{
+		Use:   "workouts",
+		Short: "Manage structured workouts",
+		Long: `List, create, delete and schedule structured workouts on Garmin Connect.
+
+Workouts are written in YAML:
+
+  name: 5x1K
+  sport: running
+  steps:
+    - type: warmup
+      duration: 15m
+    - type: repeat
+      count: 5
+      steps:
+        - type: interval
+          distance: 1km
+          pace: 4:00-4:10/km
+        - type: recovery
+          duration: 2m
+          hr: z2
+    - type: cooldown
+
+Step types are ` + strings.Join(garmin.WorkoutStepKeys(), ", ") + `. A step ends after its
+duration or distance, or at the lap button when it has neither. Targets are
+hr (bpm range or zone), power (watt range or zone) or pace (range per km or mi).`,
+	}
+
+	listWorkoutsCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List workouts",
+		Args:  cobra.NoArgs,
+		RunE:  runListWorkouts,
+	}
+
+	showWorkoutCmd = &cobra.Command{
+		Use:   "show [workoutID]",
+		Short: "Show a workout and its steps",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runShowWorkout,
+	}
+
+	createWorkoutCmd = &cobra.Command{
+		Use:   "create",
+		Short: "Create a workout from a YAML file",
+		Long: `Create a workout from a YAML file. The file is validated against the step
+schema first and every problem is reported; use --dry-run to validate without
+creating the workout.`,
+		Args: cobra.NoArgs,
+		RunE: runCreateWorkout,
+	}
+
+	deleteWorkoutCmd = &cobra.Command{
+		Use:   "delete [workoutID...]",
+		Short: "Delete workouts",
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  runDeleteWorkouts,
+	}
+
+	scheduleWorkoutCmd = &cobra.Command{
+		Use:   "schedule [workoutID]",
+		Short: "Schedule a workout on the calendar",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runScheduleWorkout,
+	}
+
+	// Flags for createWorkoutCmd
+	workoutFile   string
+	workoutDryRun bool
+
+	// Flags for deleteWorkoutCmd
+	workoutDeleteYes bool
+
+	// Flags for scheduleWorkoutCmd
+	workoutDate string
+)
+
+func init() {
+	rootCmd.AddCommand(workoutsCmd)
+
+	workoutsCmd.AddCommand(listWorkoutsCmd)
+	workoutsCmd.AddCommand(showWorkoutCmd)
+
+	workoutsCmd.AddCommand(createWorkoutCmd)
+	createWorkoutCmd.Flags().StringVarP(&workoutFile, "file", "f", "", "Workout YAML file (- for stdin)")
+	createWorkoutCmd.Flags().BoolVar(&workoutDryRun, "dry-run", false, "Validate the workout and show it without creating it")
+	_ = createWorkoutCmd.MarkFlagRequired("file")
+
+	workoutsCmd.AddCommand(deleteWorkoutCmd)
+	deleteWorkoutCmd.Flags().BoolVarP(&workoutDeleteYes, "yes", "y", false, "Delete without asking for confirmation")
+
+	workoutsCmd.AddCommand(scheduleWorkoutCmd)
+	scheduleWorkoutCmd.Flags().StringVar(&workoutDate, "date", "", "Date to schedule the workout on (YYYY-MM-DD)")
+	_ = scheduleWorkoutCmd.MarkFlagRequired("date")
+}
+
+func runListWorkouts(cmd *cobra.Command, args []string) error {
+	garminClient, err := newGarminClient()
+	if err != nil {
+		return err
+	}
+
+	workouts, err := garminClient.ListWorkouts()
+	if err != nil {
+		return fmt.Errorf("failed to list workouts: %w", err)
+	}
+
+	switch outputFormat := viper.GetString("output.format"); outputFormat {
+	case "json":
+		return printJSON(workouts)
+	case "yaml":
+		return printYAML(workouts)
+	case "table":
+		if len(workouts) == 0 {
+			fmt.Println("No workouts found.")
+			return nil
+		}
+		tbl := table.New("ID", "Name", "Sport", "Duration", "Updated")
+		for _, workout := range workouts {
+			duration, updated := "", ""
+			if workout.EstimatedDurationInSecs != nil {
+				duration = formatDuration(float64(*workout.EstimatedDurationInSecs))
+			}
+			if workout.UpdatedDate != nil {
+				updated = strings.SplitN(*workout.UpdatedDate, "T", 2)[0]
+			}
+			tbl.AddRow(workout.WorkoutID, workout.WorkoutName, workout.SportType.SportTypeKey, duration, updated)
+		}
+		tbl.Print()
+	default:
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+	return nil
+}
+
+func runShowWorkout(cmd *cobra.Command, args []string) error {
+	workoutID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid workout ID %q: %w", args[0], err)
+	}
+
+	garminClient, err := newGarminClient()
+	if err != nil {
+		return err
+	}
+
+	workout, err := garminClient.GetWorkout(workoutID)
+	if err != nil {
+		return fmt.Errorf("failed to get workout %d: %w", workoutID, err)
+	}
+	return printWorkout(workout)
+}
+
+func runCreateWorkout(cmd *cobra.Command, args []string) error {
+	var r io.Reader = os.Stdin
+	if workoutFile != "-" {
+		f, err := os.Open(workoutFile)
+		if err != nil {
+			return fmt.Errorf("failed to open workout file: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	spec, err := garmin.ReadWorkoutSpec(r)
+	if err != nil {
+		return fmt.Errorf("invalid workout %s:\n%w", workoutFile, err)
+	}
+
+	if workoutDryRun {
+		workout, err := spec.Workout()
+		if err != nil {
+			return err
+		}
+		return printWorkout(workout)
+	}
+
+	garminClient, err := newGarminClient()
+	if err != nil {
+		return err
+	}
+
+	workout, err := garminClient.CreateWorkoutFromSpec(*spec)
+	if err != nil {
+		return fmt.Errorf("failed to create workout: %w", err)
+	}
+	if viper.GetString("output.format") == "table" {
+		fmt.Printf("Created workout %d\n", workout.WorkoutID)
+	}
+	return printWorkout(workout)
+}
+
+func runDeleteWorkouts(cmd *cobra.Command, args []string) error {
+	workoutIDs := make([]int64, 0, len(args))
+	for _, arg := range args {
+		workoutID, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid workout ID %q: %w", arg, err)
+		}
+		workoutIDs = append(workoutIDs, workoutID)
+	}
+
+	garminClient, err := newGarminClient()
+	if err != nil {
+		return err
+	}
+
+	var workouts []*garmin.Workout
+	for _, workoutID := range workoutIDs {
+		workout, err := garminClient.GetWorkout(workoutID)
+		if err != nil {
+			return fmt.Errorf("failed to get workout %d: %w", workoutID, err)
+		}
+		workouts = append(workouts, workout)
+	}
+
+	fmt.Printf("The following %d workouts will be deleted:\n", len(workouts))
+	for _, workout := range workouts {
+		fmt.Printf("  %d  %s (%s)\n", workout.WorkoutID, workout.WorkoutName, workout.SportType.SportTypeKey)
+	}
+	if !workoutDeleteYes {
+		confirmed, err := confirm(fmt.Sprintf("Delete %d workouts? [y/N] ", len(workouts)))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("Aborted.")
+			return nil
+		}
+	}
+
+	for _, workout := range workouts {
+		if _, err := garminClient.DeleteWorkout(workout.WorkoutID); err != nil {
+			return fmt.Errorf("failed to delete workout %d: %w", workout.WorkoutID, err)
+		}
+		fmt.Printf("Deleted workout %d (%s)\n", workout.WorkoutID, workout.WorkoutName)
+	}
+	return nil
+}
+
+func runScheduleWorkout(cmd *cobra.Command, args []string) error {
+	workoutID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid workout ID %q: %w", args[0], err)
+	}
+	date, err := time.Parse("2006-01-02", workoutDate)
+	if err != nil {
+		return fmt.Errorf("invalid date format: %w", err)
+	}
+
+	garminClient, err := newGarminClient()
+	if err != nil {
+		return err
+	}
+
+	scheduled, err := garminClient.ScheduleWorkout(workoutID, date)
+	if err != nil {
+		return fmt.Errorf("failed to schedule workout %d: %w", workoutID, err)
+	}
+
+	switch outputFormat := viper.GetString("output.format"); outputFormat {
+	case "json":
+		return printJSON(scheduled)
+	case "yaml":
+		return printYAML(scheduled)
+	case "table":
+		fmt.Printf("Scheduled workout %d on %s\n", workoutID, date.Format("2006-01-02"))
+	default:
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+	return nil
+}
+
+// printWorkout prints a workout in the configured output format. The table
+// format lists the steps, with the steps of repeat groups indented.
+func printWorkout(workout *garmin.Workout) error {
+	switch outputFormat := viper.GetString("output.format"); outputFormat {
+	case "json":
+		return printJSON(workout)
+	case "yaml":
+		return printYAML(workout)
+	case "table":
+		fmt.Printf("%s (%s)\n", workout.WorkoutName, workout.SportType.SportTypeKey)
+		if workout.Description != "" {
+			fmt.Println(workout.Description)
+		}
+		fmt.Println()
+		tbl := table.New("#", "Step", "End", "Target", "Notes")
+		var addSteps func(steps []garmin.WorkoutStep, indent string)
+		addSteps = func(steps []garmin.WorkoutStep, indent string) {
+			for _, step := range steps {
+				if step.StepType.StepTypeKey == garmin.StepRepeat {
+					iterations := 0
+					if step.NumberOfIterations != nil {
+						iterations = *step.NumberOfIterations
+					}
+					tbl.AddRow(step.StepOrder, indent+step.StepType.StepTypeKey, fmt.Sprintf("%dx", iterations), "", step.Description)
+					addSteps(step.WorkoutSteps, indent+"  ")
+					continue
+				}
+				tbl.AddRow(step.StepOrder, indent+step.StepType.StepTypeKey, formatWorkoutEnd(step), formatWorkoutTarget(step), step.Description)
+			}
+		}
+		for _, segment := range workout.WorkoutSegments {
+			addSteps(segment.WorkoutSteps, "")
+		}
+		tbl.Print()
+	default:
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+	return nil
+}
+
+// formatWorkoutEnd describes what ends a workout step
+func formatWorkoutEnd(step garmin.WorkoutStep) string {
+	if step.EndCondition == nil {
+		return ""
+	}
+	value := 0.0
+	if step.EndConditionValue != nil {
+		value = *step.EndConditionValue
+	}
+	switch step.EndCondition.ConditionTypeKey {
+	case garmin.EndTime:
+		return formatDuration(value)
+	case garmin.EndDistance:
+		if value < 1000 {
+			return fmt.Sprintf("%.0f m", value)
+		}
+		return fmt.Sprintf("%.2f km", value/1000)
+	case garmin.EndLapButton:
+		return "lap button"
+	default:
+		return step.EndCondition.ConditionTypeKey
+	}
+}
+
+// formatWorkoutTarget describes the target of a workout step
+func formatWorkoutTarget(step garmin.WorkoutStep) string {
+	if step.TargetType == nil || step.TargetType.WorkoutTargetTypeKey == garmin.TargetNone {
+		return ""
+	}
+	key := step.TargetType.WorkoutTargetTypeKey
+	name := map[string]string{
+		garmin.TargetHeartRate: "HR",
+		garmin.TargetPower:     "power",
+		garmin.TargetPace:      "pace",
+	}[key]
+	if step.ZoneNumber != nil && *step.ZoneNumber > 0 {
+		return fmt.Sprintf("%s zone %d", name, *step.ZoneNumber)
+	}
+	if step.TargetValueOne == nil || step.TargetValueTwo == nil {
+		return key
+	}
+	low, high := *step.TargetValueOne, *step.TargetValueTwo
+	switch key {
+	case garmin.TargetHeartRate:
+		return fmt.Sprintf("%.0f-%.0f bpm", low, high)
+	case garmin.TargetPower:
+		return fmt.Sprintf("%.0f-%.0f W", low, high)
+	case garmin.TargetPace:
+		// The faster pace is the higher speed
+		return fmt.Sprintf("%s - %s", formatPace(high, 1000, "km"), formatPace(low, 1000, "km"))
+	default:
+		return fmt.Sprintf("%s %g-%g", key, low, high)
+	}
+}
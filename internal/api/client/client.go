@@ -3,14 +3,18 @@ package client
 import (
 	"bytes"
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"io"
+	"math"
 	"mime/multipart"
 	"net/http"
 	"net/http/cookiejar"
 	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -283,20 +287,51 @@ func (c *Client) GetUserProfile() (*types.UserProfile, error) {
 
 // ConnectAPI makes a raw API request to the Garmin Connect API
 func (c *Client) ConnectAPI(path string, method string, params url.Values, body io.Reader) ([]byte, error) {
+	data, _, err := c.doRequest(path, method, params, body, "application/json", "application/json")
+	return data, err
+}
+
+// DownloadFile retrieves a file from the Garmin Connect API and returns its
+// content along with the Content-Type reported by the server
+func (c *Client) DownloadFile(path string, params url.Values) ([]byte, string, error) {
+	data, header, err := c.doRequest(path, "GET", params, nil, "", "*/*")
+	if err != nil {
+		return nil, "", err
+	}
+	return data, header.Get("Content-Type"), nil
+}
+
+// doRequest performs an authenticated request and returns the response body
+// and headers. Responses with a status of 400 or above are returned as APIError.
+func (c *Client) doRequest(path string, method string, params url.Values, body io.Reader, contentType, accept string) ([]byte, http.Header, error) {
 	scheme := "https"
 	if strings.HasPrefix(c.Domain, "127.0.0.1") {
 		scheme = "http"
 	}
+	// Callers escape path segments such as display names and gear UUIDs
+	// themselves, so keep their encoding rather than escaping it again
+	unescaped, err := url.PathUnescape(path)
+	if err != nil {
+		return nil, nil, &errors.APIError{
+			GarthHTTPError: errors.GarthHTTPError{
+				GarthError: errors.GarthError{
+					Message: "Invalid request path",
+					Cause:   err,
+				},
+			},
+		}
+	}
 	u := &url.URL{
 		Scheme:   scheme,
 		Host:     c.Domain,
-		Path:     path,
+		Path:     unescaped,
+		RawPath:  path,
 		RawQuery: params.Encode(),
 	}
 
 	req, err := http.NewRequest(method, u.String(), body)
 	if err != nil {
-		return nil, &errors.APIError{
+		return nil, nil, &errors.APIError{
 			GarthHTTPError: errors.GarthHTTPError{
 				GarthError: errors.GarthError{
 					Message: "Failed to create request",
@@ -308,15 +343,15 @@ func (c *Client) ConnectAPI(path string, method string, params url.Values, body
 
 	req.Header.Set("Authorization", c.AuthToken)
 	req.Header.Set("User-Agent", "garth-go-client/1.0")
-	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Accept", accept)
 
-	if body != nil && req.Header.Get("Content-Type") == "" {
-		req.Header.Set("Content-Type", "application/json")
+	if body != nil && contentType != "" {
+		req.Header.Set("Content-Type", contentType)
 	}
 
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return nil, &errors.APIError{
+		return nil, nil, &errors.APIError{
 			GarthHTTPError: errors.GarthHTTPError{
 				GarthError: errors.GarthError{
 					Message: "Request failed",
@@ -329,7 +364,7 @@ func (c *Client) ConnectAPI(path string, method string, params url.Values, body
 
 	if resp.StatusCode >= 400 {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, &errors.APIError{
+		return nil, nil, &errors.APIError{
 			GarthHTTPError: errors.GarthHTTPError{
 				StatusCode: resp.StatusCode,
 				Response:   string(bodyBytes),
@@ -341,7 +376,8 @@ func (c *Client) ConnectAPI(path string, method string, params url.Values, body
 		}
 	}
 
-	return io.ReadAll(resp.Body)
+	data, err := io.ReadAll(resp.Body)
+	return data, resp.Header, err
 }
 
 func tryReadErrorBody(r io.Reader) string {
@@ -365,11 +401,19 @@ func (c *Client) Upload(filePath string) error {
 	}
 	defer file.Close()
 
+	_, err = c.UploadActivity(filepath.Base(filePath), file)
+	return err
+}
+
+// UploadActivity uploads an activity file (FIT, GPX or TCX) read from r.
+// Garmin answers files it has already imported with 409 Conflict; the
+// result is returned without an error and lists the duplicate in Failures.
+func (c *Client) UploadActivity(filename string, r io.Reader) (*types.UploadResult, error) {
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
-	part, err := writer.CreateFormFile("file", filepath.Base(filePath))
+	part, err := writer.CreateFormFile("file", filename)
 	if err != nil {
-		return &errors.IOError{
+		return nil, &errors.IOError{
 			GarthError: errors.GarthError{
 				Message: "Failed to create form file",
 				Cause:   err,
@@ -377,8 +421,8 @@ func (c *Client) Upload(filePath string) error {
 		}
 	}
 
-	if _, err := io.Copy(part, file); err != nil {
-		return &errors.IOError{
+	if _, err := io.Copy(part, r); err != nil {
+		return nil, &errors.IOError{
 			GarthError: errors.GarthError{
 				Message: "Failed to copy file content",
 				Cause:   err,
@@ -387,7 +431,7 @@ func (c *Client) Upload(filePath string) error {
 	}
 
 	if err := writer.Close(); err != nil {
-		return &errors.IOError{
+		return nil, &errors.IOError{
 			GarthError: errors.GarthError{
 				Message: "Failed to close multipart writer",
 				Cause:   err,
@@ -395,9 +439,13 @@ func (c *Client) Upload(filePath string) error {
 		}
 	}
 
-	_, err = c.ConnectAPI("/upload-service/upload", "POST", nil, body)
+	data, _, err := c.doRequest("/upload-service/upload", "POST", nil, body, writer.FormDataContentType(), "application/json")
+	var apiErr *errors.APIError
+	if stderrors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict {
+		data, err = []byte(apiErr.Response), nil
+	}
 	if err != nil {
-		return &errors.APIError{
+		return nil, &errors.APIError{
 			GarthHTTPError: errors.GarthHTTPError{
 				GarthError: errors.GarthError{
 					Message: "File upload failed",
@@ -407,7 +455,14 @@ func (c *Client) Upload(filePath string) error {
 		}
 	}
 
-	return nil
+	var result types.UploadResult
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &result); err != nil {
+			return nil, fmt.Errorf("failed to parse upload response: %w", err)
+		}
+	}
+
+	return &result, nil
 }
 
 // Download retrieves a file from Garmin Connect
@@ -438,16 +493,37 @@ func (c *Client) Download(activityID string, format string, filePath string) err
 
 // GetActivities retrieves recent activities
 func (c *Client) GetActivities(limit int) ([]types.Activity, error) {
+	return c.GetActivitiesPage(0, limit, nil)
+}
+
+// GetActivitiesPage retrieves one page of activities starting at the given
+// offset. Filters are passed through as activitylist-service search
+// parameters (e.g. activityType, startDate, endDate).
+func (c *Client) GetActivitiesPage(start, limit int, filters url.Values) ([]types.Activity, error) {
 	if limit <= 0 {
 		limit = 10
 	}
+	if start < 0 {
+		start = 0
+	}
 
 	scheme := "https"
 	if strings.HasPrefix(c.Domain, "127.0.0.1") {
 		scheme = "http"
 	}
+	host := c.Domain
+	if !strings.HasPrefix(c.Domain, "127.0.0.1") {
+		host = "connectapi." + c.Domain
+	}
 
-	activitiesURL := fmt.Sprintf("%s://connectapi.%s/activitylist-service/activities/search/activities?limit=%d&start=0", scheme, c.Domain, limit)
+	params := url.Values{}
+	for key, values := range filters {
+		params[key] = values
+	}
+	params.Set("start", strconv.Itoa(start))
+	params.Set("limit", strconv.Itoa(limit))
+
+	activitiesURL := fmt.Sprintf("%s://%s/activitylist-service/activities/search/activities?%s", scheme, host, params.Encode())
 
 	req, err := http.NewRequest("GET", activitiesURL, nil)
 	if err != nil {
@@ -503,45 +579,461 @@ func (c *Client) GetActivities(limit int) ([]types.Activity, error) {
 	return activities, nil
 }
 
+// GetActivity retrieves the full details of a single activity
+func (c *Client) GetActivity(activityID int64) (*types.ActivityDetails, error) {
+	path := fmt.Sprintf("/activity-service/activity/%d", activityID)
+
+	data, err := c.ConnectAPI(path, "GET", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get activity %d: %w", activityID, err)
+	}
+
+	var result types.ActivityDetails
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse activity: %w", err)
+	}
+
+	return &result, nil
+}
+
+// GetActivitySplits retrieves the laps of a single activity
+func (c *Client) GetActivitySplits(activityID int64) (*types.ActivitySplits, error) {
+	path := fmt.Sprintf("/activity-service/activity/%d/splits", activityID)
+
+	data, err := c.ConnectAPI(path, "GET", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get activity splits: %w", err)
+	}
+
+	if len(data) == 0 {
+		return &types.ActivitySplits{ActivityID: activityID}, nil
+	}
+
+	var result types.ActivitySplits
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse activity splits: %w", err)
+	}
+
+	return &result, nil
+}
+
+// UpdateActivity applies a partial update to an activity
+func (c *Client) UpdateActivity(update *types.ActivityUpdate) error {
+	body, err := json.Marshal(update)
+	if err != nil {
+		return fmt.Errorf("failed to encode activity update: %w", err)
+	}
+
+	path := fmt.Sprintf("/activity-service/activity/%d", update.ActivityID)
+	if _, err := c.ConnectAPI(path, "PUT", nil, bytes.NewReader(body)); err != nil {
+		return fmt.Errorf("failed to update activity %d: %w", update.ActivityID, err)
+	}
+
+	return nil
+}
+
+// CreateManualActivity creates an activity without a recorded file and
+// returns the created activity
+func (c *Client) CreateManualActivity(activity *types.ManualActivity) (*types.ActivityDetails, error) {
+	body, err := json.Marshal(activity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode manual activity: %w", err)
+	}
+
+	data, err := c.ConnectAPI("/activity-service/activity", "POST", nil, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create activity: %w", err)
+	}
+
+	var result types.ActivityDetails
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse created activity: %w", err)
+	}
+
+	return &result, nil
+}
+
+// DeleteActivity permanently deletes an activity
+func (c *Client) DeleteActivity(activityID int64) error {
+	path := fmt.Sprintf("/activity-service/activity/%d", activityID)
+	if _, err := c.ConnectAPI(path, "DELETE", nil, nil); err != nil {
+		return fmt.Errorf("failed to delete activity %d: %w", activityID, err)
+	}
+	return nil
+}
+
+// LinkGear links a piece of gear to an activity
+func (c *Client) LinkGear(gearUUID string, activityID int64) error {
+	path := fmt.Sprintf("/gear-service/gear/link/%s/activity/%d", url.PathEscape(gearUUID), activityID)
+	if _, err := c.ConnectAPI(path, "PUT", nil, nil); err != nil {
+		return fmt.Errorf("failed to link gear %s to activity %d: %w", gearUUID, activityID, err)
+	}
+	return nil
+}
+
+// UnlinkGear removes a piece of gear from an activity
+func (c *Client) UnlinkGear(gearUUID string, activityID int64) error {
+	path := fmt.Sprintf("/gear-service/gear/unlink/%s/activity/%d", url.PathEscape(gearUUID), activityID)
+	if _, err := c.ConnectAPI(path, "PUT", nil, nil); err != nil {
+		return fmt.Errorf("failed to unlink gear %s from activity %d: %w", gearUUID, activityID, err)
+	}
+	return nil
+}
+
+// GetActivityDetailMetrics retrieves the time-series samples of a single
+// activity. maxChartSize caps the number of rows returned; zero uses the
+// server default.
+func (c *Client) GetActivityDetailMetrics(activityID int64, maxChartSize int) (*types.ActivityDetailMetrics, error) {
+	path := fmt.Sprintf("/activity-service/activity/%d/details", activityID)
+
+	params := url.Values{}
+	if maxChartSize > 0 {
+		params.Set("maxChartSize", strconv.Itoa(maxChartSize))
+	}
+
+	data, err := c.ConnectAPI(path, "GET", params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get activity details: %w", err)
+	}
+
+	var result types.ActivityDetailMetrics
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse activity details: %w", err)
+	}
+
+	return &result, nil
+}
+
+// statsPageSize is the number of days requested at a time from the daily
+// range endpoints, matching the page size of stats.BaseStats
+const statsPageSize = 28
+
+// dailyStat is one day of a /usersummary-service/stats daily range response
+type dailyStat struct {
+	Date         time.Time       `json:"-"`
+	CalendarDate string          `json:"calendarDate"`
+	Values       json.RawMessage `json:"values"`
+}
+
+// statsDateRange truncates a date range to whole days
+func statsDateRange(startDate, endDate time.Time) (time.Time, time.Time, error) {
+	from := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 0, 0, 0, 0, time.UTC)
+	if from.After(end) {
+		return from, end, fmt.Errorf("start date %s is after end date %s", from.Format("2006-01-02"), end.Format("2006-01-02"))
+	}
+	return from, end, nil
+}
+
+// forEachStatsPage calls fn for consecutive pages of at most statsPageSize
+// days covering startDate to endDate inclusive
+func forEachStatsPage(startDate, endDate time.Time, fn func(from, to time.Time) error) error {
+	from, end, err := statsDateRange(startDate, endDate)
+	if err != nil {
+		return err
+	}
+
+	for !from.After(end) {
+		to := from.AddDate(0, 0, statsPageSize-1)
+		if to.After(end) {
+			to = end
+		}
+		if err := fn(from, to); err != nil {
+			return err
+		}
+		from = to.AddDate(0, 0, 1)
+	}
+	return nil
+}
+
+// getDailyStats retrieves a daily statistic such as "steps" or "stress" for
+// a date range, one page at a time, in date order
+func (c *Client) getDailyStats(metric string, startDate, endDate time.Time) ([]dailyStat, error) {
+	var days []dailyStat
+	err := forEachStatsPage(startDate, endDate, func(from, to time.Time) error {
+		path := fmt.Sprintf("/usersummary-service/stats/%s/daily/%s/%s",
+			metric, from.Format("2006-01-02"), to.Format("2006-01-02"))
+		data, err := c.ConnectAPI(path, "GET", nil, nil)
+		if err != nil {
+			return fmt.Errorf("failed to get daily %s stats: %w", metric, err)
+		}
+		if len(data) == 0 {
+			return nil
+		}
+
+		var page []dailyStat
+		if err := json.Unmarshal(data, &page); err != nil {
+			return fmt.Errorf("failed to parse daily %s stats: %w", metric, err)
+		}
+		for _, day := range page {
+			date, err := time.Parse("2006-01-02", day.CalendarDate)
+			if err != nil {
+				return fmt.Errorf("failed to parse daily %s stats: %w", metric, err)
+			}
+			day.Date = date
+			days = append(days, day)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(days, func(i, j int) bool {
+		return days[i].Date.Before(days[j].Date)
+	})
+	return days, nil
+}
+
+// intValue rounds an optional number to an int
+func intValue(v *float64) int {
+	if v == nil {
+		return 0
+	}
+	return int(math.Round(*v))
+}
+
+// GetSleepData retrieves daily sleep summaries for a date range. Days without
+// recorded sleep are omitted.
 func (c *Client) GetSleepData(startDate, endDate time.Time) ([]types.SleepData, error) {
-	// TODO: Implement GetSleepData
-	return nil, fmt.Errorf("GetSleepData not implemented")
+	days, err := c.getDailyStats("sleep", startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []types.SleepData
+	for _, day := range days {
+		var values struct {
+			TotalSleepTime *float64 `json:"totalSleepTime"`
+			DeepSleepTime  *float64 `json:"deepSleepTime"`
+			LightSleepTime *float64 `json:"lightSleepTime"`
+			RemSleepTime   *float64 `json:"remSleepTime"`
+			AwakeTime      *float64 `json:"awakeTime"`
+			SleepScore     *float64 `json:"sleepScore"`
+		}
+		if err := json.Unmarshal(day.Values, &values); err != nil {
+			return nil, fmt.Errorf("failed to parse sleep data for %s: %w", day.CalendarDate, err)
+		}
+		if values.TotalSleepTime == nil {
+			continue
+		}
+		results = append(results, types.SleepData{
+			Date:              day.Date,
+			SleepScore:        intValue(values.SleepScore),
+			TotalSleepSeconds: intValue(values.TotalSleepTime),
+			DeepSleepSeconds:  intValue(values.DeepSleepTime),
+			LightSleepSeconds: intValue(values.LightSleepTime),
+			RemSleepSeconds:   intValue(values.RemSleepTime),
+			AwakeSleepSeconds: intValue(values.AwakeTime),
+		})
+	}
+	return results, nil
 }
 
-// GetHrvData retrieves HRV data for a specified number of days
-func (c *Client) GetHrvData(days int) ([]types.HrvData, error) {
-	// TODO: Implement GetHrvData
-	return nil, fmt.Errorf("GetHrvData not implemented")
+// GetHrvData retrieves the nightly HRV average for a date range. Days without
+// an HRV reading are omitted.
+func (c *Client) GetHrvData(startDate, endDate time.Time) ([]types.HrvData, error) {
+	stats, err := c.getDailyStats("hrv", startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []types.HrvData
+	for _, day := range stats {
+		var values struct {
+			HRV *float64 `json:"hrv"`
+		}
+		if err := json.Unmarshal(day.Values, &values); err != nil {
+			return nil, fmt.Errorf("failed to parse HRV data for %s: %w", day.CalendarDate, err)
+		}
+		if values.HRV == nil {
+			continue
+		}
+		results = append(results, types.HrvData{Date: day.Date, HrvValue: *values.HRV})
+	}
+	return results, nil
 }
 
-// GetStressData retrieves stress data
+// GetStressData retrieves the overall daily stress level for a date range.
+// Days without enough data for a stress level are omitted.
 func (c *Client) GetStressData(startDate, endDate time.Time) ([]types.StressData, error) {
-	// TODO: Implement GetStressData
-	return nil, fmt.Errorf("GetStressData not implemented")
+	days, err := c.getDailyStats("stress", startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []types.StressData
+	for _, day := range days {
+		var values struct {
+			OverallStressLevel *float64 `json:"overallStressLevel"`
+		}
+		if err := json.Unmarshal(day.Values, &values); err != nil {
+			return nil, fmt.Errorf("failed to parse stress data for %s: %w", day.CalendarDate, err)
+		}
+		// Garmin reports negative levels for days with too little data
+		if values.OverallStressLevel == nil || *values.OverallStressLevel < 0 {
+			continue
+		}
+		results = append(results, types.StressData{
+			Date:        day.Date,
+			StressLevel: intValue(values.OverallStressLevel),
+		})
+	}
+	return results, nil
 }
 
-// GetBodyBatteryData retrieves Body Battery data
+// GetBodyBatteryData retrieves the daily Body Battery charge and drain for a
+// date range. BatteryLevel is the last reading of each day.
 func (c *Client) GetBodyBatteryData(startDate, endDate time.Time) ([]types.BodyBatteryData, error) {
-	// TODO: Implement GetBodyBatteryData
-	return nil, fmt.Errorf("GetBodyBatteryData not implemented")
+	var results []types.BodyBatteryData
+	err := forEachStatsPage(startDate, endDate, func(from, to time.Time) error {
+		params := url.Values{}
+		params.Set("startDate", from.Format("2006-01-02"))
+		params.Set("endDate", to.Format("2006-01-02"))
+
+		data, err := c.ConnectAPI("/wellness-service/wellness/bodyBattery/reports/daily", "GET", params, nil)
+		if err != nil {
+			return fmt.Errorf("failed to get Body Battery data: %w", err)
+		}
+		if len(data) == 0 {
+			return nil
+		}
+
+		var reports []struct {
+			Date                   string      `json:"date"`
+			Charged                *float64    `json:"charged"`
+			Drained                *float64    `json:"drained"`
+			BodyBatteryValuesArray [][]float64 `json:"bodyBatteryValuesArray"`
+		}
+		if err := json.Unmarshal(data, &reports); err != nil {
+			return fmt.Errorf("failed to parse Body Battery data: %w", err)
+		}
+		for _, report := range reports {
+			date, err := time.Parse("2006-01-02", report.Date)
+			if err != nil {
+				return fmt.Errorf("failed to parse Body Battery data: %w", err)
+			}
+			day := types.BodyBatteryData{
+				Date:   date,
+				Charge: intValue(report.Charged),
+				Drain:  intValue(report.Drained),
+			}
+			// Readings are [timestamp, level] pairs
+			var latest float64
+			for _, reading := range report.BodyBatteryValuesArray {
+				if len(reading) >= 2 && reading[0] >= latest {
+					latest = reading[0]
+					day.BatteryLevel = int(reading[1])
+				}
+			}
+			results = append(results, day)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Date.Before(results[j].Date)
+	})
+	return results, nil
+}
+
+// stepsValues are the values of a day of the daily steps stats
+type stepsValues struct {
+	TotalSteps    *float64 `json:"totalSteps"`
+	TotalDistance *float64 `json:"totalDistance"` // meters
 }
 
-// GetStepsData retrieves steps data for a specified date range
+// getDailySteps retrieves the daily steps stats for a date range
+func (c *Client) getDailySteps(startDate, endDate time.Time) ([]dailyStat, []stepsValues, error) {
+	days, err := c.getDailyStats("steps", startDate, endDate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values := make([]stepsValues, len(days))
+	for i, day := range days {
+		if err := json.Unmarshal(day.Values, &values[i]); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse steps data for %s: %w", day.CalendarDate, err)
+		}
+	}
+	return days, values, nil
+}
+
+// GetStepsData retrieves steps data for a specified date range. Days without
+// step data are omitted.
 func (c *Client) GetStepsData(startDate, endDate time.Time) ([]types.StepsData, error) {
-	// TODO: Implement GetStepsData
-	return nil, fmt.Errorf("GetStepsData not implemented")
+	days, values, err := c.getDailySteps(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []types.StepsData
+	for i, day := range days {
+		if values[i].TotalSteps == nil {
+			continue
+		}
+		results = append(results, types.StepsData{Date: day.Date, Steps: intValue(values[i].TotalSteps)})
+	}
+	return results, nil
 }
 
-// GetDistanceData retrieves distance data for a specified date range
+// GetDistanceData retrieves the daily distance covered, in meters, for a
+// specified date range. Days without step data are omitted.
 func (c *Client) GetDistanceData(startDate, endDate time.Time) ([]types.DistanceData, error) {
-	// TODO: Implement GetDistanceData
-	return nil, fmt.Errorf("GetDistanceData not implemented")
+	days, values, err := c.getDailySteps(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []types.DistanceData
+	for i, day := range days {
+		if values[i].TotalDistance == nil {
+			continue
+		}
+		results = append(results, types.DistanceData{Date: day.Date, Distance: *values[i].TotalDistance})
+	}
+	return results, nil
 }
 
-// GetCaloriesData retrieves calories data for a specified date range
+// GetCaloriesData retrieves the active calories burned each day of a
+// specified date range. There is no range endpoint for calories, so the
+// daily summary is fetched for each day; days without a summary are omitted.
 func (c *Client) GetCaloriesData(startDate, endDate time.Time) ([]types.CaloriesData, error) {
-	// TODO: Implement GetCaloriesData
-	return nil, fmt.Errorf("GetCaloriesData not implemented")
+	from, end, err := statsDateRange(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/usersummary-service/usersummary/daily/%s", url.PathEscape(c.Username))
+	var results []types.CaloriesData
+	for day := from; !day.After(end); day = day.AddDate(0, 0, 1) {
+		dateStr := day.Format("2006-01-02")
+		params := url.Values{}
+		params.Set("calendarDate", dateStr)
+
+		data, err := c.ConnectAPI(path, "GET", params, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get daily summary for %s: %w", dateStr, err)
+		}
+		if len(data) == 0 {
+			continue
+		}
+
+		var summary struct {
+			ActiveKilocalories *float64 `json:"activeKilocalories"`
+		}
+		if err := json.Unmarshal(data, &summary); err != nil {
+			return nil, fmt.Errorf("failed to parse daily summary for %s: %w", dateStr, err)
+		}
+		if summary.ActiveKilocalories == nil {
+			continue
+		}
+		results = append(results, types.CaloriesData{Date: day, Calories: intValue(summary.ActiveKilocalories)})
+	}
+	return results, nil
 }
 
 // GetVO2MaxData retrieves VO2 max data using the modern approach via user settings
@@ -3,6 +3,7 @@ package client_test
 import (
 	"crypto/tls"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
 	"time"
@@ -46,4 +47,181 @@ func TestClient_GetUserProfile(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "testuser", profile.UserName)
 	assert.Equal(t, "Test User", profile.DisplayName)
-}
\ No newline at end of file
+}
+
+func TestClient_GetActivitiesPage(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/activitylist-service/activities/search/activities", r.URL.Path)
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"activityId": 42, "activityName": "Morning Run"}]`))
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	c, err := client.NewClient(u.Host)
+	require.NoError(t, err)
+	c.Domain = u.Host
+	c.AuthToken = "Bearer testtoken"
+
+	filters := url.Values{}
+	filters.Set("activityType", "running")
+	filters.Set("startDate", "2024-01-01")
+
+	activities, err := c.GetActivitiesPage(40, 20, filters)
+	require.NoError(t, err)
+	require.Len(t, activities, 1)
+	assert.Equal(t, int64(42), activities[0].ActivityID)
+
+	assert.Equal(t, "40", query.Get("start"))
+	assert.Equal(t, "20", query.Get("limit"))
+	assert.Equal(t, "running", query.Get("activityType"))
+	assert.Equal(t, "2024-01-01", query.Get("startDate"))
+}
+
+func newTestClient(t *testing.T, server *httptest.Server) *client.Client {
+	u, _ := url.Parse(server.URL)
+	c, err := client.NewClient(u.Host)
+	require.NoError(t, err)
+	c.Domain = u.Host
+	c.Username = "testuser"
+	c.AuthToken = "Bearer testtoken"
+	return c
+}
+
+func TestClient_GetStepsData_Pages(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/usersummary-service/stats/steps/daily/2024-01-01/2024-01-28":
+			w.Write([]byte(`[
+				{"calendarDate": "2024-01-02", "values": {"totalSteps": 8000, "totalDistance": 6400.5, "stepGoal": 7500}},
+				{"calendarDate": "2024-01-01", "values": {"totalSteps": 12000, "totalDistance": 9600, "stepGoal": 7500}}
+			]`))
+		case "/usersummary-service/stats/steps/daily/2024-01-29/2024-02-09":
+			w.Write([]byte(`[
+				{"calendarDate": "2024-02-09", "values": {"totalSteps": null, "totalDistance": null, "stepGoal": 7500}},
+				{"calendarDate": "2024-01-29", "values": {"totalSteps": 500, "totalDistance": 400, "stepGoal": 7500}}
+			]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	c := newTestClient(t, server)
+
+	start := time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 2, 9, 7, 0, 0, 0, time.UTC)
+
+	steps, err := c.GetStepsData(start, end)
+	require.NoError(t, err)
+	assert.Len(t, paths, 2)
+	require.Len(t, steps, 3)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), steps[0].Date)
+	assert.Equal(t, 12000, steps[0].Steps)
+	assert.Equal(t, 8000, steps[1].Steps)
+	assert.Equal(t, 500, steps[2].Steps)
+
+	distance, err := c.GetDistanceData(start, end)
+	require.NoError(t, err)
+	require.Len(t, distance, 3)
+	assert.Equal(t, 6400.5, distance[1].Distance)
+
+	_, err = c.GetStepsData(end, start)
+	assert.ErrorContains(t, err, "is after end date")
+}
+
+func TestClient_GetStressSleepAndHrvData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/usersummary-service/stats/stress/daily/2024-03-01/2024-03-03":
+			w.Write([]byte(`[
+				{"calendarDate": "2024-03-01", "values": {"overallStressLevel": 32, "restStressDuration": 28000}},
+				{"calendarDate": "2024-03-02", "values": {"overallStressLevel": -1}},
+				{"calendarDate": "2024-03-03", "values": {"overallStressLevel": 41}}
+			]`))
+		case "/usersummary-service/stats/sleep/daily/2024-03-01/2024-03-03":
+			w.Write([]byte(`[
+				{"calendarDate": "2024-03-01", "values": {"totalSleepTime": 27000, "deepSleepTime": 5400,
+					"lightSleepTime": 14400, "remSleepTime": 6000, "awakeTime": 1200, "sleepScore": 82}},
+				{"calendarDate": "2024-03-02", "values": {}}
+			]`))
+		case "/usersummary-service/stats/hrv/daily/2024-03-01/2024-03-03":
+			w.Write([]byte(`[
+				{"calendarDate": "2024-03-02", "values": {"hrv": null}},
+				{"calendarDate": "2024-03-01", "values": {"hrv": 48}}
+			]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	c := newTestClient(t, server)
+
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)
+
+	stress, err := c.GetStressData(start, end)
+	require.NoError(t, err)
+	require.Len(t, stress, 2)
+	assert.Equal(t, 32, stress[0].StressLevel)
+	assert.Equal(t, 41, stress[1].StressLevel)
+
+	sleep, err := c.GetSleepData(start, end)
+	require.NoError(t, err)
+	require.Len(t, sleep, 1)
+	assert.Equal(t, 82, sleep[0].SleepScore)
+	assert.Equal(t, 27000, sleep[0].TotalSleepSeconds)
+	assert.Equal(t, 6000, sleep[0].RemSleepSeconds)
+
+	hrv, err := c.GetHrvData(start, end)
+	require.NoError(t, err)
+	require.Len(t, hrv, 1)
+	assert.Equal(t, start, hrv[0].Date)
+	assert.Equal(t, 48.0, hrv[0].HrvValue)
+}
+
+func TestClient_GetBodyBatteryAndCaloriesData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/wellness-service/wellness/bodyBattery/reports/daily":
+			assert.Equal(t, "2024-03-01", r.URL.Query().Get("startDate"))
+			assert.Equal(t, "2024-03-02", r.URL.Query().Get("endDate"))
+			w.Write([]byte(`[
+				{"date": "2024-03-01", "charged": 60, "drained": 55,
+				 "bodyBatteryValuesArray": [[1709251200000, 30], [1709330400000, 35], [1709294400000, 90]]}
+			]`))
+		case "/usersummary-service/usersummary/daily/testuser":
+			switch r.URL.Query().Get("calendarDate") {
+			case "2024-03-01":
+				w.Write([]byte(`{"activeKilocalories": 612.6}`))
+			default:
+				w.Write([]byte(`{"activeKilocalories": null}`))
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	c := newTestClient(t, server)
+
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	battery, err := c.GetBodyBatteryData(start, end)
+	require.NoError(t, err)
+	require.Len(t, battery, 1)
+	assert.Equal(t, 60, battery[0].Charge)
+	assert.Equal(t, 55, battery[0].Drain)
+	assert.Equal(t, 35, battery[0].BatteryLevel)
+
+	calories, err := c.GetCaloriesData(start, end)
+	require.NoError(t, err)
+	require.Len(t, calories, 1)
+	assert.Equal(t, 613, calories[0].Calories)
+}
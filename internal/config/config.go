@@ -26,6 +26,30 @@ type Config struct {
 		TTL     time.Duration `yaml:"ttl"`
 		Dir     string        `yaml:"dir"`
 	} `yaml:"cache"`
+
+	// Zones are the user's power and pace training zones. Heart rate zones
+	// come from Garmin Connect.
+	Zones struct {
+		Power []ZoneConfig `yaml:"power,omitempty"`
+		Pace  []ZoneConfig `yaml:"pace,omitempty"`
+	} `yaml:"zones,omitempty"`
+
+	// Gear sets the distance after which gear is flagged for replacement,
+	// such as "800km". Limits sets it per gear name or UUID; AlertDistance
+	// only applies to gear without a maximum distance on Garmin Connect.
+	Gear struct {
+		AlertDistance string            `yaml:"alert_distance,omitempty" mapstructure:"alert_distance"`
+		Limits        map[string]string `yaml:"limits,omitempty"`
+	} `yaml:"gear,omitempty"`
+}
+
+// ZoneConfig defines a training zone by its bounds. Power bounds are watts;
+// pace bounds are paces such as "5:15/km", with Min the slower pace. An empty
+// bound leaves the zone open on that side.
+type ZoneConfig struct {
+	Name string `yaml:"name"`
+	Min  string `yaml:"min"`
+	Max  string `yaml:"max"`
 }
 
 // DefaultConfig returns a new Config with default values.
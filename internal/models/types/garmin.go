@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -125,10 +126,214 @@ type Activity struct {
 	Calories        float64      `json:"calories"`
 	AverageHR       float64      `json:"averageHR"`
 	MaxHR           float64      `json:"maxHR"`
+
+	// Multisport activities such as triathlons are a parent activity with a
+	// child activity per leg, transitions included
+	IsMultiSportParent bool    `json:"isMultiSportParent,omitempty"`
+	ChildIDs           []int64 `json:"childIds,omitempty"`
+	ParentID           *int64  `json:"parentId,omitempty"`
+}
+
+// ActivityDetails represents the full activity record returned by the
+// activity service
+type ActivityDetails struct {
+	ActivityID      int64            `json:"activityId"`
+	ActivityName    string           `json:"activityName"`
+	Description     string           `json:"description"`
+	LocationName    string           `json:"locationName"`
+	ActivityTypeDTO ActivityType     `json:"activityTypeDTO"`
+	EventTypeDTO    EventType        `json:"eventTypeDTO"`
+	SummaryDTO      ActivitySummary  `json:"summaryDTO"`
+	MetadataDTO     ActivityMetadata `json:"metadataDTO"`
+
+	AccessControlRuleDTO AccessControlRule `json:"accessControlRuleDTO"`
+}
+
+// AccessControlRule represents the privacy setting of an activity
+type AccessControlRule struct {
+	TypeID  int    `json:"typeId"`
+	TypeKey string `json:"typeKey"`
+}
+
+// ActivityUpdate is the body of a partial activity update. Nil fields are
+// omitted and left unchanged.
+type ActivityUpdate struct {
+	ActivityID           int64              `json:"activityId"`
+	ActivityName         *string            `json:"activityName,omitempty"`
+	Description          *string            `json:"description,omitempty"`
+	ActivityTypeDTO      *ActivityType      `json:"activityTypeDTO,omitempty"`
+	EventTypeDTO         *EventType         `json:"eventTypeDTO,omitempty"`
+	AccessControlRuleDTO *AccessControlRule `json:"accessControlRuleDTO,omitempty"`
+}
+
+// ManualActivity is the body of a request creating an activity without a
+// recorded file
+type ManualActivity struct {
+	ActivityName         string                 `json:"activityName"`
+	Description          string                 `json:"description,omitempty"`
+	ActivityTypeDTO      ActivityType           `json:"activityTypeDTO"`
+	AccessControlRuleDTO *AccessControlRule     `json:"accessControlRuleDTO,omitempty"`
+	TimeZoneUnitDTO      TimeZoneUnit           `json:"timeZoneUnitDTO"`
+	MetadataDTO          ManualActivityMetadata `json:"metadataDTO"`
+	SummaryDTO           ManualActivitySummary  `json:"summaryDTO"`
+}
+
+// TimeZoneUnit identifies a time zone by its IANA name
+type TimeZoneUnit struct {
+	UnitKey string `json:"unitKey"`
+}
+
+// ManualActivityMetadata holds the metadata of a manual activity
+type ManualActivityMetadata struct {
+	AutoCalcCalories bool `json:"autoCalcCalories"`
+}
+
+// ManualActivitySummary holds the summary of a manual activity. The start
+// time is local to the time zone and formatted as 2006-01-02T15:04:05.000.
+type ManualActivitySummary struct {
+	StartTimeLocal string  `json:"startTimeLocal"`
+	Distance       float64 `json:"distance,omitempty"`
+	Duration       float64 `json:"duration"`
+	Calories       float64 `json:"calories,omitempty"`
+}
+
+// UploadResult is the response of the upload service
+type UploadResult struct {
+	DetailedImportResult DetailedImportResult `json:"detailedImportResult"`
+}
+
+// DetailedImportResult lists the activities created from an uploaded file
+// and the reasons any were rejected
+type DetailedImportResult struct {
+	UploadID  int64          `json:"uploadId"`
+	FileName  string         `json:"fileName"`
+	Successes []ImportResult `json:"successes"`
+	Failures  []ImportResult `json:"failures"`
+}
+
+// ImportResult is the outcome of importing one activity. InternalID is the
+// activity ID; for duplicates it is the ID of the existing activity.
+type ImportResult struct {
+	InternalID int64           `json:"internalId"`
+	Messages   []ImportMessage `json:"messages"`
+}
+
+// ImportMessage explains an import result. Code 202 marks a duplicate.
+type ImportMessage struct {
+	Code    int    `json:"code"`
+	Content string `json:"content"`
+}
+
+// ActivitySummary holds the summary metrics of an activity
+type ActivitySummary struct {
+	StartTimeLocal               GarminTime `json:"startTimeLocal"`
+	StartTimeGMT                 GarminTime `json:"startTimeGMT"`
+	Distance                     float64    `json:"distance"`
+	Duration                     float64    `json:"duration"`
+	MovingDuration               float64    `json:"movingDuration"`
+	ElapsedDuration              float64    `json:"elapsedDuration"`
+	ElevationGain                float64    `json:"elevationGain"`
+	ElevationLoss                float64    `json:"elevationLoss"`
+	AverageSpeed                 float64    `json:"averageSpeed"`
+	MaxSpeed                     float64    `json:"maxSpeed"`
+	Calories                     float64    `json:"calories"`
+	AverageHR                    float64    `json:"averageHR"`
+	MaxHR                        float64    `json:"maxHR"`
+	MinHR                        float64    `json:"minHR"`
+	AveragePower                 float64    `json:"averagePower"`
+	MaxPower                     float64    `json:"maxPower"`
+	MinPower                     float64    `json:"minPower"`
+	NormalizedPower              float64    `json:"normalizedPower"`
+	AverageRunCadence            float64    `json:"averageRunCadence"`
+	MaxRunCadence                float64    `json:"maxRunCadence"`
+	AverageBikeCadence           float64    `json:"averageBikeCadence"`
+	MaxBikeCadence               float64    `json:"maxBikeCadence"`
+	TrainingEffect               float64    `json:"trainingEffect"`
+	AnaerobicTrainingEffect      float64    `json:"anaerobicTrainingEffect"`
+	AerobicTrainingEffectMessage string     `json:"aerobicTrainingEffectMessage"`
+	TrainingEffectLabel          string     `json:"trainingEffectLabel"`
+	ActivityTrainingLoad         float64    `json:"activityTrainingLoad"`
+}
+
+// ActivityMetadata holds recording metadata of an activity
+type ActivityMetadata struct {
+	LapCount           int            `json:"lapCount"`
+	HasSplits          bool           `json:"hasSplits"`
+	Manufacturer       string         `json:"manufacturer"`
+	DeviceMetaDataDTO  DeviceMetaData `json:"deviceMetaDataDTO"`
+	IsMultiSportParent bool           `json:"isMultiSportParent"`
+	ChildIDs           []int64        `json:"childIds"`
+}
+
+// DeviceMetaData identifies the device that recorded an activity
+type DeviceMetaData struct {
+	DeviceID        json.Number `json:"deviceId"`
+	DeviceTypePK    int64       `json:"deviceTypePk"`
+	DeviceVersionPK int64       `json:"deviceVersionPk"`
+}
+
+// ActivitySplits represents the laps of an activity
+type ActivitySplits struct {
+	ActivityID int64         `json:"activityId"`
+	LapDTOs    []ActivityLap `json:"lapDTOs"`
+}
+
+// ActivityLap represents a single lap of an activity
+type ActivityLap struct {
+	LapIndex           int        `json:"lapIndex"`
+	StartTimeGMT       GarminTime `json:"startTimeGMT"`
+	Distance           float64    `json:"distance"`
+	Duration           float64    `json:"duration"`
+	MovingDuration     float64    `json:"movingDuration"`
+	ElapsedDuration    float64    `json:"elapsedDuration"`
+	ElevationGain      float64    `json:"elevationGain"`
+	ElevationLoss      float64    `json:"elevationLoss"`
+	AverageSpeed       float64    `json:"averageSpeed"`
+	MaxSpeed           float64    `json:"maxSpeed"`
+	Calories           float64    `json:"calories"`
+	AverageHR          float64    `json:"averageHR"`
+	MaxHR              float64    `json:"maxHR"`
+	AveragePower       float64    `json:"averagePower"`
+	MaxPower           float64    `json:"maxPower"`
+	AverageRunCadence  float64    `json:"averageRunCadence"`
+	MaxRunCadence      float64    `json:"maxRunCadence"`
+	AverageBikeCadence float64    `json:"averageBikeCadence"`
+	MaxBikeCadence     float64    `json:"maxBikeCadence"`
+}
+
+// ActivityDetailMetrics represents the time-series samples of an activity as
+// returned by the activity details endpoint. Each row holds one value per
+// metric descriptor, at the descriptor's MetricsIndex; missing values are null.
+type ActivityDetailMetrics struct {
+	ActivityID            int64              `json:"activityId"`
+	MeasurementCount      int                `json:"measurementCount"`
+	MetricsCount          int                `json:"metricsCount"`
+	MetricDescriptors     []MetricDescriptor `json:"metricDescriptors"`
+	ActivityDetailMetrics []MetricRow        `json:"activityDetailMetrics"`
+}
+
+// MetricDescriptor describes one column of the activity detail metrics
+type MetricDescriptor struct {
+	MetricsIndex int        `json:"metricsIndex"`
+	Key          string     `json:"key"`
+	Unit         MetricUnit `json:"unit"`
+}
+
+// MetricUnit describes the unit of a metric column
+type MetricUnit struct {
+	ID     int     `json:"id"`
+	Key    string  `json:"key"`
+	Factor float64 `json:"factor"`
+}
+
+// MetricRow holds the values of a single sample
+type MetricRow struct {
+	Metrics []*float64 `json:"metrics"`
 }
 
 // UserProfile represents a Garmin user profile
 type UserProfile struct {
+	ProfileID       int64      `json:"profileId"`
 	UserName        string     `json:"userName"`
 	DisplayName     string     `json:"displayName"`
 	LevelUpdateDate GarminTime `json:"levelUpdateDate"`
@@ -421,3 +626,237 @@ type CaloriesData struct {
 	Date     time.Time `json:"calendarDate"`
 	Calories int       `json:"activeCalories"`
 }
+
+// Device represents a device registered to the user's account
+type Device struct {
+	DeviceID               int64  `json:"deviceId"`
+	DeviceTypePK           int    `json:"deviceTypePk"`
+	DeviceVersionPK        int    `json:"deviceVersionPk"`
+	DisplayName            string `json:"displayName"`
+	ProductDisplayName     string `json:"productDisplayName"`
+	PartNumber             string `json:"partNumber"`
+	SoftwareVersionString  string `json:"softwareVersionString"`
+	UnitID                 int64  `json:"unitId"`
+	PrimaryDevice          bool   `json:"primaryDevice"`
+	ImageURL               string `json:"imageUrl"`
+	DeviceRegistrationDate *int64 `json:"registeredDate"`
+	LastSyncTimestamp      *int64 `json:"lastSyncTime"`
+}
+
+// Goals represents the user's fitness goals
+type Goals struct {
+	WeeklyStepGoal          *int     `json:"weeklyStepGoal"`
+	WeeklyIntensityMinutes  *int     `json:"weeklyIntensityMinutes"`
+	WeeklyFloorsClimbedGoal *int     `json:"weeklyFloorsClimbedGoal"`
+	WeeklyWorkoutGoal       *int     `json:"weeklyWorkoutGoal"`
+	DailyHydrationGoal      *float64 `json:"dailyHydrationGoal"`
+}
+
+// Badge represents a badge earned by the user
+type Badge struct {
+	BadgeID        int     `json:"badgeId"`
+	BadgeKey       string  `json:"badgeKey"`
+	BadgeName      string  `json:"badgeName"`
+	BadgeCategory  int     `json:"badgeCategoryId"`
+	BadgePoints    int     `json:"badgePoints"`
+	EarnedDate     string  `json:"badgeEarnedDate"`
+	EarnedNumber   int     `json:"badgeEarnedNumber"`
+	AssociatedGoal *string `json:"associatedGoal"`
+}
+
+// Gear represents a piece of gear such as shoes or a bike
+type Gear struct {
+	GearPK          int64   `json:"gearPk"`
+	UUID            string  `json:"uuid"`
+	UserProfilePK   int64   `json:"userProfilePk"`
+	GearTypeName    string  `json:"gearTypeName"`
+	GearStatusName  string  `json:"gearStatusName"`
+	GearMakeName    string  `json:"gearMakeName"`
+	GearModelName   string  `json:"gearModelName"`
+	CustomMakeModel string  `json:"customMakeModel"`
+	DisplayName     string  `json:"displayName"`
+	DateBegin       *string `json:"dateBegin"`
+	DateEnd         *string `json:"dateEnd"`
+	MaximumMeters   float64 `json:"maximumMeters"`
+	Notified        bool    `json:"notified"`
+}
+
+// GearStats represents the usage totals of a piece of gear
+type GearStats struct {
+	UUID            string  `json:"uuid"`
+	TotalActivities int     `json:"totalActivities"`
+	TotalDistance   float64 `json:"totalDistance"` // meters
+	Processing      bool    `json:"processing"`
+}
+
+// Course represents a course from the course service. The same structure is
+// sent to create a course.
+type Course struct {
+	CourseID           int64            `json:"courseId,omitempty"`
+	CourseName         string           `json:"courseName"`
+	Description        string           `json:"description,omitempty"`
+	ActivityTypePK     int              `json:"activityTypePk"`
+	DistanceMeter      float64          `json:"distanceMeter"`
+	ElevationGainMeter float64          `json:"elevationGainMeter"`
+	ElevationLossMeter float64          `json:"elevationLossMeter"`
+	StartLatitude      float64          `json:"startLatitude"`
+	StartLongitude     float64          `json:"startLongitude"`
+	RulePK             int              `json:"rulePK,omitempty"`
+	SourceTypeID       int              `json:"sourceTypeId,omitempty"`
+	GeoPoints          []CourseGeoPoint `json:"geoPoints,omitempty"`
+}
+
+// CourseGeoPoint represents a track point of a course
+type CourseGeoPoint struct {
+	Latitude  float64  `json:"latitude"`
+	Longitude float64  `json:"longitude"`
+	Elevation *float64 `json:"elevation"`
+	Distance  float64  `json:"distance"` // meters from the start
+}
+
+// Workout represents a structured workout from the workout service. The same
+// structure is sent to create a workout.
+type Workout struct {
+	WorkoutID               int64            `json:"workoutId,omitempty"`
+	WorkoutName             string           `json:"workoutName"`
+	Description             string           `json:"description,omitempty"`
+	SportType               WorkoutSportType `json:"sportType"`
+	EstimatedDurationInSecs *int             `json:"estimatedDurationInSecs,omitempty"`
+	CreatedDate             *string          `json:"createdDate,omitempty"`
+	UpdatedDate             *string          `json:"updatedDate,omitempty"`
+	WorkoutSegments         []WorkoutSegment `json:"workoutSegments,omitempty"`
+}
+
+// WorkoutSportType represents the sport of a workout
+type WorkoutSportType struct {
+	SportTypeID  int    `json:"sportTypeId"`
+	SportTypeKey string `json:"sportTypeKey"`
+}
+
+// WorkoutSegment represents a segment of a workout. Single-sport workouts
+// have one segment.
+type WorkoutSegment struct {
+	SegmentOrder int              `json:"segmentOrder"`
+	SportType    WorkoutSportType `json:"sportType"`
+	WorkoutSteps []WorkoutStep    `json:"workoutSteps"`
+}
+
+// WorkoutStep represents a workout step. Type is "ExecutableStepDTO" for a
+// single step and "RepeatGroupDTO" for a group of steps repeated
+// NumberOfIterations times.
+type WorkoutStep struct {
+	Type               string               `json:"type"`
+	StepOrder          int                  `json:"stepOrder"`
+	ChildStepID        *int                 `json:"childStepId,omitempty"`
+	StepType           WorkoutStepType      `json:"stepType"`
+	Description        string               `json:"description,omitempty"`
+	EndCondition       *WorkoutEndCondition `json:"endCondition,omitempty"`
+	EndConditionValue  *float64             `json:"endConditionValue,omitempty"`
+	TargetType         *WorkoutTargetType   `json:"targetType,omitempty"`
+	TargetValueOne     *float64             `json:"targetValueOne,omitempty"`
+	TargetValueTwo     *float64             `json:"targetValueTwo,omitempty"`
+	ZoneNumber         *int                 `json:"zoneNumber,omitempty"`
+	NumberOfIterations *int                 `json:"numberOfIterations,omitempty"`
+	SmartRepeat        bool                 `json:"smartRepeat,omitempty"`
+	WorkoutSteps       []WorkoutStep        `json:"workoutSteps,omitempty"`
+}
+
+// WorkoutStepType represents the kind of a workout step, e.g. warmup
+type WorkoutStepType struct {
+	StepTypeID  int    `json:"stepTypeId"`
+	StepTypeKey string `json:"stepTypeKey"`
+}
+
+// WorkoutEndCondition represents what ends a workout step, e.g. time
+type WorkoutEndCondition struct {
+	ConditionTypeID  int    `json:"conditionTypeId"`
+	ConditionTypeKey string `json:"conditionTypeKey"`
+}
+
+// WorkoutTargetType represents the target of a workout step, e.g. a heart
+// rate range
+type WorkoutTargetType struct {
+	WorkoutTargetTypeID  int    `json:"workoutTargetTypeId"`
+	WorkoutTargetTypeKey string `json:"workoutTargetTypeKey"`
+}
+
+// ScheduledWorkout represents a workout scheduled on the calendar
+type ScheduledWorkout struct {
+	WorkoutScheduleID int64    `json:"workoutScheduleId"`
+	CalendarDate      string   `json:"calendarDate"`
+	Workout           *Workout `json:"workout,omitempty"`
+}
+
+// CalendarMonth represents the items on the calendar for a month or a week
+type CalendarMonth struct {
+	StartDayOfMonth  int            `json:"startDayOfMonth"`
+	NumOfDaysInMonth int            `json:"numOfDaysInMonth"`
+	CalendarItems    []CalendarItem `json:"calendarItems"`
+}
+
+// CalendarItem represents an activity, scheduled workout, event or note on
+// the calendar
+type CalendarItem struct {
+	ID                  int64    `json:"id"`
+	ItemType            string   `json:"itemType"`
+	ActivityTypeID      *int     `json:"activityTypeId"`
+	Title               string   `json:"title"`
+	Date                string   `json:"date"`     // YYYY-MM-DD
+	Duration            *float64 `json:"duration"` // milliseconds
+	Distance            *float64 `json:"distance"` // meters
+	Calories            *float64 `json:"calories"`
+	StartTimestampLocal *string  `json:"startTimestampLocal"`
+	WorkoutID           *int64   `json:"workoutId"`
+	IsRace              bool     `json:"isRace"`
+	Location            *string  `json:"location"`
+}
+
+// ActivityWeather represents the weather report of an activity. The weather
+// service reports temperatures in degrees Fahrenheit and wind speeds in miles
+// per hour.
+type ActivityWeather struct {
+	IssueDate                 *string         `json:"issueDate"`
+	Temp                      *float64        `json:"temp"`
+	ApparentTemp              *float64        `json:"apparentTemp"`
+	DewPoint                  *float64        `json:"dewPoint"`
+	RelativeHumidity          *float64        `json:"relativeHumidity"`
+	WindDirection             *float64        `json:"windDirection"`
+	WindDirectionCompassPoint string          `json:"windDirectionCompassPoint"`
+	WindSpeed                 *float64        `json:"windSpeed"`
+	WindGust                  *float64        `json:"windGust"`
+	WeatherStationDTO         *WeatherStation `json:"weatherStationDTO"`
+	WeatherTypeDTO            *WeatherType    `json:"weatherTypeDTO"`
+}
+
+// WeatherStation identifies the station a weather report comes from
+type WeatherStation struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// WeatherType describes the weather conditions, e.g. "Partly Cloudy"
+type WeatherType struct {
+	WeatherTypePK int    `json:"weatherTypePk"`
+	Desc          string `json:"desc"`
+}
+
+// PersonalRecord represents a personal record from the personal record service
+type PersonalRecord struct {
+	ID                       int64   `json:"id"`
+	TypeID                   int     `json:"typeId"`
+	ActivityID               int64   `json:"activityId"`
+	ActivityName             string  `json:"activityName"`
+	ActivityType             string  `json:"activityType"`
+	ActivityStartDateTimeGMT *int64  `json:"activityStartDateTimeInGMT"`
+	Value                    float64 `json:"value"`
+	PRStartTimeGMT           *int64  `json:"prStartTimeGmt"`
+	PRTypeLabelKey           *string `json:"prTypeLabelKey"`
+}
+
+// DailyHydration represents hydration intake for a single day
+type DailyHydration struct {
+	CalendarDate string  `json:"calendarDate"`
+	ValueInML    float64 `json:"valueInML"`
+	GoalInML     float64 `json:"goalInML"`
+	SweatLossML  float64 `json:"sweatLossInML"`
+}
@@ -0,0 +1,123 @@
+// Package export generates GPX, TCX and GeoJSON files locally from activity
+// details and time-series samples, without relying on Garmin's export
+// service. This also covers manual and indoor activities, which Garmin does
+// not export.
+package export
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+// Writer writes an activity in a specific file format
+type Writer func(w io.Writer, detail *garmin.ActivityDetail, samples *garmin.ActivitySamples) error
+
+// writers maps format names to writers
+var writers = map[string]Writer{
+	"gpx":     WriteGPX,
+	"tcx":     WriteTCX,
+	"geojson": WriteGeoJSON,
+}
+
+// Formats returns the supported format names
+func Formats() []string {
+	formats := make([]string, 0, len(writers))
+	for name := range writers {
+		formats = append(formats, name)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// Write writes an activity in the named format
+func Write(w io.Writer, format string, detail *garmin.ActivityDetail, samples *garmin.ActivitySamples) error {
+	writer, ok := writers[strings.ToLower(format)]
+	if !ok {
+		return fmt.Errorf("unsupported export format %q (supported: %s)", format, strings.Join(Formats(), ", "))
+	}
+	return writer(w, detail, samples)
+}
+
+// point is a single sample with NaN for values that were not recorded
+type point struct {
+	time        time.Time
+	lat, lon    float64
+	elevation   float64
+	distance    float64
+	heartRate   float64
+	speed       float64
+	power       float64
+	cadence     float64
+	temperature float64
+}
+
+func (p point) hasPosition() bool {
+	return !math.IsNaN(p.lat) && !math.IsNaN(p.lon)
+}
+
+// samplePoints returns the samples that have a timestamp, in row order
+func samplePoints(samples *garmin.ActivitySamples) []point {
+	if samples == nil {
+		return nil
+	}
+	at := func(s garmin.Series, i int) float64 {
+		if i >= len(s) {
+			return math.NaN()
+		}
+		return s[i]
+	}
+
+	points := make([]point, 0, samples.Len())
+	for i, t := range samples.Timestamps {
+		if t.IsZero() {
+			continue
+		}
+		points = append(points, point{
+			time:        t.UTC(),
+			lat:         at(samples.Latitude, i),
+			lon:         at(samples.Longitude, i),
+			elevation:   at(samples.Elevation, i),
+			distance:    at(samples.Distance, i),
+			heartRate:   at(samples.HeartRate, i),
+			speed:       at(samples.Speed, i),
+			power:       at(samples.Power, i),
+			cadence:     at(samples.Cadence, i),
+			temperature: at(samples.Temperature, i),
+		})
+	}
+	return points
+}
+
+// startTime returns the activity start in UTC
+func startTime(detail *garmin.ActivityDetail, points []point) time.Time {
+	if !detail.StartTimeGMT.IsZero() {
+		return detail.StartTimeGMT.Time.UTC()
+	}
+	if len(points) > 0 {
+		return points[0].time
+	}
+	return detail.StartTimeLocal.Time.UTC()
+}
+
+// optional returns nil for NaN so the value is omitted from the output
+func optional(v float64) *float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return nil
+	}
+	return &v
+}
+
+// optionalInt rounds a value for integer-typed elements, returning nil for NaN
+func optionalInt(v float64) *int {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return nil
+	}
+	n := int(math.Round(v))
+	return &n
+}
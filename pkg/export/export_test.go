@@ -0,0 +1,282 @@
+package export_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sstent/go-garth-cli/pkg/export"
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+	types "github.com/sstent/go-garth/models/types"
+)
+
+var start = time.Date(2024, 3, 1, 7, 0, 0, 0, time.UTC)
+
+func testActivity() (*garmin.ActivityDetail, *garmin.ActivitySamples) {
+	nan := math.NaN()
+	detail := &garmin.ActivityDetail{
+		Activity: garmin.Activity{
+			ActivityID:   42,
+			ActivityName: "Tempo <Run>",
+			ActivityType: garmin.ActivityType{TypeKey: "running"},
+			StartTimeGMT: types.GarminTime{Time: start},
+			Distance:     30,
+			Duration:     3,
+		},
+		Laps: []garmin.Lap{
+			{Index: 1, StartTime: start, Distance: 20, Duration: 2, Calories: 1,
+				HeartRate: garmin.Metric{Average: 121, Max: 122}, Power: garmin.Metric{Average: 255, Max: 260}},
+			{Index: 2, StartTime: start.Add(2 * time.Second), Distance: 10, Duration: 1},
+		},
+	}
+	samples := &garmin.ActivitySamples{
+		ActivityID:  42,
+		Timestamps:  []time.Time{start, start.Add(time.Second), start.Add(2 * time.Second), {}},
+		Latitude:    garmin.Series{47.6, 47.6001, nan, nan},
+		Longitude:   garmin.Series{-122.3, -122.3001, nan, nan},
+		Distance:    garmin.Series{0, 10, 20, nan},
+		HeartRate:   garmin.Series{120, 122, nan, nan},
+		Speed:       garmin.Series{3.1, 3.2, 3.3, nan},
+		Power:       garmin.Series{250, 260, nan, nan},
+		Cadence:     garmin.Series{170, 172, 174, nan},
+		Elevation:   garmin.Series{10, 11, nan, nan},
+		Temperature: garmin.Series{18, nan, nan, nan},
+	}
+	return detail, samples
+}
+
+// assertElementOrder checks that the children of every element named parent
+// are known and appear in the given order, copied from the xs:sequence of the
+// format's schema. It is not a full validation against the schema.
+func assertElementOrder(t *testing.T, data []byte, parent string, sequence []string) {
+	t.Helper()
+	rank := map[string]int{}
+	for i, name := range sequence {
+		rank[name] = i
+	}
+
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	depth, parentDepth, last := 0, -1, -1
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			break
+		}
+		switch el := tok.(type) {
+		case xml.StartElement:
+			depth++
+			if parentDepth >= 0 && depth == parentDepth+1 {
+				r, ok := rank[el.Name.Local]
+				require.True(t, ok, "unexpected element %s in %s", el.Name.Local, parent)
+				assert.GreaterOrEqual(t, r, last, "element %s out of order in %s", el.Name.Local, parent)
+				last = r
+			}
+			if el.Name.Local == parent {
+				parentDepth, last = depth, -1
+			}
+		case xml.EndElement:
+			if depth == parentDepth {
+				parentDepth = -1
+			}
+			depth--
+		}
+	}
+}
+
+type gpxDoc struct {
+	XMLName xml.Name `xml:"http://www.topografix.com/GPX/1/1 gpx"`
+	Version string   `xml:"version,attr"`
+	Track   struct {
+		Name   string `xml:"http://www.topografix.com/GPX/1/1 name"`
+		Points []struct {
+			Lat        float64  `xml:"lat,attr"`
+			Lon        float64  `xml:"lon,attr"`
+			Ele        *float64 `xml:"http://www.topografix.com/GPX/1/1 ele"`
+			Time       string   `xml:"http://www.topografix.com/GPX/1/1 time"`
+			Extensions struct {
+				TPX struct {
+					ATemp *float64 `xml:"http://www.garmin.com/xmlschemas/TrackPointExtension/v2 atemp"`
+					HR    *int     `xml:"http://www.garmin.com/xmlschemas/TrackPointExtension/v2 hr"`
+					Cad   *int     `xml:"http://www.garmin.com/xmlschemas/TrackPointExtension/v2 cad"`
+				} `xml:"http://www.garmin.com/xmlschemas/TrackPointExtension/v2 TrackPointExtension"`
+			} `xml:"http://www.topografix.com/GPX/1/1 extensions"`
+		} `xml:"http://www.topografix.com/GPX/1/1 trkseg>trkpt"`
+	} `xml:"http://www.topografix.com/GPX/1/1 trk"`
+}
+
+func TestWriteGPX(t *testing.T) {
+	detail, samples := testActivity()
+	var buf bytes.Buffer
+	require.NoError(t, export.WriteGPX(&buf, detail, samples))
+
+	var doc gpxDoc
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, "1.1", doc.Version)
+	assert.Equal(t, "Tempo <Run>", doc.Track.Name)
+
+	// Samples without a position are not GPX track points
+	require.Len(t, doc.Track.Points, 2)
+	p := doc.Track.Points[0]
+	assert.Equal(t, 47.6, p.Lat)
+	assert.Equal(t, -122.3, p.Lon)
+	assert.Equal(t, "2024-03-01T07:00:00Z", p.Time)
+	assert.Equal(t, 10.0, *p.Ele)
+	assert.Equal(t, 120, *p.Extensions.TPX.HR)
+	assert.Equal(t, 170, *p.Extensions.TPX.Cad)
+	assert.Equal(t, 18.0, *p.Extensions.TPX.ATemp)
+	assert.Nil(t, doc.Track.Points[1].Extensions.TPX.ATemp)
+
+	assertElementOrder(t, buf.Bytes(), "gpx", []string{"metadata", "wpt", "rte", "trk", "extensions"})
+	assertElementOrder(t, buf.Bytes(), "trkpt", []string{"ele", "time", "magvar", "geoidheight", "name", "cmt", "desc", "src", "link", "sym", "type", "fix", "sat", "hdop", "vdop", "pdop", "ageofdgpsdata", "dgpsid", "extensions"})
+	assertElementOrder(t, buf.Bytes(), "TrackPointExtension", []string{"atemp", "wtemp", "depth", "hr", "cad", "speed", "course", "bearing"})
+}
+
+type tcxDoc struct {
+	Activity struct {
+		Sport string `xml:"Sport,attr"`
+		ID    string `xml:"http://www.garmin.com/xmlschemas/TrainingCenterDatabase/v2 Id"`
+		Laps  []struct {
+			StartTime  string  `xml:"StartTime,attr"`
+			Time       float64 `xml:"http://www.garmin.com/xmlschemas/TrainingCenterDatabase/v2 TotalTimeSeconds"`
+			Extensions struct {
+				LX struct {
+					AvgWatts *int `xml:"http://www.garmin.com/xmlschemas/ActivityExtension/v2 AvgWatts"`
+				} `xml:"http://www.garmin.com/xmlschemas/ActivityExtension/v2 LX"`
+			} `xml:"http://www.garmin.com/xmlschemas/TrainingCenterDatabase/v2 Extensions"`
+			Points []struct {
+				Time       string   `xml:"http://www.garmin.com/xmlschemas/TrainingCenterDatabase/v2 Time"`
+				Lat        *float64 `xml:"http://www.garmin.com/xmlschemas/TrainingCenterDatabase/v2 Position>LatitudeDegrees"`
+				HR         *int     `xml:"http://www.garmin.com/xmlschemas/TrainingCenterDatabase/v2 HeartRateBpm>Value"`
+				Extensions struct {
+					TPX struct {
+						Watts *int `xml:"http://www.garmin.com/xmlschemas/ActivityExtension/v2 Watts"`
+					} `xml:"http://www.garmin.com/xmlschemas/ActivityExtension/v2 TPX"`
+				} `xml:"http://www.garmin.com/xmlschemas/TrainingCenterDatabase/v2 Extensions"`
+				Distance *float64 `xml:"http://www.garmin.com/xmlschemas/TrainingCenterDatabase/v2 DistanceMeters"`
+			} `xml:"http://www.garmin.com/xmlschemas/TrainingCenterDatabase/v2 Track>Trackpoint"`
+		} `xml:"http://www.garmin.com/xmlschemas/TrainingCenterDatabase/v2 Lap"`
+	} `xml:"http://www.garmin.com/xmlschemas/TrainingCenterDatabase/v2 Activities>Activity"`
+}
+
+func TestWriteTCX(t *testing.T) {
+	detail, samples := testActivity()
+	var buf bytes.Buffer
+	require.NoError(t, export.WriteTCX(&buf, detail, samples))
+
+	var doc tcxDoc
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, "Running", doc.Activity.Sport)
+	assert.Equal(t, "2024-03-01T07:00:00Z", doc.Activity.ID)
+
+	require.Len(t, doc.Activity.Laps, 2)
+	lap := doc.Activity.Laps[0]
+	assert.Equal(t, 2.0, lap.Time)
+	require.NotNil(t, lap.Extensions.LX.AvgWatts)
+	assert.Equal(t, 255, *lap.Extensions.LX.AvgWatts)
+	require.Len(t, lap.Points, 2)
+	assert.Equal(t, 120, *lap.Points[0].HR)
+	assert.Equal(t, 250, *lap.Points[0].Extensions.TPX.Watts)
+
+	// The third sample has no position but still belongs to the second lap
+	require.Len(t, doc.Activity.Laps[1].Points, 1)
+	assert.Nil(t, doc.Activity.Laps[1].Points[0].Lat)
+	assert.Equal(t, 20.0, *doc.Activity.Laps[1].Points[0].Distance)
+
+	assertElementOrder(t, buf.Bytes(), "Activity", []string{"Id", "Lap", "Notes", "Training", "Creator", "Extensions"})
+	assertElementOrder(t, buf.Bytes(), "Lap", []string{"TotalTimeSeconds", "DistanceMeters", "MaximumSpeed", "Calories", "AverageHeartRateBpm", "MaximumHeartRateBpm", "Intensity", "Cadence", "TriggerMethod", "Track", "Notes", "Extensions"})
+	assertElementOrder(t, buf.Bytes(), "Trackpoint", []string{"Time", "Position", "AltitudeMeters", "DistanceMeters", "HeartRateBpm", "Cadence", "SensorState", "Extensions"})
+	assertElementOrder(t, buf.Bytes(), "TPX", []string{"Speed", "RunCadence", "Watts"})
+}
+
+func TestWriteGeoJSON(t *testing.T) {
+	detail, samples := testActivity()
+	var buf bytes.Buffer
+	require.NoError(t, export.WriteGeoJSON(&buf, detail, samples))
+
+	var doc struct {
+		Type     string `json:"type"`
+		Features []struct {
+			Type     string `json:"type"`
+			Geometry struct {
+				Type        string      `json:"type"`
+				Coordinates [][]float64 `json:"coordinates"`
+			} `json:"geometry"`
+			Properties map[string]interface{} `json:"properties"`
+		} `json:"features"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+
+	assert.Equal(t, "FeatureCollection", doc.Type)
+	require.Len(t, doc.Features, 1)
+	f := doc.Features[0]
+	assert.Equal(t, "Feature", f.Type)
+	assert.Equal(t, "LineString", f.Geometry.Type)
+	require.Len(t, f.Geometry.Coordinates, 2)
+	for _, c := range f.Geometry.Coordinates {
+		require.GreaterOrEqual(t, len(c), 2)
+		assert.True(t, c[0] >= -180 && c[0] <= 180, "longitude first")
+		assert.True(t, c[1] >= -90 && c[1] <= 90, "latitude second")
+	}
+	assert.Equal(t, []float64{-122.3, 47.6, 10}, f.Geometry.Coordinates[0])
+	assert.Equal(t, "running", f.Properties["activityType"])
+	assert.Len(t, f.Properties["coordTimes"], 2)
+	assert.Equal(t, []interface{}{120.0, 122.0}, f.Properties["heartRates"])
+}
+
+func TestWrite_WithoutSamples(t *testing.T) {
+	// A manual activity has a summary but no laps or samples
+	detail := &garmin.ActivityDetail{
+		Activity: garmin.Activity{
+			ActivityID:   43,
+			ActivityName: "Treadmill",
+			ActivityType: garmin.ActivityType{TypeKey: "treadmill_running"},
+			StartTimeGMT: types.GarminTime{Time: start},
+			Distance:     5000,
+			Duration:     1800,
+		},
+	}
+	samples := &garmin.ActivitySamples{ActivityID: 43}
+
+	var buf bytes.Buffer
+	require.NoError(t, export.WriteTCX(&buf, detail, samples))
+	var tcx tcxDoc
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &tcx))
+	assert.Equal(t, "Running", tcx.Activity.Sport)
+	require.Len(t, tcx.Activity.Laps, 1)
+	assert.Equal(t, "2024-03-01T07:00:00Z", tcx.Activity.Laps[0].StartTime)
+	assert.Equal(t, 1800.0, tcx.Activity.Laps[0].Time)
+	assert.Empty(t, tcx.Activity.Laps[0].Points)
+
+	buf.Reset()
+	require.NoError(t, export.WriteGPX(&buf, detail, samples))
+	var gpx gpxDoc
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &gpx))
+	assert.Equal(t, "Treadmill", gpx.Track.Name)
+	assert.Empty(t, gpx.Track.Points)
+
+	buf.Reset()
+	require.NoError(t, export.WriteGeoJSON(&buf, detail, samples))
+	var geo struct {
+		Features []struct {
+			Geometry   json.RawMessage        `json:"geometry"`
+			Properties map[string]interface{} `json:"properties"`
+		} `json:"features"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &geo))
+	require.Len(t, geo.Features, 1)
+	assert.Equal(t, "null", string(geo.Features[0].Geometry))
+	assert.Equal(t, 5000.0, geo.Features[0].Properties["distance"])
+	assert.Equal(t, "2024-03-01T07:00:00Z", geo.Features[0].Properties["startTime"])
+}
+
+func TestWrite_UnsupportedFormat(t *testing.T) {
+	detail, samples := testActivity()
+	err := export.Write(&bytes.Buffer{}, "kml", detail, samples)
+	assert.Error(t, err)
+}
@@ -0,0 +1,92 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+type geoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+type geoJSONFeature struct {
+	Type       string                 `json:"type"`
+	Geometry   *geoJSONGeometry       `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type geoJSONGeometry struct {
+	Type        string      `json:"type"`
+	Coordinates [][]float64 `json:"coordinates"`
+}
+
+// WriteGeoJSON writes an activity as an RFC 7946 FeatureCollection holding a
+// single LineString feature. Coordinates are [longitude, latitude] with
+// elevation as a third value when recorded. The feature properties carry the
+// activity summary and per-coordinate arrays (coordTimes, heartRates,
+// cadences, powers), aligned with the coordinates. Activities without a
+// position produce a feature with a null geometry.
+func WriteGeoJSON(w io.Writer, detail *garmin.ActivityDetail, samples *garmin.ActivitySamples) error {
+	points := samplePoints(samples)
+
+	var (
+		coordinates [][]float64
+		times       []string
+		heartRates  []*int
+		cadences    []*int
+		powers      []*int
+	)
+	for _, p := range points {
+		if !p.hasPosition() {
+			continue
+		}
+		coord := []float64{p.lon, p.lat}
+		if e := optional(p.elevation); e != nil {
+			coord = append(coord, *e)
+		}
+		coordinates = append(coordinates, coord)
+		times = append(times, p.time.Format(time.RFC3339))
+		heartRates = append(heartRates, optionalInt(p.heartRate))
+		cadences = append(cadences, optionalInt(p.cadence))
+		powers = append(powers, optionalInt(p.power))
+	}
+
+	properties := map[string]interface{}{
+		"activityId":   detail.ActivityID,
+		"name":         detail.ActivityName,
+		"activityType": detail.ActivityType.TypeKey,
+		"startTime":    startTime(detail, points).Format(time.RFC3339),
+		"distance":     detail.Distance,
+		"duration":     detail.Duration,
+		"coordTimes":   times,
+		"heartRates":   heartRates,
+		"cadences":     cadences,
+		"powers":       powers,
+	}
+	if detail.Description != "" {
+		properties["description"] = detail.Description
+	}
+	if detail.ElevationGain != 0 {
+		properties["elevationGain"] = detail.ElevationGain
+	}
+	if detail.Calories != 0 {
+		properties["calories"] = detail.Calories
+	}
+
+	feature := geoJSONFeature{Type: "Feature", Properties: properties}
+	// A LineString needs at least two positions
+	if len(coordinates) >= 2 {
+		feature.Geometry = &geoJSONGeometry{Type: "LineString", Coordinates: coordinates}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(geoJSONFeatureCollection{
+		Type:     "FeatureCollection",
+		Features: []geoJSONFeature{feature},
+	})
+}
@@ -0,0 +1,127 @@
+package export
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+const (
+	gpxNamespace    = "http://www.topografix.com/GPX/1/1"
+	gpxtpxNamespace = "http://www.garmin.com/xmlschemas/TrackPointExtension/v2"
+	xsiNamespace    = "http://www.w3.org/2001/XMLSchema-instance"
+	gpxSchema       = gpxNamespace + " http://www.topografix.com/GPX/1/1/gpx.xsd " +
+		gpxtpxNamespace + " http://www.garmin.com/xmlschemas/TrackPointExtensionv2.xsd"
+)
+
+type gpxFile struct {
+	XMLName        xml.Name    `xml:"gpx"`
+	Version        string      `xml:"version,attr"`
+	Creator        string      `xml:"creator,attr"`
+	Xmlns          string      `xml:"xmlns,attr"`
+	XmlnsGpxtpx    string      `xml:"xmlns:gpxtpx,attr"`
+	XmlnsXsi       string      `xml:"xmlns:xsi,attr"`
+	SchemaLocation string      `xml:"xsi:schemaLocation,attr"`
+	Metadata       gpxMetadata `xml:"metadata"`
+	Track          gpxTrack    `xml:"trk"`
+}
+
+type gpxMetadata struct {
+	Name string `xml:"name,omitempty"`
+	Desc string `xml:"desc,omitempty"`
+	Time string `xml:"time"`
+}
+
+type gpxTrack struct {
+	Name    string          `xml:"name,omitempty"`
+	Type    string          `xml:"type,omitempty"`
+	Segment gpxTrackSegment `xml:"trkseg"`
+}
+
+type gpxTrackSegment struct {
+	Points []gpxTrackPoint `xml:"trkpt"`
+}
+
+type gpxTrackPoint struct {
+	Lat        float64        `xml:"lat,attr"`
+	Lon        float64        `xml:"lon,attr"`
+	Elevation  *float64       `xml:"ele,omitempty"`
+	Time       string         `xml:"time"`
+	Extensions *gpxExtensions `xml:"extensions,omitempty"`
+}
+
+type gpxExtensions struct {
+	TrackPoint gpxTrackPointExtension `xml:"gpxtpx:TrackPointExtension"`
+}
+
+// gpxTrackPointExtension follows the element order of TrackPointExtension v2
+type gpxTrackPointExtension struct {
+	AirTemperature *float64 `xml:"gpxtpx:atemp,omitempty"`
+	HeartRate      *int     `xml:"gpxtpx:hr,omitempty"`
+	Cadence        *int     `xml:"gpxtpx:cad,omitempty"`
+	Speed          *float64 `xml:"gpxtpx:speed,omitempty"`
+}
+
+// WriteGPX writes an activity as a GPX 1.1 track. Heart rate, cadence,
+// temperature and speed are carried in Garmin's TrackPointExtension v2.
+// Samples without a position are skipped, as GPX track points require one.
+func WriteGPX(w io.Writer, detail *garmin.ActivityDetail, samples *garmin.ActivitySamples) error {
+	points := samplePoints(samples)
+
+	gpx := gpxFile{
+		Version:        "1.1",
+		Creator:        "garth",
+		Xmlns:          gpxNamespace,
+		XmlnsGpxtpx:    gpxtpxNamespace,
+		XmlnsXsi:       xsiNamespace,
+		SchemaLocation: gpxSchema,
+		Metadata: gpxMetadata{
+			Name: detail.ActivityName,
+			Desc: detail.Description,
+			Time: startTime(detail, points).Format(time.RFC3339),
+		},
+		Track: gpxTrack{
+			Name: detail.ActivityName,
+			Type: detail.ActivityType.TypeKey,
+		},
+	}
+
+	for _, p := range points {
+		if !p.hasPosition() {
+			continue
+		}
+		tp := gpxTrackPoint{
+			Lat:       p.lat,
+			Lon:       p.lon,
+			Elevation: optional(p.elevation),
+			Time:      p.time.Format(time.RFC3339),
+		}
+		ext := gpxTrackPointExtension{
+			AirTemperature: optional(p.temperature),
+			HeartRate:      optionalInt(p.heartRate),
+			Cadence:        optionalInt(p.cadence),
+			Speed:          optional(p.speed),
+		}
+		if ext != (gpxTrackPointExtension{}) {
+			tp.Extensions = &gpxExtensions{TrackPoint: ext}
+		}
+		gpx.Track.Segment.Points = append(gpx.Track.Segment.Points, tp)
+	}
+
+	return writeXML(w, gpx)
+}
+
+func writeXML(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,237 @@
+package export
+
+import (
+	"encoding/xml"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+const (
+	tcxNamespace = "http://www.garmin.com/xmlschemas/TrainingCenterDatabase/v2"
+	tcxExtension = "http://www.garmin.com/xmlschemas/ActivityExtension/v2"
+	tcxSchema    = tcxNamespace + " http://www.garmin.com/xmlschemas/TrainingCenterDatabasev2.xsd"
+)
+
+type tcxFile struct {
+	XMLName        xml.Name      `xml:"TrainingCenterDatabase"`
+	Xmlns          string        `xml:"xmlns,attr"`
+	XmlnsNs3       string        `xml:"xmlns:ns3,attr"`
+	XmlnsXsi       string        `xml:"xmlns:xsi,attr"`
+	SchemaLocation string        `xml:"xsi:schemaLocation,attr"`
+	Activities     tcxActivities `xml:"Activities"`
+}
+
+type tcxActivities struct {
+	Activity tcxActivity `xml:"Activity"`
+}
+
+type tcxActivity struct {
+	Sport string   `xml:"Sport,attr"`
+	ID    string   `xml:"Id"`
+	Laps  []tcxLap `xml:"Lap"`
+	Notes string   `xml:"Notes,omitempty"`
+}
+
+// tcxLap follows the element order of ActivityLap_t
+type tcxLap struct {
+	StartTime        string            `xml:"StartTime,attr"`
+	TotalTimeSeconds float64           `xml:"TotalTimeSeconds"`
+	DistanceMeters   float64           `xml:"DistanceMeters"`
+	MaximumSpeed     *float64          `xml:"MaximumSpeed,omitempty"`
+	Calories         int               `xml:"Calories"`
+	AverageHeartRate *tcxHeartRate     `xml:"AverageHeartRateBpm,omitempty"`
+	MaximumHeartRate *tcxHeartRate     `xml:"MaximumHeartRateBpm,omitempty"`
+	Intensity        string            `xml:"Intensity"`
+	Cadence          *int              `xml:"Cadence,omitempty"`
+	TriggerMethod    string            `xml:"TriggerMethod"`
+	Track            *tcxTrack         `xml:"Track,omitempty"`
+	Extensions       *tcxLapExtensions `xml:"Extensions,omitempty"`
+}
+
+type tcxHeartRate struct {
+	Value int `xml:"Value"`
+}
+
+type tcxTrack struct {
+	Points []tcxTrackpoint `xml:"Trackpoint"`
+}
+
+// tcxTrackpoint follows the element order of Trackpoint_t
+type tcxTrackpoint struct {
+	Time           string                   `xml:"Time"`
+	Position       *tcxPosition             `xml:"Position,omitempty"`
+	AltitudeMeters *float64                 `xml:"AltitudeMeters,omitempty"`
+	DistanceMeters *float64                 `xml:"DistanceMeters,omitempty"`
+	HeartRate      *tcxHeartRate            `xml:"HeartRateBpm,omitempty"`
+	Cadence        *int                     `xml:"Cadence,omitempty"`
+	Extensions     *tcxTrackpointExtensions `xml:"Extensions,omitempty"`
+}
+
+type tcxPosition struct {
+	Latitude  float64 `xml:"LatitudeDegrees"`
+	Longitude float64 `xml:"LongitudeDegrees"`
+}
+
+type tcxTrackpointExtensions struct {
+	TPX tcxTPX `xml:"ns3:TPX"`
+}
+
+type tcxTPX struct {
+	Speed *float64 `xml:"ns3:Speed,omitempty"`
+	Watts *int     `xml:"ns3:Watts,omitempty"`
+}
+
+type tcxLapExtensions struct {
+	LX tcxLX `xml:"ns3:LX"`
+}
+
+type tcxLX struct {
+	AvgSpeed *float64 `xml:"ns3:AvgSpeed,omitempty"`
+	AvgWatts *int     `xml:"ns3:AvgWatts,omitempty"`
+	MaxWatts *int     `xml:"ns3:MaxWatts,omitempty"`
+}
+
+// tcxSport maps Garmin activity types onto the TCX Sport_t enumeration
+func tcxSport(typeKey string) string {
+	switch {
+	case strings.Contains(typeKey, "running"):
+		return "Running"
+	case strings.Contains(typeKey, "cycling"), strings.Contains(typeKey, "biking"):
+		return "Biking"
+	default:
+		return "Other"
+	}
+}
+
+// WriteTCX writes an activity as a TCX training center database. Each
+// Garmin lap becomes a TCX lap holding the samples recorded during it, and
+// power is carried in the ActivityExtension v2 TPX and LX elements. Samples
+// without a position are kept, so indoor activities export as well.
+func WriteTCX(w io.Writer, detail *garmin.ActivityDetail, samples *garmin.ActivitySamples) error {
+	points := samplePoints(samples)
+	start := startTime(detail, points)
+
+	laps := detail.Laps
+	if len(laps) == 0 {
+		laps = []garmin.Lap{{
+			Index:     1,
+			StartTime: start,
+			Distance:  detail.Distance,
+			Duration:  detail.Duration,
+			Calories:  detail.Calories,
+			HeartRate: detail.HeartRate,
+			Power:     detail.Power,
+			Cadence:   detail.Cadence,
+			Speed:     detail.Speed,
+		}}
+	}
+
+	activity := tcxActivity{
+		Sport: tcxSport(detail.ActivityType.TypeKey),
+		ID:    start.Format(time.RFC3339),
+		Notes: detail.Description,
+	}
+
+	next := 0
+	for i, lap := range laps {
+		lapStart := lap.StartTime.UTC()
+		if lapStart.IsZero() {
+			lapStart = start
+		}
+		tl := tcxLap{
+			StartTime:        lapStart.Format(time.RFC3339),
+			TotalTimeSeconds: lap.Duration,
+			DistanceMeters:   lap.Distance,
+			MaximumSpeed:     nonZero(lap.Speed.Max),
+			Calories:         int(math.Round(lap.Calories)),
+			AverageHeartRate: heartRate(lap.HeartRate.Average),
+			MaximumHeartRate: heartRate(lap.HeartRate.Max),
+			Intensity:        "Active",
+			TriggerMethod:    "Manual",
+		}
+		if lap.Cadence.Average > 0 && lap.Cadence.Average <= 254 {
+			cadence := int(math.Round(lap.Cadence.Average))
+			tl.Cadence = &cadence
+		}
+		if lap.Speed.Average > 0 || lap.Power.Average > 0 {
+			tl.Extensions = &tcxLapExtensions{LX: tcxLX{
+				AvgSpeed: nonZero(lap.Speed.Average),
+				AvgWatts: nonZeroInt(lap.Power.Average),
+				MaxWatts: nonZeroInt(lap.Power.Max),
+			}}
+		}
+
+		// Samples belong to a lap until the next lap starts
+		var end time.Time
+		if i+1 < len(laps) {
+			end = laps[i+1].StartTime.UTC()
+		}
+		track := &tcxTrack{}
+		for ; next < len(points); next++ {
+			p := points[next]
+			if !end.IsZero() && !p.time.Before(end) {
+				break
+			}
+			track.Points = append(track.Points, tcxPoint(p))
+		}
+		if len(track.Points) > 0 {
+			tl.Track = track
+		}
+		activity.Laps = append(activity.Laps, tl)
+	}
+
+	return writeXML(w, tcxFile{
+		Xmlns:          tcxNamespace,
+		XmlnsNs3:       tcxExtension,
+		XmlnsXsi:       xsiNamespace,
+		SchemaLocation: tcxSchema,
+		Activities:     tcxActivities{Activity: activity},
+	})
+}
+
+func tcxPoint(p point) tcxTrackpoint {
+	tp := tcxTrackpoint{
+		Time:           p.time.Format(time.RFC3339),
+		AltitudeMeters: optional(p.elevation),
+		DistanceMeters: optional(p.distance),
+	}
+	if p.hasPosition() {
+		tp.Position = &tcxPosition{Latitude: p.lat, Longitude: p.lon}
+	}
+	if hr := optionalInt(p.heartRate); hr != nil && *hr > 0 && *hr <= 255 {
+		tp.HeartRate = &tcxHeartRate{Value: *hr}
+	}
+	if cad := optionalInt(p.cadence); cad != nil && *cad >= 0 && *cad <= 254 {
+		tp.Cadence = cad
+	}
+	tpx := tcxTPX{Speed: optional(p.speed), Watts: optionalInt(p.power)}
+	if tpx.Speed != nil || tpx.Watts != nil {
+		tp.Extensions = &tcxTrackpointExtensions{TPX: tpx}
+	}
+	return tp
+}
+
+func heartRate(v float64) *tcxHeartRate {
+	if v <= 0 || v > 255 {
+		return nil
+	}
+	return &tcxHeartRate{Value: int(math.Round(v))}
+}
+
+func nonZero(v float64) *float64 {
+	if v == 0 {
+		return nil
+	}
+	return optional(v)
+}
+
+func nonZeroInt(v float64) *int {
+	if v == 0 {
+		return nil
+	}
+	return optionalInt(v)
+}
@@ -0,0 +1,601 @@
+// Package fit decodes Garmin FIT (Flexible and Interoperable Data Transfer)
+// activity files.
+//
+// Decode parses definition and data messages, developer fields and
+// compressed timestamp headers, validates the header and file CRCs and
+// returns the file's session, lap, record, event and device_info messages as
+// typed structs. Every decoded message is also available in generic form
+// through File.Messages.
+//
+//	file, err := fit.DecodeFile("12345_ACTIVITY.fit")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, rec := range file.Records {
+//	    fmt.Println(rec.Timestamp, rec.HeartRate)
+//	}
+package fit
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+)
+
+var (
+	// ErrInvalidHeader is returned when the data does not start with a FIT file header
+	ErrInvalidHeader = errors.New("fit: invalid file header")
+	// ErrChecksum is returned when the header or file CRC does not match the content
+	ErrChecksum = errors.New("fit: checksum mismatch")
+	// ErrTruncated is returned when the file ends in the middle of a message
+	ErrTruncated = errors.New("fit: unexpected end of data")
+)
+
+// Header is the FIT file header
+type Header struct {
+	Size            byte   `json:"size"`
+	ProtocolVersion byte   `json:"protocolVersion"`
+	ProfileVersion  uint16 `json:"profileVersion"`
+	DataSize        uint32 `json:"dataSize"`
+	CRC             uint16 `json:"crc,omitempty"`
+}
+
+// Field is a decoded field of a data message. Value holds an int64, uint64,
+// float64, string or []byte; array fields hold a []interface{} of those. Value
+// is nil when the field holds the base type's invalid value.
+type Field struct {
+	Num   byte        `json:"num"`
+	Value interface{} `json:"value"`
+}
+
+// DeveloperField is a decoded developer data field. Name and Units come from
+// the matching field_description message, whose scale and offset have been
+// applied to Value. Fields without a description keep their raw bytes.
+type DeveloperField struct {
+	DeveloperDataIndex byte        `json:"developerDataIndex"`
+	Num                byte        `json:"num"`
+	Name               string      `json:"name,omitempty"`
+	Units              string      `json:"units,omitempty"`
+	Value              interface{} `json:"value"`
+}
+
+// Message is a decoded data message in generic form
+type Message struct {
+	Num             uint16           `json:"num"`
+	Fields          []Field          `json:"fields"`
+	DeveloperFields []DeveloperField `json:"developerFields,omitempty"`
+}
+
+// Field returns the value of a field, or nil if it is missing or invalid
+func (m *Message) Field(num byte) interface{} {
+	for _, f := range m.Fields {
+		if f.Num == num {
+			return f.Value
+		}
+	}
+	return nil
+}
+
+// Float returns a numeric field as float64
+func (m *Message) Float(num byte) (float64, bool) {
+	switch v := m.Field(num).(type) {
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+// Uint returns an integer field as uint64
+func (m *Message) Uint(num byte) (uint64, bool) {
+	switch v := m.Field(num).(type) {
+	case int64:
+		if v >= 0 {
+			return uint64(v), true
+		}
+	case uint64:
+		return v, true
+	}
+	return 0, false
+}
+
+// Int returns an integer field as int64
+func (m *Message) Int(num byte) (int64, bool) {
+	switch v := m.Field(num).(type) {
+	case int64:
+		return v, true
+	case uint64:
+		if v <= math.MaxInt64 {
+			return int64(v), true
+		}
+	}
+	return 0, false
+}
+
+// String returns a string field
+func (m *Message) String(num byte) string {
+	s, _ := m.Field(num).(string)
+	return s
+}
+
+// File is a decoded FIT file
+type File struct {
+	Header      Header       `json:"header"`
+	FileID      *FileID      `json:"fileId,omitempty"`
+	Sessions    []Session    `json:"sessions"`
+	Laps        []Lap        `json:"laps"`
+	Records     []Record     `json:"records"`
+	Events      []Event      `json:"events"`
+	DeviceInfos []DeviceInfo `json:"deviceInfos"`
+	Messages    []Message    `json:"-"`
+}
+
+// DecodeFile decodes the FIT file at path
+func DecodeFile(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeBytes(data)
+}
+
+// Decode reads and decodes a FIT file from r
+func Decode(r io.Reader) (*File, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeBytes(data)
+}
+
+// DecodeBytes decodes a FIT file held in memory. Chained FIT files (several
+// files concatenated together) are decoded into a single File.
+func DecodeBytes(data []byte) (*File, error) {
+	if len(data) == 0 {
+		return nil, ErrInvalidHeader
+	}
+	file := &File{}
+	for offset := 0; offset < len(data); {
+		n, err := file.decodeChunk(data[offset:], offset == 0)
+		if err != nil {
+			return nil, err
+		}
+		offset += n
+	}
+	return file, nil
+}
+
+// decodeChunk decodes one FIT file from the start of data and returns the
+// number of bytes it occupied
+func (f *File) decodeChunk(data []byte, first bool) (int, error) {
+	header, err := parseHeader(data)
+	if err != nil {
+		return 0, err
+	}
+	if first {
+		f.Header = header
+	}
+
+	end := int(header.Size) + int(header.DataSize)
+	if len(data) < end+2 {
+		return 0, ErrTruncated
+	}
+	fileCRC := binary.LittleEndian.Uint16(data[end : end+2])
+	if CRC(0, data[:end]) != fileCRC {
+		return 0, fmt.Errorf("%w: file CRC", ErrChecksum)
+	}
+
+	d := &decoder{file: f, data: data[header.Size:end], devFields: map[[2]byte]fieldDescription{}}
+	if err := d.decodeRecords(); err != nil {
+		return 0, err
+	}
+	return end + 2, nil
+}
+
+func parseHeader(data []byte) (Header, error) {
+	if len(data) < 12 {
+		return Header{}, ErrInvalidHeader
+	}
+	h := Header{
+		Size:            data[0],
+		ProtocolVersion: data[1],
+		ProfileVersion:  binary.LittleEndian.Uint16(data[2:4]),
+		DataSize:        binary.LittleEndian.Uint32(data[4:8]),
+	}
+	if (h.Size != 12 && h.Size != 14) || len(data) < int(h.Size) || string(data[8:12]) != ".FIT" {
+		return Header{}, ErrInvalidHeader
+	}
+	if h.Size == 14 {
+		h.CRC = binary.LittleEndian.Uint16(data[12:14])
+		// A header CRC of zero means the CRC was not computed
+		if h.CRC != 0 && CRC(0, data[:12]) != h.CRC {
+			return Header{}, fmt.Errorf("%w: header CRC", ErrChecksum)
+		}
+	}
+	return h, nil
+}
+
+type fieldDefinition struct {
+	num      byte
+	size     byte
+	baseType byte
+}
+
+type devFieldDefinition struct {
+	num                byte
+	size               byte
+	developerDataIndex byte
+}
+
+type definition struct {
+	globalNum uint16
+	order     binary.ByteOrder
+	fields    []fieldDefinition
+	devFields []devFieldDefinition
+}
+
+// fieldDescription is the subset of a field_description message needed to
+// decode developer fields
+type fieldDescription struct {
+	baseType byte
+	name     string
+	units    string
+	scale    float64
+	offset   float64
+}
+
+type decoder struct {
+	file          *File
+	data          []byte
+	pos           int
+	definitions   [16]*definition
+	lastTimestamp uint32
+	devFields     map[[2]byte]fieldDescription
+}
+
+func (d *decoder) read(n int) ([]byte, error) {
+	if d.pos+n > len(d.data) {
+		return nil, ErrTruncated
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+func (d *decoder) decodeRecords() error {
+	for d.pos < len(d.data) {
+		b, err := d.read(1)
+		if err != nil {
+			return err
+		}
+		header := b[0]
+
+		switch {
+		case header&0x80 != 0:
+			// Compressed timestamp header: local type in bits 5-6, offset in bits 0-4
+			local := (header >> 5) & 0x03
+			offset := uint32(header & 0x1F)
+			ts := (d.lastTimestamp &^ 0x1F) + offset
+			if offset < d.lastTimestamp&0x1F {
+				ts += 0x20
+			}
+			d.lastTimestamp = ts
+			if err := d.decodeData(local, &ts); err != nil {
+				return err
+			}
+		case header&0x40 != 0:
+			if err := d.decodeDefinition(header&0x0F, header&0x20 != 0); err != nil {
+				return err
+			}
+		default:
+			if err := d.decodeData(header&0x0F, nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *decoder) decodeDefinition(local byte, hasDevFields bool) error {
+	b, err := d.read(5)
+	if err != nil {
+		return err
+	}
+	def := &definition{order: binary.LittleEndian}
+	if b[1] == 1 {
+		def.order = binary.BigEndian
+	}
+	def.globalNum = def.order.Uint16(b[2:4])
+
+	numFields := int(b[4])
+	fields, err := d.read(numFields * 3)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < numFields; i++ {
+		def.fields = append(def.fields, fieldDefinition{
+			num:      fields[i*3],
+			size:     fields[i*3+1],
+			baseType: fields[i*3+2],
+		})
+	}
+
+	if hasDevFields {
+		n, err := d.read(1)
+		if err != nil {
+			return err
+		}
+		devFields, err := d.read(int(n[0]) * 3)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < int(n[0]); i++ {
+			def.devFields = append(def.devFields, devFieldDefinition{
+				num:                devFields[i*3],
+				size:               devFields[i*3+1],
+				developerDataIndex: devFields[i*3+2],
+			})
+		}
+	}
+
+	d.definitions[local] = def
+	return nil
+}
+
+// decodeData decodes a data message. ts is set for compressed timestamp
+// headers and is added to the message as its timestamp field.
+func (d *decoder) decodeData(local byte, ts *uint32) error {
+	def := d.definitions[local]
+	if def == nil {
+		return fmt.Errorf("fit: data message for undefined local type %d at offset %d", local, d.pos)
+	}
+
+	msg := Message{Num: def.globalNum}
+	for _, fd := range def.fields {
+		raw, err := d.read(int(fd.size))
+		if err != nil {
+			return err
+		}
+		value := decodeValue(raw, fd.baseType, def.order)
+		if fd.num == fieldTimestamp {
+			if v, ok := value.(uint64); ok {
+				d.lastTimestamp = uint32(v)
+			}
+		}
+		msg.Fields = append(msg.Fields, Field{Num: fd.num, Value: value})
+	}
+	if ts != nil {
+		msg.Fields = append(msg.Fields, Field{Num: fieldTimestamp, Value: uint64(*ts)})
+	}
+
+	for _, dd := range def.devFields {
+		raw, err := d.read(int(dd.size))
+		if err != nil {
+			return err
+		}
+		desc, known := d.devFields[[2]byte{dd.developerDataIndex, dd.num}]
+		field := DeveloperField{DeveloperDataIndex: dd.developerDataIndex, Num: dd.num}
+		if known {
+			field.Name = desc.name
+			field.Units = desc.units
+			field.Value = applyScale(decodeValue(raw, desc.baseType, def.order), desc.scale, desc.offset)
+		} else {
+			field.Value = append([]byte(nil), raw...)
+		}
+		msg.DeveloperFields = append(msg.DeveloperFields, field)
+	}
+
+	if msg.Num == MesgNumFieldDescription {
+		d.registerFieldDescription(&msg)
+	}
+	d.file.add(msg)
+	return nil
+}
+
+func (d *decoder) registerFieldDescription(msg *Message) {
+	index, ok1 := msg.Uint(0)
+	num, ok2 := msg.Uint(1)
+	baseType, ok3 := msg.Uint(2)
+	if !ok1 || !ok2 || !ok3 {
+		return
+	}
+	desc := fieldDescription{
+		baseType: byte(baseType),
+		name:     msg.String(3),
+		units:    msg.String(8),
+		scale:    1,
+	}
+	if scale, ok := msg.Float(6); ok && scale != 0 {
+		desc.scale = scale
+	}
+	if offset, ok := msg.Float(7); ok {
+		desc.offset = offset
+	}
+	d.devFields[[2]byte{byte(index), byte(num)}] = desc
+}
+
+func applyScale(value interface{}, scale, offset float64) interface{} {
+	if scale == 1 && offset == 0 {
+		return value
+	}
+	switch v := value.(type) {
+	case int64:
+		return float64(v)/scale - offset
+	case uint64:
+		return float64(v)/scale - offset
+	case float64:
+		return v/scale - offset
+	}
+	return value
+}
+
+// Base types as defined by the FIT protocol
+const (
+	baseEnum    = 0x00
+	baseSint8   = 0x01
+	baseUint8   = 0x02
+	baseSint16  = 0x83
+	baseUint16  = 0x84
+	baseSint32  = 0x85
+	baseUint32  = 0x86
+	baseString  = 0x07
+	baseFloat32 = 0x88
+	baseFloat64 = 0x89
+	baseUint8z  = 0x0A
+	baseUint16z = 0x8B
+	baseUint32z = 0x8C
+	baseByte    = 0x0D
+	baseSint64  = 0x8E
+	baseUint64  = 0x8F
+	baseUint64z = 0x90
+)
+
+func baseTypeSize(baseType byte) int {
+	switch baseType {
+	case baseSint16, baseUint16, baseUint16z:
+		return 2
+	case baseSint32, baseUint32, baseUint32z, baseFloat32:
+		return 4
+	case baseSint64, baseUint64, baseUint64z, baseFloat64:
+		return 8
+	default:
+		return 1
+	}
+}
+
+// decodeValue decodes a field of the given base type. Fields larger than the
+// base type are arrays.
+func decodeValue(raw []byte, baseType byte, order binary.ByteOrder) interface{} {
+	switch baseType {
+	case baseString:
+		if i := bytes.IndexByte(raw, 0); i >= 0 {
+			raw = raw[:i]
+		}
+		if len(raw) == 0 {
+			return nil
+		}
+		return string(raw)
+	case baseByte:
+		for _, b := range raw {
+			if b != 0xFF {
+				return append([]byte(nil), raw...)
+			}
+		}
+		return nil
+	}
+
+	size := baseTypeSize(baseType)
+	if len(raw) < size {
+		return nil
+	}
+	if len(raw) == size {
+		return decodeScalar(raw, baseType, order)
+	}
+
+	var values []interface{}
+	valid := false
+	for i := 0; i+size <= len(raw); i += size {
+		v := decodeScalar(raw[i:i+size], baseType, order)
+		valid = valid || v != nil
+		values = append(values, v)
+	}
+	if !valid {
+		return nil
+	}
+	return values
+}
+
+func decodeScalar(raw []byte, baseType byte, order binary.ByteOrder) interface{} {
+	switch baseType {
+	case baseEnum, baseUint8:
+		if raw[0] == 0xFF {
+			return nil
+		}
+		return uint64(raw[0])
+	case baseUint8z:
+		if raw[0] == 0 {
+			return nil
+		}
+		return uint64(raw[0])
+	case baseSint8:
+		if raw[0] == 0x7F {
+			return nil
+		}
+		return int64(int8(raw[0]))
+	case baseUint16:
+		v := order.Uint16(raw)
+		if v == 0xFFFF {
+			return nil
+		}
+		return uint64(v)
+	case baseUint16z:
+		v := order.Uint16(raw)
+		if v == 0 {
+			return nil
+		}
+		return uint64(v)
+	case baseSint16:
+		v := order.Uint16(raw)
+		if v == 0x7FFF {
+			return nil
+		}
+		return int64(int16(v))
+	case baseUint32:
+		v := order.Uint32(raw)
+		if v == 0xFFFFFFFF {
+			return nil
+		}
+		return uint64(v)
+	case baseUint32z:
+		v := order.Uint32(raw)
+		if v == 0 {
+			return nil
+		}
+		return uint64(v)
+	case baseSint32:
+		v := order.Uint32(raw)
+		if v == 0x7FFFFFFF {
+			return nil
+		}
+		return int64(int32(v))
+	case baseFloat32:
+		v := order.Uint32(raw)
+		if v == 0xFFFFFFFF {
+			return nil
+		}
+		return float64(math.Float32frombits(v))
+	case baseFloat64:
+		v := order.Uint64(raw)
+		if v == 0xFFFFFFFFFFFFFFFF {
+			return nil
+		}
+		return math.Float64frombits(v)
+	case baseUint64:
+		v := order.Uint64(raw)
+		if v == 0xFFFFFFFFFFFFFFFF {
+			return nil
+		}
+		return v
+	case baseUint64z:
+		v := order.Uint64(raw)
+		if v == 0 {
+			return nil
+		}
+		return v
+	case baseSint64:
+		v := order.Uint64(raw)
+		if v == 0x7FFFFFFFFFFFFFFF {
+			return nil
+		}
+		return int64(v)
+	}
+	// Unknown base types are kept as raw bytes
+	return append([]byte(nil), raw...)
+}
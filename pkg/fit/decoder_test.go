@@ -0,0 +1,189 @@
+package fit_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sstent/go-garth-cli/pkg/fit"
+)
+
+// fitBuilder assembles FIT files for tests
+type fitBuilder struct {
+	data bytes.Buffer
+}
+
+type testField struct {
+	num, size, baseType byte
+}
+
+func (b *fitBuilder) definition(local byte, global uint16, fields []testField, devFields []testField) {
+	header := 0x40 | local
+	if len(devFields) > 0 {
+		header |= 0x20
+	}
+	b.data.WriteByte(header)
+	b.data.WriteByte(0) // reserved
+	b.data.WriteByte(0) // little endian
+	binary.Write(&b.data, binary.LittleEndian, global)
+	b.data.WriteByte(byte(len(fields)))
+	for _, f := range fields {
+		b.data.Write([]byte{f.num, f.size, f.baseType})
+	}
+	if len(devFields) > 0 {
+		b.data.WriteByte(byte(len(devFields)))
+		for _, f := range devFields {
+			// baseType holds the developer data index for developer fields
+			b.data.Write([]byte{f.num, f.size, f.baseType})
+		}
+	}
+}
+
+func (b *fitBuilder) message(header byte, values ...interface{}) {
+	b.data.WriteByte(header)
+	for _, v := range values {
+		switch v := v.(type) {
+		case string:
+			b.data.WriteString(v)
+		default:
+			binary.Write(&b.data, binary.LittleEndian, v)
+		}
+	}
+}
+
+func (b *fitBuilder) bytes() []byte {
+	var out bytes.Buffer
+	header := []byte{14, 0x20, 0, 0, 0, 0, 0, 0, '.', 'F', 'I', 'T'}
+	binary.LittleEndian.PutUint16(header[2:4], 2132)
+	binary.LittleEndian.PutUint32(header[4:8], uint32(b.data.Len()))
+	out.Write(header)
+	binary.Write(&out, binary.LittleEndian, fit.CRC(0, header))
+	out.Write(b.data.Bytes())
+	binary.Write(&out, binary.LittleEndian, fit.CRC(0, out.Bytes()))
+	return out.Bytes()
+}
+
+// fitTime converts a time into FIT seconds
+func fitTime(t time.Time) uint32 {
+	return uint32(t.Sub(time.Date(1989, 12, 31, 0, 0, 0, 0, time.UTC)) / time.Second)
+}
+
+func buildActivity() []byte {
+	start := time.Date(2024, 3, 1, 7, 0, 0, 0, time.UTC)
+	b := &fitBuilder{}
+
+	// file_id
+	b.definition(0, fit.MesgNumFileID, []testField{{0, 1, 0x00}, {1, 2, 0x84}, {2, 2, 0x84}, {4, 4, 0x86}}, nil)
+	b.message(0x00, uint8(4), uint16(1), uint16(3121), fitTime(start))
+
+	// developer_data_id and field_description for a "stryd_l" developer field
+	b.definition(1, fit.MesgNumDeveloperDataID, []testField{{3, 1, 0x02}}, nil)
+	b.message(0x01, uint8(0))
+	b.definition(2, fit.MesgNumFieldDescription, []testField{
+		{0, 1, 0x02}, {1, 1, 0x02}, {2, 1, 0x02}, {3, 8, 0x07}, {8, 4, 0x07},
+	}, nil)
+	b.message(0x02, uint8(0), uint8(0), uint8(0x84), "stryd_l\x00", "kN/m")
+
+	// record with developer field
+	b.definition(3, fit.MesgNumRecord, []testField{
+		{253, 4, 0x86}, {0, 4, 0x85}, {1, 4, 0x85}, {3, 1, 0x02}, {6, 2, 0x84}, {7, 2, 0x84}, {13, 1, 0x01},
+	}, []testField{{0, 2, 0}})
+	b.message(0x03, fitTime(start), int32(568471500), int32(-1459617000), uint8(120), uint16(3200), uint16(250), int8(18), uint16(11))
+
+	// records with compressed timestamp headers; only local types 0-3 can be
+	// used, so local type 0 is redefined as a record layout
+	b.definition(0, fit.MesgNumRecord, []testField{{3, 1, 0x02}, {5, 4, 0x86}}, nil)
+	b.message(0x80|byte((fitTime(start)+3)&0x1F), uint8(125), uint32(500))
+	b.message(0x80|byte((fitTime(start)+5)&0x1F), uint8(0xFF), uint32(1050))
+
+	// lap and session
+	b.definition(5, fit.MesgNumLap, []testField{
+		{253, 4, 0x86}, {2, 4, 0x86}, {7, 4, 0x86}, {9, 4, 0x86}, {15, 1, 0x02},
+	}, nil)
+	b.message(0x05, fitTime(start.Add(time.Hour)), fitTime(start), uint32(3600000), uint32(1000000), uint8(150))
+	b.definition(6, fit.MesgNumSession, []testField{
+		{253, 4, 0x86}, {2, 4, 0x86}, {5, 1, 0x00}, {9, 4, 0x86}, {16, 1, 0x02}, {24, 1, 0x02},
+	}, nil)
+	b.message(0x06, fitTime(start.Add(time.Hour)), fitTime(start), uint8(1), uint32(1000000), uint8(150), uint8(34))
+
+	// event and device_info
+	b.definition(7, fit.MesgNumEvent, []testField{{253, 4, 0x86}, {0, 1, 0x00}, {1, 1, 0x00}}, nil)
+	b.message(0x07, fitTime(start), uint8(0), uint8(0))
+	b.definition(8, fit.MesgNumDeviceInfo, []testField{{0, 1, 0x02}, {2, 2, 0x84}, {5, 2, 0x84}, {27, 8, 0x07}}, nil)
+	b.message(0x08, uint8(0), uint16(1), uint16(1210), "Forerun\x00")
+
+	return b.bytes()
+}
+
+func TestDecodeBytes(t *testing.T) {
+	file, err := fit.DecodeBytes(buildActivity())
+	require.NoError(t, err)
+
+	start := time.Date(2024, 3, 1, 7, 0, 0, 0, time.UTC)
+
+	require.NotNil(t, file.FileID)
+	assert.Equal(t, uint16(3121), file.FileID.Product)
+	assert.Equal(t, start, file.FileID.TimeCreated)
+
+	require.Len(t, file.Records, 3)
+	rec := file.Records[0]
+	assert.Equal(t, start, rec.Timestamp)
+	require.NotNil(t, rec.Latitude)
+	assert.InDelta(t, 47.649, *rec.Latitude, 0.001)
+	assert.InDelta(t, -122.35, *rec.Longitude, 0.01)
+	assert.Equal(t, uint8(120), *rec.HeartRate)
+	assert.InDelta(t, 3.2, *rec.Speed, 1e-9)
+	assert.Equal(t, uint16(250), *rec.Power)
+	assert.Equal(t, int8(18), *rec.Temperature)
+	require.Len(t, rec.DeveloperFields, 1)
+	assert.Equal(t, "stryd_l", rec.DeveloperFields[0].Name)
+	assert.Equal(t, "kN/m", rec.DeveloperFields[0].Units)
+	assert.Equal(t, uint64(11), rec.DeveloperFields[0].Value)
+
+	// Compressed timestamps advance from the last full timestamp
+	assert.Equal(t, start.Add(3*time.Second), file.Records[1].Timestamp)
+	assert.Equal(t, uint8(125), *file.Records[1].HeartRate)
+	assert.Equal(t, start.Add(5*time.Second), file.Records[2].Timestamp)
+	assert.Nil(t, file.Records[2].HeartRate)
+	assert.InDelta(t, 10.5, *file.Records[2].Distance, 1e-9)
+
+	require.Len(t, file.Laps, 1)
+	assert.Equal(t, 3600.0, file.Laps[0].TotalElapsedTime)
+	assert.Equal(t, 10000.0, file.Laps[0].TotalDistance)
+
+	require.Len(t, file.Sessions, 1)
+	assert.Equal(t, uint8(1), file.Sessions[0].Sport)
+	assert.Equal(t, uint8(150), file.Sessions[0].AvgHeartRate)
+	assert.InDelta(t, 3.4, file.Sessions[0].TotalTrainingEffect, 1e-9)
+
+	require.Len(t, file.Events, 1)
+	require.Len(t, file.DeviceInfos, 1)
+	assert.Equal(t, "Forerun", file.DeviceInfos[0].ProductName)
+	assert.InDelta(t, 12.1, file.DeviceInfos[0].SoftwareVersion, 1e-9)
+
+	assert.NotEmpty(t, file.Messages)
+}
+
+func TestDecodeBytes_ChecksumMismatch(t *testing.T) {
+	data := buildActivity()
+	data[20] ^= 0xFF
+
+	_, err := fit.DecodeBytes(data)
+	assert.True(t, errors.Is(err, fit.ErrChecksum))
+}
+
+func TestDecodeBytes_InvalidHeader(t *testing.T) {
+	_, err := fit.DecodeBytes([]byte("<html>not a fit file</html>"))
+	assert.True(t, errors.Is(err, fit.ErrInvalidHeader))
+}
+
+func TestDecodeBytes_Truncated(t *testing.T) {
+	data := buildActivity()
+	_, err := fit.DecodeBytes(data[:len(data)-10])
+	assert.Error(t, err)
+}
@@ -0,0 +1,289 @@
+package fit
+
+import (
+	"time"
+)
+
+// Global message numbers from the FIT profile
+const (
+	MesgNumFileID           uint16 = 0
+	MesgNumSession          uint16 = 18
+	MesgNumLap              uint16 = 19
+	MesgNumRecord           uint16 = 20
+	MesgNumEvent            uint16 = 21
+	MesgNumDeviceInfo       uint16 = 23
+	MesgNumActivity         uint16 = 34
+	MesgNumFieldDescription uint16 = 206
+	MesgNumDeveloperDataID  uint16 = 207
+)
+
+// fieldTimestamp is the field number of the timestamp in every message that has one
+const fieldTimestamp = 253
+
+// fitEpoch is the FIT time origin, 1989-12-31T00:00:00Z
+var fitEpoch = time.Date(1989, 12, 31, 0, 0, 0, 0, time.UTC)
+
+// semicirclesToDegrees converts FIT position units to degrees
+const semicirclesToDegrees = 180.0 / (1 << 31)
+
+// FileID is the file_id message identifying the file and the device that created it
+type FileID struct {
+	Type         uint8     `json:"type"`
+	Manufacturer uint16    `json:"manufacturer"`
+	Product      uint16    `json:"product"`
+	SerialNumber uint32    `json:"serialNumber,omitempty"`
+	TimeCreated  time.Time `json:"timeCreated"`
+}
+
+// Session is a session message summarizing an activity. Zero values mean the
+// field was not recorded.
+type Session struct {
+	Timestamp               time.Time `json:"timestamp"`
+	StartTime               time.Time `json:"startTime"`
+	Sport                   uint8     `json:"sport"`
+	SubSport                uint8     `json:"subSport"`
+	TotalElapsedTime        float64   `json:"totalElapsedTime"` // s
+	TotalTimerTime          float64   `json:"totalTimerTime"`   // s
+	TotalDistance           float64   `json:"totalDistance"`    // m
+	TotalCalories           uint16    `json:"totalCalories"`    // kcal
+	AvgSpeed                float64   `json:"avgSpeed"`         // m/s
+	MaxSpeed                float64   `json:"maxSpeed"`         // m/s
+	AvgHeartRate            uint8     `json:"avgHeartRate"`     // bpm
+	MaxHeartRate            uint8     `json:"maxHeartRate"`     // bpm
+	AvgCadence              uint8     `json:"avgCadence"`       // rpm
+	MaxCadence              uint8     `json:"maxCadence"`       // rpm
+	AvgPower                uint16    `json:"avgPower"`         // W
+	MaxPower                uint16    `json:"maxPower"`         // W
+	TotalAscent             uint16    `json:"totalAscent"`      // m
+	TotalDescent            uint16    `json:"totalDescent"`     // m
+	NumLaps                 uint16    `json:"numLaps"`
+	TotalTrainingEffect     float64   `json:"totalTrainingEffect"`
+	AnaerobicTrainingEffect float64   `json:"anaerobicTrainingEffect"`
+}
+
+// Lap is a lap message. Zero values mean the field was not recorded.
+type Lap struct {
+	Timestamp        time.Time `json:"timestamp"`
+	StartTime        time.Time `json:"startTime"`
+	StartLatitude    *float64  `json:"startLatitude,omitempty"`  // degrees
+	StartLongitude   *float64  `json:"startLongitude,omitempty"` // degrees
+	TotalElapsedTime float64   `json:"totalElapsedTime"`         // s
+	TotalTimerTime   float64   `json:"totalTimerTime"`           // s
+	TotalDistance    float64   `json:"totalDistance"`            // m
+	TotalCalories    uint16    `json:"totalCalories"`            // kcal
+	AvgSpeed         float64   `json:"avgSpeed"`                 // m/s
+	MaxSpeed         float64   `json:"maxSpeed"`                 // m/s
+	AvgHeartRate     uint8     `json:"avgHeartRate"`             // bpm
+	MaxHeartRate     uint8     `json:"maxHeartRate"`             // bpm
+	AvgCadence       uint8     `json:"avgCadence"`               // rpm
+	MaxCadence       uint8     `json:"maxCadence"`               // rpm
+	AvgPower         uint16    `json:"avgPower"`                 // W
+	MaxPower         uint16    `json:"maxPower"`                 // W
+	TotalAscent      uint16    `json:"totalAscent"`              // m
+	TotalDescent     uint16    `json:"totalDescent"`             // m
+}
+
+// Record is a record message holding one sample of an activity stream.
+// Fields the device did not record are nil.
+type Record struct {
+	Timestamp       time.Time        `json:"timestamp"`
+	Latitude        *float64         `json:"latitude"`    // degrees
+	Longitude       *float64         `json:"longitude"`   // degrees
+	Altitude        *float64         `json:"altitude"`    // m
+	HeartRate       *uint8           `json:"heartRate"`   // bpm
+	Cadence         *float64         `json:"cadence"`     // rpm, including fractional cadence
+	Distance        *float64         `json:"distance"`    // m
+	Speed           *float64         `json:"speed"`       // m/s
+	Power           *uint16          `json:"power"`       // W
+	Temperature     *int8            `json:"temperature"` // °C
+	DeveloperFields []DeveloperField `json:"developerFields,omitempty"`
+}
+
+// Event is an event message such as a timer start or stop
+type Event struct {
+	Timestamp  time.Time `json:"timestamp"`
+	Event      uint8     `json:"event"`
+	EventType  uint8     `json:"eventType"`
+	Data       uint32    `json:"data"`
+	EventGroup uint8     `json:"eventGroup"`
+}
+
+// DeviceInfo is a device_info message describing the recording device or a sensor
+type DeviceInfo struct {
+	Timestamp       time.Time `json:"timestamp"`
+	DeviceIndex     uint8     `json:"deviceIndex"`
+	DeviceType      uint8     `json:"deviceType"`
+	Manufacturer    uint16    `json:"manufacturer"`
+	SerialNumber    uint32    `json:"serialNumber,omitempty"`
+	Product         uint16    `json:"product"`
+	ProductName     string    `json:"productName,omitempty"`
+	SoftwareVersion float64   `json:"softwareVersion"`
+	HardwareVersion uint8     `json:"hardwareVersion"`
+	BatteryVoltage  float64   `json:"batteryVoltage,omitempty"` // V
+	BatteryStatus   uint8     `json:"batteryStatus,omitempty"`
+}
+
+// add stores a decoded message and its typed form, if it has one
+func (f *File) add(m Message) {
+	f.Messages = append(f.Messages, m)
+
+	switch m.Num {
+	case MesgNumFileID:
+		if f.FileID == nil {
+			f.FileID = &FileID{
+				Type:         uint8(m.uintOr(0)),
+				Manufacturer: uint16(m.uintOr(1)),
+				Product:      uint16(m.uintOr(2)),
+				SerialNumber: uint32(m.uintOr(3)),
+				TimeCreated:  m.time(4),
+			}
+		}
+	case MesgNumSession:
+		f.Sessions = append(f.Sessions, Session{
+			Timestamp:               m.time(fieldTimestamp),
+			StartTime:               m.time(2),
+			Sport:                   uint8(m.uintOr(5)),
+			SubSport:                uint8(m.uintOr(6)),
+			TotalElapsedTime:        m.scaled(7, 1000),
+			TotalTimerTime:          m.scaled(8, 1000),
+			TotalDistance:           m.scaled(9, 100),
+			TotalCalories:           uint16(m.uintOr(11)),
+			AvgSpeed:                m.enhanced(124, 14, 1000),
+			MaxSpeed:                m.enhanced(125, 15, 1000),
+			AvgHeartRate:            uint8(m.uintOr(16)),
+			MaxHeartRate:            uint8(m.uintOr(17)),
+			AvgCadence:              uint8(m.uintOr(18)),
+			MaxCadence:              uint8(m.uintOr(19)),
+			AvgPower:                uint16(m.uintOr(20)),
+			MaxPower:                uint16(m.uintOr(21)),
+			TotalAscent:             uint16(m.uintOr(22)),
+			TotalDescent:            uint16(m.uintOr(23)),
+			NumLaps:                 uint16(m.uintOr(26)),
+			TotalTrainingEffect:     m.scaled(24, 10),
+			AnaerobicTrainingEffect: m.scaled(137, 10),
+		})
+	case MesgNumLap:
+		f.Laps = append(f.Laps, Lap{
+			Timestamp:        m.time(fieldTimestamp),
+			StartTime:        m.time(2),
+			StartLatitude:    m.position(3),
+			StartLongitude:   m.position(4),
+			TotalElapsedTime: m.scaled(7, 1000),
+			TotalTimerTime:   m.scaled(8, 1000),
+			TotalDistance:    m.scaled(9, 100),
+			TotalCalories:    uint16(m.uintOr(11)),
+			AvgSpeed:         m.enhanced(110, 13, 1000),
+			MaxSpeed:         m.enhanced(111, 14, 1000),
+			AvgHeartRate:     uint8(m.uintOr(15)),
+			MaxHeartRate:     uint8(m.uintOr(16)),
+			AvgCadence:       uint8(m.uintOr(17)),
+			MaxCadence:       uint8(m.uintOr(18)),
+			AvgPower:         uint16(m.uintOr(19)),
+			MaxPower:         uint16(m.uintOr(20)),
+			TotalAscent:      uint16(m.uintOr(21)),
+			TotalDescent:     uint16(m.uintOr(22)),
+		})
+	case MesgNumRecord:
+		rec := Record{
+			Timestamp:       m.time(fieldTimestamp),
+			Latitude:        m.position(0),
+			Longitude:       m.position(1),
+			Distance:        m.optional(5, 100, 0),
+			DeveloperFields: m.DeveloperFields,
+		}
+		rec.Altitude = m.optional(78, 5, 500)
+		if rec.Altitude == nil {
+			rec.Altitude = m.optional(2, 5, 500)
+		}
+		rec.Speed = m.optional(73, 1000, 0)
+		if rec.Speed == nil {
+			rec.Speed = m.optional(6, 1000, 0)
+		}
+		if v, ok := m.Uint(3); ok {
+			hr := uint8(v)
+			rec.HeartRate = &hr
+		}
+		if cadence := m.optional(4, 1, 0); cadence != nil {
+			if fraction, ok := m.Float(53); ok {
+				*cadence += fraction / 128
+			}
+			rec.Cadence = cadence
+		}
+		if v, ok := m.Uint(7); ok {
+			power := uint16(v)
+			rec.Power = &power
+		}
+		if v, ok := m.Int(13); ok {
+			temp := int8(v)
+			rec.Temperature = &temp
+		}
+		f.Records = append(f.Records, rec)
+	case MesgNumEvent:
+		f.Events = append(f.Events, Event{
+			Timestamp:  m.time(fieldTimestamp),
+			Event:      uint8(m.uintOr(0)),
+			EventType:  uint8(m.uintOr(1)),
+			Data:       uint32(m.uintOr(3)),
+			EventGroup: uint8(m.uintOr(4)),
+		})
+	case MesgNumDeviceInfo:
+		f.DeviceInfos = append(f.DeviceInfos, DeviceInfo{
+			Timestamp:       m.time(fieldTimestamp),
+			DeviceIndex:     uint8(m.uintOr(0)),
+			DeviceType:      uint8(m.uintOr(1)),
+			Manufacturer:    uint16(m.uintOr(2)),
+			SerialNumber:    uint32(m.uintOr(3)),
+			Product:         uint16(m.uintOr(4)),
+			SoftwareVersion: m.scaled(5, 100),
+			HardwareVersion: uint8(m.uintOr(6)),
+			BatteryVoltage:  m.scaled(10, 256),
+			BatteryStatus:   uint8(m.uintOr(11)),
+			ProductName:     m.String(27),
+		})
+	}
+}
+
+func (m *Message) uintOr(num byte) uint64 {
+	v, _ := m.Uint(num)
+	return v
+}
+
+func (m *Message) time(num byte) time.Time {
+	v, ok := m.Uint(num)
+	if !ok {
+		return time.Time{}
+	}
+	return fitEpoch.Add(time.Duration(v) * time.Second)
+}
+
+func (m *Message) scaled(num byte, scale float64) float64 {
+	v, _ := m.Float(num)
+	return v / scale
+}
+
+// enhanced returns the enhanced (32-bit) variant of a field when present and
+// falls back to the legacy 16-bit field
+func (m *Message) enhanced(enhancedNum, legacyNum byte, scale float64) float64 {
+	if v, ok := m.Float(enhancedNum); ok {
+		return v / scale
+	}
+	return m.scaled(legacyNum, scale)
+}
+
+func (m *Message) optional(num byte, scale, offset float64) *float64 {
+	v, ok := m.Float(num)
+	if !ok {
+		return nil
+	}
+	v = v/scale - offset
+	return &v
+}
+
+func (m *Message) position(num byte) *float64 {
+	v, ok := m.Int(num)
+	if !ok {
+		return nil
+	}
+	deg := float64(v) * semicirclesToDegrees
+	return &deg
+}
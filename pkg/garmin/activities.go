@@ -6,32 +6,79 @@ import (
 
 // ActivityOptions for filtering activity lists
 type ActivityOptions struct {
-	Limit      int
-	Offset     int
+	Limit        int
+	Offset       int
 	ActivityType string
-	DateFrom   time.Time
-	DateTo     time.Time
+	DateFrom     time.Time
+	DateTo       time.Time
+	Search       string // Free-text match on activity names
+	// Multisport controls how ListActivities returns multisport activities:
+	// as the parent only (default), grouped or flattened into their legs
+	Multisport MultisportMode
 }
 
 // ActivityDetail represents detailed information for an activity
 type ActivityDetail struct {
-	Activity // Embed garmin.Activity from pkg/garmin/types.go
-	Description string `json:"description"`	// Add more fields as needed
+	Activity                      // Embed garmin.Activity from pkg/garmin/types.go
+	Description    string         `json:"description"`
+	LocationName   string         `json:"locationName,omitempty"`
+	Device         ActivityDevice `json:"device"`
+	HeartRate      Metric         `json:"heartRate"`
+	Power          Metric         `json:"power"`
+	Cadence        Metric         `json:"cadence"`
+	Speed          Metric         `json:"speed"`
+	TrainingEffect TrainingEffect `json:"trainingEffect"`
+	Laps           []Lap          `json:"laps"`
+	Weather        *Weather       `json:"weather,omitempty"`
+
+	// Leg names a leg of a multisport activity, e.g. "swim" or "T1"
+	Leg string `json:"leg,omitempty"`
+	// Children holds the legs of a multisport activity, in order
+	Children []ActivityDetail `json:"children,omitempty"`
+}
+
+// ActivityDevice identifies the device that recorded an activity
+type ActivityDevice struct {
+	DeviceID     string `json:"deviceId,omitempty"`
+	Manufacturer string `json:"manufacturer,omitempty"`
+	DeviceTypePK int64  `json:"deviceTypePk,omitempty"`
+}
+
+// TrainingEffect summarizes the training effect of an activity
+type TrainingEffect struct {
+	Aerobic   float64 `json:"aerobic"`
+	Anaerobic float64 `json:"anaerobic"`
+	Label     string  `json:"label,omitempty"`
+	Load      float64 `json:"load"`
 }
 
 // Lap represents a lap in an activity
 type Lap struct {
-	// Define lap fields
+	Index          int       `json:"index"`
+	StartTime      time.Time `json:"startTime"`
+	Distance       float64   `json:"distance"`
+	Duration       float64   `json:"duration"`
+	MovingDuration float64   `json:"movingDuration"`
+	ElevationGain  float64   `json:"elevationGain"`
+	ElevationLoss  float64   `json:"elevationLoss"`
+	Calories       float64   `json:"calories"`
+	HeartRate      Metric    `json:"heartRate"`
+	Power          Metric    `json:"power"`
+	Cadence        Metric    `json:"cadence"`
+	Speed          Metric    `json:"speed"`
 }
 
-// Metric represents a metric in an activity
+// Metric represents a metric in an activity. Zero values mean the metric
+// was not recorded.
 type Metric struct {
-	// Define metric fields
+	Average float64 `json:"average"`
+	Max     float64 `json:"max"`
+	Min     float64 `json:"min,omitempty"`
 }
 
 // DownloadOptions for downloading activity data
 type DownloadOptions struct {
-	Format    string // "gpx", "tcx", "fit", "csv"
+	Format    string // "gpx", "tcx", "kml", "fit", "csv"
 	Original  bool   // Download original uploaded file
 	OutputDir string
 	Filename  string
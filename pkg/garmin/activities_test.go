@@ -0,0 +1,233 @@
+package garmin_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+// newActivityServer serves total activities with sequential IDs, honouring
+// the start and limit search parameters, and records every query it receives
+func newActivityServer(t *testing.T, total int, queries *[]url.Values) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		*queries = append(*queries, q)
+		start, _ := strconv.Atoi(q.Get("start"))
+		limit, _ := strconv.Atoi(q.Get("limit"))
+
+		page := []map[string]interface{}{}
+		for i := start; i < total && i < start+limit; i++ {
+			page = append(page, map[string]interface{}{
+				"activityId":   i + 1,
+				"activityName": fmt.Sprintf("Activity %d", i+1),
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(page))
+	}))
+}
+
+func newTestClient(t *testing.T, server *httptest.Server) *garmin.Client {
+	u, _ := url.Parse(server.URL)
+	c, err := garmin.NewClient(u.Host)
+	require.NoError(t, err)
+	c.Client.Domain = u.Host
+	c.Client.AuthToken = "Bearer testtoken"
+	return c
+}
+
+func TestListActivities_PaginatesFullHistory(t *testing.T) {
+	var queries []url.Values
+	server := newActivityServer(t, 250, &queries)
+	defer server.Close()
+
+	activities, err := newTestClient(t, server).ListActivities(garmin.ActivityOptions{})
+	require.NoError(t, err)
+	require.Len(t, activities, 250)
+	assert.Equal(t, int64(1), activities[0].ActivityID)
+	assert.Equal(t, int64(250), activities[249].ActivityID)
+
+	require.Len(t, queries, 3)
+	assert.Equal(t, "0", queries[0].Get("start"))
+	assert.Equal(t, "100", queries[1].Get("start"))
+	assert.Equal(t, "200", queries[2].Get("start"))
+}
+
+func TestListActivities_OptionsAndLimit(t *testing.T) {
+	var queries []url.Values
+	server := newActivityServer(t, 500, &queries)
+	defer server.Close()
+
+	opts := garmin.ActivityOptions{
+		Limit:        150,
+		Offset:       10,
+		ActivityType: "running",
+		DateFrom:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		DateTo:       time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC),
+	}
+	activities, err := newTestClient(t, server).ListActivities(opts)
+	require.NoError(t, err)
+	require.Len(t, activities, 150)
+	assert.Equal(t, int64(11), activities[0].ActivityID)
+
+	require.Len(t, queries, 2)
+	assert.Equal(t, "10", queries[0].Get("start"))
+	assert.Equal(t, "100", queries[0].Get("limit"))
+	assert.Equal(t, "110", queries[1].Get("start"))
+	assert.Equal(t, "50", queries[1].Get("limit"))
+	for _, q := range queries {
+		assert.Equal(t, "running", q.Get("activityType"))
+		assert.Equal(t, "2024-01-01", q.Get("startDate"))
+		assert.Equal(t, "2024-06-30", q.Get("endDate"))
+	}
+}
+
+func TestActivities_StopsFetchingWhenConsumerBreaks(t *testing.T) {
+	var queries []url.Values
+	server := newActivityServer(t, 1000, &queries)
+	defer server.Close()
+
+	var ids []int64
+	for activity, err := range newTestClient(t, server).Activities(context.Background(), garmin.ActivityOptions{}) {
+		require.NoError(t, err)
+		ids = append(ids, activity.ActivityID)
+		if len(ids) == 120 {
+			break
+		}
+	}
+
+	assert.Len(t, ids, 120)
+	assert.Len(t, queries, 2, "only the pages needed by the consumer should be fetched")
+}
+
+func TestActivities_CancelledContext(t *testing.T) {
+	var queries []url.Values
+	server := newActivityServer(t, 10, &queries)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var errs []error
+	for _, err := range newTestClient(t, server).Activities(ctx, garmin.ActivityOptions{}) {
+		errs = append(errs, err)
+	}
+
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], context.Canceled)
+	assert.Empty(t, queries)
+}
+
+func TestGetActivity_DetailsAndLaps(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/activity-service/activity/42":
+			w.Write([]byte(`{
+				"activityId": 42,
+				"activityName": "Tempo Run",
+				"description": "Felt good",
+				"activityTypeDTO": {"typeId": 1, "typeKey": "running"},
+				"summaryDTO": {
+					"startTimeLocal": "2024-03-01T07:00:00.0",
+					"distance": 10000, "duration": 2700,
+					"averageHR": 155, "maxHR": 172, "minHR": 98,
+					"averagePower": 280, "maxPower": 410,
+					"averageRunCadence": 172, "maxRunCadence": 184,
+					"trainingEffect": 3.4, "anaerobicTrainingEffect": 1.2,
+					"trainingEffectLabel": "TEMPO"
+				},
+				"metadataDTO": {
+					"manufacturer": "GARMIN",
+					"deviceMetaDataDTO": {"deviceId": 3442978877, "deviceTypePk": 36089}
+				}
+			}`))
+		case "/activity-service/activity/42/splits":
+			w.Write([]byte(`{"activityId": 42, "lapDTOs": [
+				{"lapIndex": 1, "distance": 5000, "duration": 1360, "averageHR": 150, "averageRunCadence": 170},
+				{"lapIndex": 2, "distance": 5000, "duration": 1340, "averageHR": 160, "averageBikeCadence": 0}
+			]}`))
+		case "/activity-service/activity/42/weather":
+			w.Write([]byte(`{"temp": 50, "windDirectionCompassPoint": "ssw"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	detail, err := newTestClient(t, server).GetActivity(42)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(42), detail.ActivityID)
+	assert.Equal(t, "Tempo Run", detail.ActivityName)
+	assert.Equal(t, "running", detail.ActivityType.TypeKey)
+	assert.Equal(t, "Felt good", detail.Description)
+	assert.Equal(t, 2024, detail.StartTimeLocal.Year())
+	assert.Equal(t, "3442978877", detail.Device.DeviceID)
+	assert.Equal(t, "GARMIN", detail.Device.Manufacturer)
+	assert.Equal(t, garmin.Metric{Average: 155, Max: 172, Min: 98}, detail.HeartRate)
+	assert.Equal(t, garmin.Metric{Average: 172, Max: 184}, detail.Cadence)
+	assert.Equal(t, 280.0, detail.Power.Average)
+	assert.Equal(t, 3.4, detail.TrainingEffect.Aerobic)
+	assert.Equal(t, "TEMPO", detail.TrainingEffect.Label)
+
+	require.Len(t, detail.Laps, 2)
+	assert.Equal(t, 1, detail.Laps[0].Index)
+	assert.Equal(t, 170.0, detail.Laps[0].Cadence.Average)
+	assert.Equal(t, 160.0, detail.Laps[1].HeartRate.Average)
+
+	require.NotNil(t, detail.Weather)
+	assert.Equal(t, "SSW", detail.Weather.WindCompass)
+}
+
+func TestGetActivity_WeatherIsBestEffort(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/activity-service/activity/42":
+			w.Write([]byte(`{"activityId": 42, "activityName": "Tempo Run"}`))
+		case "/activity-service/activity/42/splits":
+			w.Write([]byte(`{"activityId": 42, "lapDTOs": []}`))
+		default:
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	detail, err := newTestClient(t, server).GetActivity(42)
+	require.NoError(t, err)
+	assert.Equal(t, "Tempo Run", detail.ActivityName)
+	assert.Nil(t, detail.Weather)
+}
+
+func TestGetActivitySummary_SkipsLapsAndWeather(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/activity-service/activity/42" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"activityId": 42, "activityName": "Tempo Run",
+			"activityTypeDTO": {"typeId": 1, "typeKey": "running"},
+			"summaryDTO": {"distance": 10000, "duration": 2700}}`))
+	}))
+	defer server.Close()
+
+	activity, err := newTestClient(t, server).GetActivitySummary(42)
+	require.NoError(t, err)
+	assert.Equal(t, "Tempo Run", activity.ActivityName)
+	assert.Equal(t, "running", activity.ActivityType.TypeKey)
+	assert.Equal(t, 10000.0, activity.Distance)
+}
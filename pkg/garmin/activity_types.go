@@ -0,0 +1,71 @@
+package garmin
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var (
+	// activityTypesMu guards activityTypes, which LoadActivityTypes extends
+	activityTypesMu     sync.RWMutex
+	activityTypesLoaded bool
+)
+
+// LoadActivityTypes adds every activity type offered by Garmin Connect, such
+// as yoga or indoor_rowing, to the common types accepted by validation. The
+// list is fetched once and kept for the life of the process.
+func (c *Client) LoadActivityTypes() error {
+	activityTypesMu.RLock()
+	loaded := activityTypesLoaded
+	activityTypesMu.RUnlock()
+	if loaded {
+		return nil
+	}
+
+	list, err := c.GetActivityTypes()
+	if err != nil {
+		return fmt.Errorf("failed to load activity types: %w", err)
+	}
+
+	activityTypesMu.Lock()
+	defer activityTypesMu.Unlock()
+	for _, activityType := range list {
+		if activityType.TypeKey != "" {
+			activityTypes[activityType.TypeKey] = activityType.TypeID
+		}
+	}
+	activityTypesLoaded = true
+	return nil
+}
+
+// lookupActivityType returns the ID of an activity type key
+func lookupActivityType(key string) (int, bool) {
+	activityTypesMu.RLock()
+	defer activityTypesMu.RUnlock()
+	id, ok := activityTypes[key]
+	return id, ok
+}
+
+// ensureActivityType loads the activity types when key is not one of the
+// types already known, so that only uncommon types cost a request
+func (c *Client) ensureActivityType(key string) error {
+	if key == "" {
+		return nil
+	}
+	if _, ok := lookupActivityType(strings.ToLower(key)); ok {
+		return nil
+	}
+	return c.LoadActivityTypes()
+}
+
+// ActivityTypeKey returns the key of an activity type ID like the package
+// function, loading the full list of types when the ID is not already known.
+// A failure to load them only leaves the key empty.
+func (c *Client) ActivityTypeKey(id int) string {
+	if key := ActivityTypeKey(id); key != "" {
+		return key
+	}
+	_ = c.LoadActivityTypes()
+	return ActivityTypeKey(id)
+}
@@ -0,0 +1,61 @@
+package garmin_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+func TestCreateManualActivity_LoadsUncommonTypes(t *testing.T) {
+	var typeRequests int
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/activity-service/activity/activityTypes":
+			typeRequests++
+			w.Write([]byte(`[
+				{"typeId": 1, "typeKey": "running", "parentTypeId": 17},
+				{"typeId": 9901, "typeKey": "yoga", "parentTypeId": 29},
+				{"typeId": 9902, "typeKey": "indoor_rowing", "parentTypeId": 29}
+			]`))
+		case "/activity-service/activity":
+			data, _ := io.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(data, &body))
+			w.Write([]byte(`{"activityId": 99}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	client := newTestClient(t, server)
+
+	activity := garmin.ManualActivity{
+		Type:     "yoga",
+		Start:    time.Date(2024, 5, 1, 18, 30, 0, 0, time.UTC),
+		Duration: time.Hour,
+	}
+	_, err := client.CreateManualActivity(activity)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"typeId": 9901.0, "typeKey": "yoga"}, body["activityTypeDTO"])
+
+	// The list is fetched once and then used by the package functions
+	activity.Type = "indoor_rowing"
+	_, err = client.CreateManualActivity(activity)
+	require.NoError(t, err)
+	assert.Equal(t, 1, typeRequests)
+	assert.Equal(t, "indoor_rowing", garmin.ActivityTypeKey(9902))
+	assert.Contains(t, garmin.ActivityTypeKeys(), "yoga")
+
+	activity.Type = "underwater_hockey"
+	_, err = client.CreateManualActivity(activity)
+	assert.ErrorContains(t, err, `unknown activity type "underwater_hockey"`)
+}
@@ -0,0 +1,183 @@
+package garmin
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CalendarKind is the kind of a calendar entry
+type CalendarKind string
+
+const (
+	CalendarEvent    CalendarKind = "event"
+	CalendarWorkout  CalendarKind = "workout"
+	CalendarActivity CalendarKind = "activity"
+	CalendarNote     CalendarKind = "note"
+	CalendarOther    CalendarKind = "other"
+)
+
+// calendarKindOrder orders the entries of a day: what is planned first, then
+// what was done
+var calendarKindOrder = map[CalendarKind]int{
+	CalendarEvent:    0,
+	CalendarWorkout:  1,
+	CalendarActivity: 2,
+	CalendarNote:     3,
+	CalendarOther:    4,
+}
+
+// CalendarEntry is an item on the training calendar: a scheduled workout, a
+// completed activity, a race or other event, or a note
+type CalendarEntry struct {
+	Date         time.Time    `json:"date"`
+	Kind         CalendarKind `json:"kind"`
+	ID           int64        `json:"id"`
+	Title        string       `json:"title"`
+	ActivityType string       `json:"activityType,omitempty"`
+	Start        *time.Time   `json:"start,omitempty"`
+	Seconds      float64      `json:"seconds,omitempty"`
+	Distance     float64      `json:"distance,omitempty"` // meters
+	Calories     float64      `json:"calories,omitempty"`
+	WorkoutID    int64        `json:"workoutId,omitempty"`
+	Race         bool         `json:"race,omitempty"`
+	Location     string       `json:"location,omitempty"`
+}
+
+// Calendar holds the calendar entries from From to To, both inclusive,
+// ordered by date
+type Calendar struct {
+	From    time.Time       `json:"from"`
+	To      time.Time       `json:"to"`
+	Entries []CalendarEntry `json:"entries"`
+}
+
+// Day returns the entries on a date
+func (c *Calendar) Day(date time.Time) []CalendarEntry {
+	var entries []CalendarEntry
+	for _, entry := range c.Entries {
+		if sameDay(entry.Date, date) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// GetMonthCalendar retrieves the calendar of a month
+func (c *Client) GetMonthCalendar(year int, month time.Month) (*Calendar, error) {
+	// The calendar service counts months from 0
+	items, err := c.GetCalendarMonth(year, int(month)-1)
+	if err != nil {
+		return nil, err
+	}
+	from := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	return c.buildCalendar(items, from, from.AddDate(0, 1, -1))
+}
+
+// GetWeekCalendar retrieves the calendar of the week, Monday to Sunday,
+// containing day
+func (c *Client) GetWeekCalendar(day time.Time) (*Calendar, error) {
+	from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	from = from.AddDate(0, 0, -((int(from.Weekday()) + 6) % 7))
+	items, err := c.GetCalendarWeek(from.Year(), int(from.Month())-1, from.Day(), 2)
+	if err != nil {
+		return nil, err
+	}
+	return c.buildCalendar(items, from, from.AddDate(0, 0, 6))
+}
+
+// buildCalendar converts calendar items into entries, keeping those between
+// from and to
+func (c *Client) buildCalendar(month *CalendarMonth, from, to time.Time) (*Calendar, error) {
+	calendar := &Calendar{From: from, To: to, Entries: []CalendarEntry{}}
+	if month == nil {
+		return calendar, nil
+	}
+	for _, item := range month.CalendarItems {
+		entry, err := c.calendarEntry(item)
+		if err != nil {
+			return nil, err
+		}
+		if entry.Date.Before(from) || entry.Date.After(to) {
+			continue
+		}
+		calendar.Entries = append(calendar.Entries, entry)
+	}
+	sort.SliceStable(calendar.Entries, func(i, j int) bool {
+		a, b := calendar.Entries[i], calendar.Entries[j]
+		if !a.Date.Equal(b.Date) {
+			return a.Date.Before(b.Date)
+		}
+		if calendarKindOrder[a.Kind] != calendarKindOrder[b.Kind] {
+			return calendarKindOrder[a.Kind] < calendarKindOrder[b.Kind]
+		}
+		if a.Start != nil && b.Start != nil {
+			return a.Start.Before(*b.Start)
+		}
+		return false
+	})
+	return calendar, nil
+}
+
+func (c *Client) calendarEntry(item CalendarItem) (CalendarEntry, error) {
+	date, err := time.Parse("2006-01-02", item.Date)
+	if err != nil {
+		return CalendarEntry{}, fmt.Errorf("invalid calendar item date %q: %w", item.Date, err)
+	}
+
+	entry := CalendarEntry{
+		Date:  date,
+		Kind:  calendarKind(item.ItemType),
+		ID:    item.ID,
+		Title: item.Title,
+		Race:  item.IsRace,
+	}
+	if item.ActivityTypeID != nil {
+		entry.ActivityType = c.ActivityTypeKey(*item.ActivityTypeID)
+	}
+	if item.StartTimestampLocal != nil {
+		for _, layout := range []string{"2006-01-02T15:04:05.0", "2006-01-02T15:04:05"} {
+			if start, err := time.Parse(layout, *item.StartTimestampLocal); err == nil {
+				entry.Start = &start
+				break
+			}
+		}
+	}
+	if item.Duration != nil {
+		entry.Seconds = *item.Duration / 1000
+	}
+	if item.Distance != nil {
+		entry.Distance = *item.Distance
+	}
+	if item.Calories != nil {
+		entry.Calories = *item.Calories
+	}
+	if item.WorkoutID != nil {
+		entry.WorkoutID = *item.WorkoutID
+	}
+	if item.Location != nil {
+		entry.Location = *item.Location
+	}
+	return entry, nil
+}
+
+// calendarKind maps the item types of the calendar service to entry kinds
+func calendarKind(itemType string) CalendarKind {
+	switch strings.ToLower(itemType) {
+	case "activity":
+		return CalendarActivity
+	case "workout", "fbtadaptiveworkout":
+		return CalendarWorkout
+	case "event", "race":
+		return CalendarEvent
+	case "note":
+		return CalendarNote
+	default:
+		return CalendarOther
+	}
+}
+
+func sameDay(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
@@ -0,0 +1,71 @@
+package garmin_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+func TestGetMonthCalendar(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// October is month 9 for the calendar service
+		assert.Equal(t, "/calendar-service/year/2026/month/9", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"startDayOfMonth": 3, "numOfDaysInMonth": 31, "calendarItems": [
+			{"id": 3, "itemType": "activity", "activityTypeId": 1, "title": "Easy Run", "date": "2026-10-12",
+			 "duration": 1800000, "distance": 5000, "startTimestampLocal": "2026-10-12T07:30:00.0"},
+			{"id": 2, "itemType": "workout", "title": "5x1K", "date": "2026-10-12", "workoutId": 42},
+			{"id": 1, "itemType": "event", "title": "City Marathon", "date": "2026-10-25", "isRace": true},
+			{"id": 4, "itemType": "note", "title": "Sore calf", "date": "2026-10-13"},
+			{"id": 5, "itemType": "workout", "title": "Long Run", "date": "2026-11-01"}
+		]}`))
+	}))
+	defer server.Close()
+
+	calendar, err := newTestClient(t, server).GetMonthCalendar(2026, time.October)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), calendar.From)
+	assert.Equal(t, time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC), calendar.To)
+
+	// Entries outside the month are dropped; workouts come before activities
+	require.Len(t, calendar.Entries, 4)
+	assert.Equal(t, garmin.CalendarWorkout, calendar.Entries[0].Kind)
+	assert.Equal(t, int64(42), calendar.Entries[0].WorkoutID)
+
+	run := calendar.Entries[1]
+	assert.Equal(t, garmin.CalendarActivity, run.Kind)
+	assert.Equal(t, "running", run.ActivityType)
+	assert.Equal(t, 1800.0, run.Seconds)
+	require.NotNil(t, run.Start)
+	assert.Equal(t, 7, run.Start.Hour())
+
+	assert.Equal(t, garmin.CalendarNote, calendar.Entries[2].Kind)
+	assert.True(t, calendar.Entries[3].Race)
+
+	assert.Len(t, calendar.Day(time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)), 2)
+}
+
+func TestGetWeekCalendar(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Thursday 15 October is in the week starting Monday 12 October
+		assert.Equal(t, "/calendar-service/year/2026/month/9/day/12/start/2", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"calendarItems": [
+			{"id": 1, "itemType": "workout", "title": "Tempo", "date": "2026-10-11"},
+			{"id": 2, "itemType": "workout", "title": "Intervals", "date": "2026-10-14"}
+		]}`))
+	}))
+	defer server.Close()
+
+	calendar, err := newTestClient(t, server).GetWeekCalendar(time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC), calendar.To)
+	require.Len(t, calendar.Entries, 1)
+	assert.Equal(t, "Intervals", calendar.Entries[0].Title)
+}
@@ -1,15 +1,15 @@
 package garmin
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"iter"
 	"net/url"
-	"os"
-	"path/filepath"
+	"sort"
 	"time"
 
 	internalClient "github.com/sstent/go-garth/api/client"
-	"github.com/sstent/go-garth/errors"
 	types "github.com/sstent/go-garth/models/types"
 	shared "github.com/sstent/go-garth-cli/shared/interfaces"
 	models "github.com/sstent/go-garth-cli/shared/models"
@@ -80,91 +80,268 @@ func (c *Client) RefreshSession() error {
 	return c.Client.RefreshSession()
 }
 
-// ListActivities retrieves recent activities
+// activityPageSize is the number of activities requested per page when
+// ListActivities pages through the activity history
+const activityPageSize = 100
+
+// ListActivities retrieves activities matching opts. Results start at
+// opts.Offset and are fetched page by page until opts.Limit activities have
+// been collected; a Limit of zero returns every matching activity. The limit
+// counts multisport activities once, before opts.Multisport expands them.
 func (c *Client) ListActivities(opts ActivityOptions) ([]Activity, error) {
-	// TODO: Map ActivityOptions to internalClient.Client.GetActivities parameters
-	// For now, just call the internal client's GetActivities with a dummy limit
-	internalActivities, err := c.Client.GetActivities(opts.Limit)
-	if err != nil {
+	if err := validateMultisportMode(opts.Multisport); err != nil {
 		return nil, err
 	}
 
 	var garminActivities []Activity
-	for _, act := range internalActivities {
-		garminActivities = append(garminActivities, Activity{
-			ActivityID:     act.ActivityID,
-			ActivityName:   act.ActivityName,
-			ActivityType:   act.ActivityType,
-			StartTimeLocal: act.StartTimeLocal,
-			Distance:       act.Distance,
-			Duration:       act.Duration,
-		})
+	for activity, err := range c.Activities(context.Background(), opts) {
+		if err != nil {
+			return nil, err
+		}
+		garminActivities = append(garminActivities, activity)
 	}
-	return garminActivities, nil
+	return c.expandMultisport(garminActivities, opts.Multisport)
 }
 
-// GetActivity retrieves details for a specific activity ID
-func (c *Client) GetActivity(activityID int) (*ActivityDetail, error) {
-	// TODO: Implement internalClient.Client.GetActivity
-	return nil, fmt.Errorf("not implemented")
+// Activities returns an iterator over the activities matching opts. Pages are
+// fetched lazily as the consumer advances, so memory use stays bounded by the
+// page size, and no further requests are made once the consumer stops. A
+// failed request or a cancelled ctx is yielded as the final error.
+func (c *Client) Activities(ctx context.Context, opts ActivityOptions) iter.Seq2[Activity, error] {
+	return func(yield func(Activity, error) bool) {
+		filters := activityFilters(opts)
+		start := opts.Offset
+		count := 0
+		for {
+			pageSize := activityPageSize
+			if opts.Limit > 0 {
+				remaining := opts.Limit - count
+				if remaining <= 0 {
+					return
+				}
+				if remaining < pageSize {
+					pageSize = remaining
+				}
+			}
+
+			if err := ctx.Err(); err != nil {
+				yield(Activity{}, err)
+				return
+			}
+
+			internalActivities, err := c.Client.GetActivitiesPage(start, pageSize, filters)
+			if err != nil {
+				yield(Activity{}, err)
+				return
+			}
+
+			for _, act := range internalActivities {
+				count++
+				if !yield(act, nil) {
+					return
+				}
+			}
+
+			if len(internalActivities) < pageSize {
+				return
+			}
+			start += len(internalActivities)
+		}
+	}
 }
 
-// DownloadActivity downloads activity data
-func (c *Client) DownloadActivity(activityID int, opts DownloadOptions) error {
-	// TODO: Determine file extension based on format
-	fileExtension := opts.Format
-	if fileExtension == "csv" {
-		fileExtension = "csv"
-	} else if fileExtension == "gpx" {
-		fileExtension = "gpx"
-	} else if fileExtension == "tcx" {
-		fileExtension = "tcx"
-	} else {
-		return fmt.Errorf("unsupported download format: %s", opts.Format)
+// activityFilters maps ActivityOptions onto activitylist-service search parameters
+func activityFilters(opts ActivityOptions) url.Values {
+	filters := url.Values{}
+	if opts.ActivityType != "" {
+		filters.Set("activityType", opts.ActivityType)
+	}
+	if !opts.DateFrom.IsZero() {
+		filters.Set("startDate", opts.DateFrom.Format("2006-01-02"))
+	}
+	if !opts.DateTo.IsZero() {
+		filters.Set("endDate", opts.DateTo.Format("2006-01-02"))
+	}
+	if opts.Search != "" {
+		filters.Set("search", opts.Search)
+	}
+	return filters
+}
+
+// GetActivity retrieves details for a specific activity ID, including the
+// device, heart rate/power/cadence summaries, training effect, laps and the
+// weather. The legs of a multisport activity are retrieved as its children.
+// The weather is best-effort: it is left nil when it cannot be retrieved.
+func (c *Client) GetActivity(activityID int) (*ActivityDetail, error) {
+	detail, err := c.getActivityDetail(activityID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Construct filename
-	filename := fmt.Sprintf("%d.%s", activityID, fileExtension)
-	if opts.Filename != "" {
-		filename = opts.Filename
+	if weather, err := c.GetWeather(activityID); err == nil {
+		detail.Weather = weather
 	}
 
-	// Construct output path
-	outputPath := filename
-	if opts.OutputDir != "" {
-		outputPath = filepath.Join(opts.OutputDir, filename)
+	if detail.IsMultiSportParent {
+		for _, id := range detail.ChildIDs {
+			child, err := c.getActivityDetail(int(id))
+			if err != nil {
+				return nil, fmt.Errorf("failed to get leg %d of activity %d: %w", id, activityID, err)
+			}
+			parentID := detail.ActivityID
+			child.ParentID = &parentID
+			detail.Children = append(detail.Children, *child)
+		}
+		sort.SliceStable(detail.Children, func(i, j int) bool {
+			return detail.Children[i].StartTimeGMT.Before(detail.Children[j].StartTimeGMT.Time)
+		})
+		legs := make([]Activity, len(detail.Children))
+		for i, child := range detail.Children {
+			legs[i] = child.Activity
+		}
+		for i, name := range LegNames(legs) {
+			detail.Children[i].Leg = name
+		}
 	}
 
-	err := c.Client.Download(fmt.Sprintf("%d", activityID), opts.Format, outputPath)
+	return detail, nil
+}
+
+// GetActivitySummary retrieves the summary of a single activity, as it
+// appears in ListActivities, without its laps, weather or legs
+func (c *Client) GetActivitySummary(activityID int) (*Activity, error) {
+	details, err := c.Client.GetActivity(int64(activityID))
 	if err != nil {
-		return err
+		return nil, err
 	}
+	activity := activityFromDetails(details)
+	return &activity, nil
+}
 
-	// Basic validation: check if file is empty
-	fileInfo, err := os.Stat(outputPath)
+// getActivityDetail retrieves the details and laps of an activity
+func (c *Client) getActivityDetail(activityID int) (*ActivityDetail, error) {
+	details, err := c.Client.GetActivity(int64(activityID))
 	if err != nil {
-		return &errors.IOError{
-			GarthError: errors.GarthError{
-				Message: "Failed to get file info after download",
-				Cause:   err,
-			},
-		}
+		return nil, err
+	}
+
+	splits, err := c.Client.GetActivitySplits(int64(activityID))
+	if err != nil {
+		return nil, err
+	}
+
+	summary := details.SummaryDTO
+	device := details.MetadataDTO.DeviceMetaDataDTO
+	detail := &ActivityDetail{
+		Activity:     activityFromDetails(details),
+		Description:  details.Description,
+		LocationName: details.LocationName,
+		Device: ActivityDevice{
+			DeviceID:     device.DeviceID.String(),
+			Manufacturer: details.MetadataDTO.Manufacturer,
+			DeviceTypePK: device.DeviceTypePK,
+		},
+		HeartRate: Metric{Average: summary.AverageHR, Max: summary.MaxHR, Min: summary.MinHR},
+		Power:     Metric{Average: summary.AveragePower, Max: summary.MaxPower, Min: summary.MinPower},
+		Cadence: cadenceMetric(summary.AverageRunCadence, summary.MaxRunCadence,
+			summary.AverageBikeCadence, summary.MaxBikeCadence),
+		Speed: Metric{Average: summary.AverageSpeed, Max: summary.MaxSpeed},
+		TrainingEffect: TrainingEffect{
+			Aerobic:   summary.TrainingEffect,
+			Anaerobic: summary.AnaerobicTrainingEffect,
+			Label:     summary.TrainingEffectLabel,
+			Load:      summary.ActivityTrainingLoad,
+		},
 	}
-	if fileInfo.Size() == 0 {
-		return &errors.IOError{
-			GarthError: errors.GarthError{
-				Message: "Downloaded file is empty",
-			},
+
+	for i, lap := range splits.LapDTOs {
+		index := lap.LapIndex
+		if index == 0 {
+			index = i + 1
 		}
+		detail.Laps = append(detail.Laps, Lap{
+			Index:          index,
+			StartTime:      lap.StartTimeGMT.Time,
+			Distance:       lap.Distance,
+			Duration:       lap.Duration,
+			MovingDuration: lap.MovingDuration,
+			ElevationGain:  lap.ElevationGain,
+			ElevationLoss:  lap.ElevationLoss,
+			Calories:       lap.Calories,
+			HeartRate:      Metric{Average: lap.AverageHR, Max: lap.MaxHR},
+			Power:          Metric{Average: lap.AveragePower, Max: lap.MaxPower},
+			Cadence: cadenceMetric(lap.AverageRunCadence, lap.MaxRunCadence,
+				lap.AverageBikeCadence, lap.MaxBikeCadence),
+			Speed: Metric{Average: lap.AverageSpeed, Max: lap.MaxSpeed},
+		})
 	}
 
-	return nil
+	return detail, nil
+}
+
+// activityFromDetails converts an activity service record into an Activity
+func activityFromDetails(details *types.ActivityDetails) Activity {
+	summary := details.SummaryDTO
+	return Activity{
+		ActivityID:      details.ActivityID,
+		ActivityName:    details.ActivityName,
+		Description:     details.Description,
+		StartTimeLocal:  summary.StartTimeLocal,
+		StartTimeGMT:    summary.StartTimeGMT,
+		ActivityType:    details.ActivityTypeDTO,
+		EventType:       details.EventTypeDTO,
+		Distance:        summary.Distance,
+		Duration:        summary.Duration,
+		ElapsedDuration: summary.ElapsedDuration,
+		MovingDuration:  summary.MovingDuration,
+		ElevationGain:   summary.ElevationGain,
+		ElevationLoss:   summary.ElevationLoss,
+		AverageSpeed:    summary.AverageSpeed,
+		MaxSpeed:        summary.MaxSpeed,
+		Calories:        summary.Calories,
+		AverageHR:       summary.AverageHR,
+		MaxHR:           summary.MaxHR,
+
+		IsMultiSportParent: details.MetadataDTO.IsMultiSportParent,
+		ChildIDs:           details.MetadataDTO.ChildIDs,
+	}
 }
 
-// SearchActivities searches for activities by a query string
+// cadenceMetric picks running cadence when recorded and falls back to
+// cycling cadence otherwise
+func cadenceMetric(avgRun, maxRun, avgBike, maxBike float64) Metric {
+	if avgRun != 0 || maxRun != 0 {
+		return Metric{Average: avgRun, Max: maxRun}
+	}
+	return Metric{Average: avgBike, Max: maxBike}
+}
+
+// DownloadActivity downloads activity data. See DownloadActivityFiles for
+// the supported formats.
+func (c *Client) DownloadActivity(activityID int, opts DownloadOptions) error {
+	_, err := c.DownloadActivityFiles(activityID, opts)
+	return err
+}
+
+// SearchActivities searches for activities matching a query such as
+// `type:running distance>10km date:2024-05.. name~"tempo" hr_avg<150`.
+// See ActivityQuery for the query syntax.
 func (c *Client) SearchActivities(query string) ([]Activity, error) {
-	// TODO: Implement internalClient.Client.SearchActivities
-	return nil, fmt.Errorf("not implemented")
+	q, err := ParseActivityQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []Activity
+	for activity, err := range c.Activities(context.Background(), q.Options) {
+		if err != nil {
+			return nil, err
+		}
+		if q.Match(activity) {
+			matches = append(matches, activity)
+		}
+	}
+	return matches, nil
 }
 
 // GetSleepData retrieves sleep data for a specified date range
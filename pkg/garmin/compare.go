@@ -0,0 +1,353 @@
+package garmin
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Comparison alignments
+const (
+	AlignByDistance = "distance"
+	AlignByTime     = "time"
+)
+
+// CompareOptions controls how activities are aligned for comparison
+type CompareOptions struct {
+	// AlignBy is AlignByDistance (default) or AlignByTime
+	AlignBy string
+	// SplitLength is the split size in meters when aligning by distance
+	// (default 1000) or in seconds when aligning by time (default 300)
+	SplitLength float64
+	// Step is the resolution of the aligned series in meters (default 100)
+	// or seconds (default 10)
+	Step float64
+}
+
+// ComparedActivity is one activity of a comparison with its summary and
+// its differences from the first, baseline activity
+type ComparedActivity struct {
+	ActivityID     int64          `json:"activityId"`
+	Name           string         `json:"name"`
+	StartTime      time.Time      `json:"startTime"`
+	Summary        SegmentStats   `json:"summary"`
+	TrainingEffect TrainingEffect `json:"trainingEffect"`
+	Delta          *ActivityDelta `json:"delta,omitempty"`
+}
+
+// ActivityDelta is the difference of an activity's summary from the
+// baseline; positive values are higher than the baseline
+type ActivityDelta struct {
+	Seconds      float64 `json:"seconds"`
+	Distance     float64 `json:"distance"`
+	AverageSpeed float64 `json:"averageSpeed"`
+	// Pace is in seconds per kilometer, so a faster activity has a negative
+	// pace delta. It is nil when either activity has no speed.
+	Pace            *float64 `json:"pace,omitempty"`
+	AverageHR       float64  `json:"averageHR"`
+	AveragePower    float64  `json:"averagePower"`
+	AverageCadence  float64  `json:"averageCadence"`
+	ElevationGain   float64  `json:"elevationGain"`
+	ElevationLoss   float64  `json:"elevationLoss"`
+	AerobicEffect   float64  `json:"aerobicTrainingEffect"`
+	AnaerobicEffect float64  `json:"anaerobicTrainingEffect"`
+	TrainingLoad    float64  `json:"trainingLoad"`
+}
+
+// SplitComparison holds the same split of every compared activity, with
+// each split's difference from the baseline: the time taken when aligned by
+// distance, the distance covered when aligned by time. Activities that did
+// not reach the split have no entry for it.
+type SplitComparison struct {
+	Index  int             `json:"split"`
+	Splits []*SegmentStats `json:"splits"`
+	Deltas []*float64      `json:"deltas"` // seconds or meters versus the baseline split
+}
+
+// AlignedSeries holds an activity's samples resampled onto the comparison
+// axis. Elapsed and Gap are set when aligning by distance, Distance when
+// aligning by time.
+type AlignedSeries struct {
+	ActivityID int64  `json:"activityId"`
+	Elapsed    Series `json:"elapsed,omitempty"`  // seconds from the start
+	Gap        Series `json:"gap,omitempty"`      // seconds behind the baseline
+	Distance   Series `json:"distance,omitempty"` // meters from the start
+	HeartRate  Series `json:"heartRate"`
+	Power      Series `json:"power"`
+	Speed      Series `json:"speed"`
+}
+
+// Comparison compares several activities aligned by distance or time. Axis
+// and Series are meant for plotting: Series[i] holds the values of activity
+// i at each point of Axis.
+type Comparison struct {
+	AlignBy    string             `json:"alignBy"`
+	Activities []ComparedActivity `json:"activities"`
+	Splits     []SplitComparison  `json:"splits"`
+	Axis       []float64          `json:"axis"` // meters or seconds
+	Series     []AlignedSeries    `json:"series"`
+}
+
+// CompareActivities fetches the given activities and compares them; the
+// first activity is the baseline
+func (c *Client) CompareActivities(activityIDs []int, opts CompareOptions) (*Comparison, error) {
+	details := make([]*ActivityDetail, len(activityIDs))
+	samples := make([]*ActivitySamples, len(activityIDs))
+	for i, id := range activityIDs {
+		var err error
+		if details[i], err = c.GetActivity(id); err != nil {
+			return nil, fmt.Errorf("failed to get activity %d: %w", id, err)
+		}
+		if samples[i], err = c.GetActivitySamples(id); err != nil {
+			return nil, fmt.Errorf("failed to get samples of activity %d: %w", id, err)
+		}
+	}
+	return Compare(details, samples, opts)
+}
+
+// Compare compares activities from their details and samples, which must be
+// given in the same order; the first activity is the baseline
+func Compare(details []*ActivityDetail, samples []*ActivitySamples, opts CompareOptions) (*Comparison, error) {
+	if len(details) != len(samples) {
+		return nil, fmt.Errorf("got %d activity details for %d sample sets", len(details), len(samples))
+	}
+	if len(details) < 2 {
+		return nil, fmt.Errorf("at least two activities are needed for a comparison")
+	}
+	if opts.AlignBy == "" {
+		opts.AlignBy = AlignByDistance
+	}
+	switch opts.AlignBy {
+	case AlignByDistance:
+		if opts.SplitLength <= 0 {
+			opts.SplitLength = 1000
+		}
+		if opts.Step <= 0 {
+			opts.Step = 100
+		}
+	case AlignByTime:
+		if opts.SplitLength <= 0 {
+			opts.SplitLength = 300
+		}
+		if opts.Step <= 0 {
+			opts.Step = 10
+		}
+	default:
+		return nil, fmt.Errorf("unsupported alignment %q (use %s or %s)", opts.AlignBy, AlignByDistance, AlignByTime)
+	}
+
+	comparison := &Comparison{AlignBy: opts.AlignBy}
+
+	// Summaries and deltas against the baseline
+	for i, detail := range details {
+		activity := ComparedActivity{
+			ActivityID: detail.ActivityID,
+			Name:       detail.ActivityName,
+			StartTime:  detail.StartTimeLocal.Time,
+			Summary: SegmentStats{
+				Seconds:        detail.MovingDuration,
+				Distance:       detail.Distance,
+				AverageSpeed:   detail.Speed.Average,
+				AverageHR:      detail.HeartRate.Average,
+				AveragePower:   detail.Power.Average,
+				AverageCadence: detail.Cadence.Average,
+				ElevationGain:  detail.ElevationGain,
+				ElevationLoss:  detail.ElevationLoss,
+			},
+			TrainingEffect: detail.TrainingEffect,
+		}
+		if activity.Summary.Seconds == 0 {
+			activity.Summary.Seconds = detail.Duration
+		}
+		if i > 0 {
+			base := comparison.Activities[0]
+			activity.Delta = &ActivityDelta{
+				Seconds:         activity.Summary.Seconds - base.Summary.Seconds,
+				Distance:        activity.Summary.Distance - base.Summary.Distance,
+				AverageSpeed:    activity.Summary.AverageSpeed - base.Summary.AverageSpeed,
+				AverageHR:       activity.Summary.AverageHR - base.Summary.AverageHR,
+				AveragePower:    activity.Summary.AveragePower - base.Summary.AveragePower,
+				AverageCadence:  activity.Summary.AverageCadence - base.Summary.AverageCadence,
+				ElevationGain:   activity.Summary.ElevationGain - base.Summary.ElevationGain,
+				ElevationLoss:   activity.Summary.ElevationLoss - base.Summary.ElevationLoss,
+				AerobicEffect:   activity.TrainingEffect.Aerobic - base.TrainingEffect.Aerobic,
+				AnaerobicEffect: activity.TrainingEffect.Anaerobic - base.TrainingEffect.Anaerobic,
+				TrainingLoad:    activity.TrainingEffect.Load - base.TrainingEffect.Load,
+			}
+			if activity.Summary.AverageSpeed > 0 && base.Summary.AverageSpeed > 0 {
+				pace := 1000/activity.Summary.AverageSpeed - 1000/base.Summary.AverageSpeed
+				activity.Delta.Pace = &pace
+			}
+		}
+		comparison.Activities = append(comparison.Activities, activity)
+	}
+
+	// Per-split comparison
+	splits := make([][]Split, len(samples))
+	longest := 0
+	for i, s := range samples {
+		if opts.AlignBy == AlignByDistance {
+			splits[i] = Splits(s, opts.SplitLength)
+		} else {
+			splits[i] = timeSplits(s, time.Duration(opts.SplitLength*float64(time.Second)))
+		}
+		longest = max(longest, len(splits[i]))
+	}
+	for n := 0; n < longest; n++ {
+		split := SplitComparison{
+			Index:  n + 1,
+			Splits: make([]*SegmentStats, len(samples)),
+			Deltas: make([]*float64, len(samples)),
+		}
+		for i := range samples {
+			if n < len(splits[i]) {
+				split.Splits[i] = &splits[i][n].SegmentStats
+			}
+		}
+		if base := split.Splits[0]; base != nil {
+			for i := 1; i < len(samples); i++ {
+				if s := split.Splits[i]; s != nil {
+					delta := s.Seconds - base.Seconds
+					if opts.AlignBy == AlignByTime {
+						delta = s.Distance - base.Distance
+					}
+					split.Deltas[i] = &delta
+				}
+			}
+		}
+		comparison.Splits = append(comparison.Splits, split)
+	}
+
+	// Aligned series for plotting, up to the end of the longest activity
+	extent := 0.0
+	for _, s := range samples {
+		if opts.AlignBy == AlignByDistance {
+			extent = math.Max(extent, lastReading(s.Distance))
+		} else if s.Len() > 0 {
+			extent = math.Max(extent, s.Timestamps[s.Len()-1].Sub(s.Timestamps[0]).Seconds())
+		}
+	}
+	for x := 0.0; x <= extent; x += opts.Step {
+		comparison.Axis = append(comparison.Axis, x)
+	}
+	for _, s := range samples {
+		comparison.Series = append(comparison.Series, alignSamples(s, comparison.Axis, opts.AlignBy))
+	}
+	if opts.AlignBy == AlignByDistance && len(comparison.Series) > 0 {
+		base := comparison.Series[0].Elapsed
+		for i := range comparison.Series {
+			gap := make(Series, len(base))
+			for j := range gap {
+				gap[j] = comparison.Series[i].Elapsed[j] - base[j]
+			}
+			comparison.Series[i].Gap = gap
+		}
+	}
+	return comparison, nil
+}
+
+// timeSplits divides an activity into splits of equal elapsed time
+func timeSplits(samples *ActivitySamples, length time.Duration) []Split {
+	if length <= 0 || samples.Len() == 0 {
+		return nil
+	}
+
+	var splits []Split
+	var acc segmentAccumulator
+	start := samples.Timestamps[0]
+	boundary := start.Add(length)
+	for i := 0; i+1 < samples.Len(); i++ {
+		t0, t1 := samples.Timestamps[i], samples.Timestamps[i+1]
+		from := 0.0
+		if !t0.IsZero() && t1.After(t0) {
+			for !boundary.After(t1) {
+				to := float64(boundary.Sub(t0)) / float64(t1.Sub(t0))
+				acc.add(samples, i, from, to)
+				splits = append(splits, Split{Index: len(splits) + 1, SegmentStats: acc.result()})
+				acc = segmentAccumulator{}
+				from = max(from, to)
+				boundary = boundary.Add(length)
+			}
+		}
+		acc.add(samples, i, from, 1)
+	}
+	if acc.stats.Seconds > 0 {
+		splits = append(splits, Split{Index: len(splits) + 1, SegmentStats: acc.result()})
+	}
+	return splits
+}
+
+// lastReading returns the last value of a series, or zero without readings
+func lastReading(values Series) float64 {
+	for i := len(values) - 1; i >= 0; i-- {
+		if !math.IsNaN(values[i]) {
+			return values[i]
+		}
+	}
+	return 0
+}
+
+// alignSamples resamples an activity onto axis, which holds distances or
+// elapsed seconds in increasing order. Points past the end of the activity
+// are NaN.
+func alignSamples(samples *ActivitySamples, axis []float64, alignBy string) AlignedSeries {
+	aligned := AlignedSeries{
+		ActivityID: samples.ActivityID,
+		HeartRate:  make(Series, len(axis)),
+		Power:      make(Series, len(axis)),
+		Speed:      make(Series, len(axis)),
+	}
+	interpolated := make(Series, len(axis))
+
+	// position returns the axis coordinate of sample i
+	position := func(i int) float64 {
+		if alignBy == AlignByDistance {
+			return samples.Distance.at(i)
+		}
+		if samples.Timestamps[i].IsZero() {
+			return math.NaN()
+		}
+		return samples.Timestamps[i].Sub(samples.Timestamps[0]).Seconds()
+	}
+	// other returns the interpolated coordinate, elapsed time or distance
+	other := func(i int) float64 {
+		if alignBy == AlignByDistance {
+			if samples.Timestamps[i].IsZero() {
+				return math.NaN()
+			}
+			return samples.Timestamps[i].Sub(samples.Timestamps[0]).Seconds()
+		}
+		return samples.Distance.at(i)
+	}
+
+	prev, j := -1, 0
+	for k, x := range axis {
+		for j < samples.Len() && (math.IsNaN(position(j)) || position(j) < x) {
+			if !math.IsNaN(position(j)) {
+				prev = j
+			}
+			j++
+		}
+		if j == samples.Len() {
+			interpolated[k] = math.NaN()
+			aligned.HeartRate[k], aligned.Power[k], aligned.Speed[k] = math.NaN(), math.NaN(), math.NaN()
+			continue
+		}
+
+		interpolated[k] = other(j)
+		if prev >= 0 && position(j) > position(prev) {
+			f := (x - position(prev)) / (position(j) - position(prev))
+			interpolated[k] = other(prev) + (other(j)-other(prev))*f
+		}
+		aligned.HeartRate[k] = samples.HeartRate.at(j)
+		aligned.Power[k] = samples.Power.at(j)
+		aligned.Speed[k] = samples.Speed.at(j)
+	}
+
+	if alignBy == AlignByDistance {
+		aligned.Elapsed = interpolated
+	} else {
+		aligned.Distance = interpolated
+	}
+	return aligned
+}
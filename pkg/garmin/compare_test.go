@@ -0,0 +1,69 @@
+package garmin_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+func TestCompare(t *testing.T) {
+	constant := func(speed float64, seconds int) []float64 {
+		speeds := make([]float64, seconds)
+		for i := range speeds {
+			speeds[i] = speed
+		}
+		return speeds
+	}
+	slow, fast := steadySamples(constant(4, 500)), steadySamples(constant(5, 300))
+	fast.ActivityID = 10
+
+	detail := func(id int64, seconds, distance, aerobic float64) *garmin.ActivityDetail {
+		d := &garmin.ActivityDetail{}
+		d.ActivityID, d.MovingDuration, d.Distance = id, seconds, distance
+		d.Speed.Average = distance / seconds
+		d.TrainingEffect.Aerobic = aerobic
+		return d
+	}
+	details := []*garmin.ActivityDetail{detail(9, 500, 2000, 3.1), detail(10, 300, 1500, 3.4)}
+
+	comparison, err := garmin.Compare(details, []*garmin.ActivitySamples{slow, fast}, garmin.CompareOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, garmin.AlignByDistance, comparison.AlignBy)
+
+	require.Len(t, comparison.Activities, 2)
+	assert.Nil(t, comparison.Activities[0].Delta)
+	delta := comparison.Activities[1].Delta
+	require.NotNil(t, delta)
+	assert.Equal(t, -200.0, delta.Seconds)
+	assert.Equal(t, 1.0, delta.AverageSpeed)
+	require.NotNil(t, delta.Pace)
+	assert.InDelta(t, -50, *delta.Pace, 1e-9) // 200 s/km versus 250 s/km
+	assert.InDelta(t, 0.3, delta.AerobicEffect, 1e-9)
+
+	// The faster activity gains 50s per kilometer and stops after 1.5 km
+	require.Len(t, comparison.Splits, 2)
+	require.NotNil(t, comparison.Splits[0].Deltas[1])
+	assert.InDelta(t, -50, *comparison.Splits[0].Deltas[1], 1e-6)
+	assert.Nil(t, comparison.Splits[0].Deltas[0])
+	assert.InDelta(t, 500, comparison.Splits[1].Splits[1].Distance, 1e-6)
+
+	require.Len(t, comparison.Axis, 21)
+	assert.Equal(t, 1000.0, comparison.Axis[10])
+	assert.InDelta(t, -50, comparison.Series[1].Gap[10], 1e-6)
+	assert.True(t, math.IsNaN(comparison.Series[1].Elapsed[20]))
+
+	// NaN gaps past the end of an activity encode as null
+	_, err = json.Marshal(comparison)
+	require.NoError(t, err)
+
+	byTime, err := garmin.Compare(details, []*garmin.ActivitySamples{slow, fast}, garmin.CompareOptions{AlignBy: garmin.AlignByTime, SplitLength: 100})
+	require.NoError(t, err)
+	require.Len(t, byTime.Splits, 5)
+	assert.InDelta(t, 100, *byTime.Splits[0].Deltas[1], 1e-6) // meters further
+	assert.InDelta(t, 500, byTime.Series[1].Distance[10], 1e-6)
+}
@@ -0,0 +1,239 @@
+package garmin
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sstent/go-garth/errors"
+)
+
+// earthRadius is the mean radius of the Earth in meters
+const earthRadius = 6371008.8
+
+// elevationThreshold is the smallest climb or descent counted in the
+// elevation totals of a course, which filters out GPS altitude noise
+const elevationThreshold = 2.0
+
+// CourseOptions describes a course created from a GPX file
+type CourseOptions struct {
+	// Name of the course. Empty uses the name in the GPX file, or the file
+	// name.
+	Name        string
+	Description string
+	// ActivityType is an activity type key, e.g. "cycling" (default "other")
+	ActivityType string
+	// Privacy is "public", "subscribers" or "private" (default "private")
+	Privacy string
+}
+
+// gpxFile holds the parts of a GPX document used for courses
+type gpxFile struct {
+	Metadata struct {
+		Name string `xml:"name"`
+	} `xml:"metadata"`
+	Tracks []struct {
+		Name     string `xml:"name"`
+		Segments []struct {
+			Points []gpxPoint `xml:"trkpt"`
+		} `xml:"trkseg"`
+	} `xml:"trk"`
+	Routes []struct {
+		Name   string     `xml:"name"`
+		Points []gpxPoint `xml:"rtept"`
+	} `xml:"rte"`
+}
+
+type gpxPoint struct {
+	Lat       float64  `xml:"lat,attr"`
+	Lon       float64  `xml:"lon,attr"`
+	Elevation *float64 `xml:"ele"`
+}
+
+// ReadGPXCourse builds a course from the tracks, or failing that the routes,
+// of a GPX document. The distance of each point and the elevation gain and
+// loss of the course are computed from the coordinates.
+func ReadGPXCourse(r io.Reader, opts CourseOptions) (*Course, error) {
+	var doc gpxFile
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, &errors.IOError{
+			GarthError: errors.GarthError{
+				Message: "Failed to parse GPX file",
+				Cause:   err,
+			},
+		}
+	}
+
+	var points []gpxPoint
+	name := doc.Metadata.Name
+	for _, track := range doc.Tracks {
+		for _, segment := range track.Segments {
+			points = append(points, segment.Points...)
+		}
+		if name == "" {
+			name = track.Name
+		}
+	}
+	if len(points) == 0 {
+		for _, route := range doc.Routes {
+			points = append(points, route.Points...)
+			if name == "" {
+				name = route.Name
+			}
+		}
+	}
+	if len(points) < 2 {
+		return nil, &errors.ValidationError{
+			GarthError: errors.GarthError{
+				Message: "GPX file has fewer than two track or route points",
+			},
+			Field: "gpx",
+		}
+	}
+
+	typeKey := strings.ToLower(opts.ActivityType)
+	if typeKey == "" {
+		typeKey = "other"
+	}
+	typeID, ok := lookupActivityType(typeKey)
+	if !ok {
+		return nil, unknownKey("activityType", "activity type", opts.ActivityType, ActivityTypeKeys())
+	}
+	privacy := strings.ToLower(opts.Privacy)
+	if privacy == "" {
+		privacy = "private"
+	}
+	rule, ok := privacyRules[privacy]
+	if !ok {
+		return nil, unknownKey("privacy", "privacy setting", opts.Privacy, PrivacyKeys())
+	}
+	if opts.Name != "" {
+		name = opts.Name
+	}
+
+	course := &Course{
+		CourseName:     strings.TrimSpace(name),
+		Description:    opts.Description,
+		ActivityTypePK: typeID,
+		RulePK:         rule,
+		StartLatitude:  points[0].Lat,
+		StartLongitude: points[0].Lon,
+		GeoPoints:      make([]CourseGeoPoint, 0, len(points)),
+	}
+
+	var reference *float64
+	for i, p := range points {
+		if i > 0 {
+			prev := points[i-1]
+			course.DistanceMeter += haversine(prev.Lat, prev.Lon, p.Lat, p.Lon)
+		}
+		course.GeoPoints = append(course.GeoPoints, CourseGeoPoint{
+			Latitude:  p.Lat,
+			Longitude: p.Lon,
+			Elevation: p.Elevation,
+			Distance:  course.DistanceMeter,
+		})
+
+		// Elevation changes are counted once they exceed the threshold
+		if p.Elevation == nil {
+			continue
+		}
+		switch {
+		case reference == nil:
+			reference = p.Elevation
+		case *p.Elevation-*reference >= elevationThreshold:
+			course.ElevationGainMeter += *p.Elevation - *reference
+			reference = p.Elevation
+		case *reference-*p.Elevation >= elevationThreshold:
+			course.ElevationLossMeter += *reference - *p.Elevation
+			reference = p.Elevation
+		}
+	}
+	return course, nil
+}
+
+// haversine returns the great-circle distance in meters between two points
+func haversine(lat1, lon1, lat2, lon2 float64) float64 {
+	const rad = math.Pi / 180
+	dLat := (lat2 - lat1) * rad
+	dLon := (lon2 - lon1) * rad
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// CreateCourseFromGPX creates a course from a local GPX file and returns the
+// created course
+func (c *Client) CreateCourseFromGPX(path string, opts CourseOptions) (*Course, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, &errors.IOError{
+			GarthError: errors.GarthError{
+				Message: fmt.Sprintf("Failed to open %s", path),
+				Cause:   err,
+			},
+		}
+	}
+	defer f.Close()
+
+	if err := c.ensureActivityType(opts.ActivityType); err != nil {
+		return nil, err
+	}
+	course, err := ReadGPXCourse(f, opts)
+	if err != nil {
+		return nil, err
+	}
+	// Fall back to the file name when neither opts nor the GPX name the course
+	if course.CourseName == "" {
+		course.CourseName = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return c.CreateCourse(course)
+}
+
+// DownloadCourse downloads a course as "gpx" or "fit" and returns the path of
+// the file written. The default file name is the course ID with the format
+// as extension.
+func (c *Client) DownloadCourse(courseID int64, opts DownloadOptions) (string, error) {
+	format := strings.ToLower(opts.Format)
+	var path string
+	switch format {
+	case "", "gpx":
+		format = "gpx"
+		path = fmt.Sprintf("/course-service/course/gpx/%d", courseID)
+	case "fit":
+		path = fmt.Sprintf("/course-service/course/fit/%d/0", courseID)
+	default:
+		return "", &errors.ValidationError{
+			GarthError: errors.GarthError{
+				Message: fmt.Sprintf("unsupported course format: %s (use gpx or fit)", opts.Format),
+			},
+			Field: "format",
+		}
+	}
+
+	data, contentType, err := c.Client.DownloadFile(path, nil)
+	if err != nil {
+		return "", err
+	}
+	if format == "fit" {
+		if !isFIT(data) {
+			return "", fmt.Errorf("course %d: unexpected FIT file content (%s)", courseID, contentType)
+		}
+	} else if err := checkDownloadContent(data, contentType, downloadFormats["gpx"].contentTypes, true); err != nil {
+		return "", fmt.Errorf("course %d is not available as gpx: %w", courseID, err)
+	}
+
+	filename := opts.Filename
+	if filename == "" {
+		filename = fmt.Sprintf("%d.%s", courseID, format)
+	}
+	outputPath := filepath.Join(opts.OutputDir, filename)
+	if err := writeDownload(outputPath, data); err != nil {
+		return "", err
+	}
+	return outputPath, nil
+}
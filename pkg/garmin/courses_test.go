@@ -0,0 +1,106 @@
+package garmin_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+// testGPX climbs 10 m and descends 5 m over two segments of 0.001° of
+// latitude each, with 1 m of noise below the elevation threshold
+const testGPX = `<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" xmlns="http://www.topografix.com/GPX/1/1">
+  <trk>
+    <name>Hill Repeats</name>
+    <trkseg>
+      <trkpt lat="45.000" lon="6.000"><ele>100</ele></trkpt>
+      <trkpt lat="45.001" lon="6.000"><ele>101</ele></trkpt>
+      <trkpt lat="45.001" lon="6.000"><ele>110</ele></trkpt>
+    </trkseg>
+    <trkseg>
+      <trkpt lat="45.002" lon="6.000"><ele>105</ele></trkpt>
+    </trkseg>
+  </trk>
+</gpx>`
+
+func TestReadGPXCourse(t *testing.T) {
+	course, err := garmin.ReadGPXCourse(strings.NewReader(testGPX), garmin.CourseOptions{ActivityType: "cycling"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Hill Repeats", course.CourseName)
+	assert.Equal(t, 2, course.ActivityTypePK)
+	assert.Equal(t, 2, course.RulePK) // private
+	assert.Equal(t, 45.0, course.StartLatitude)
+	require.Len(t, course.GeoPoints, 4)
+	assert.InDelta(t, 111.2, course.GeoPoints[1].Distance, 0.1)
+	assert.InDelta(t, 222.4, course.DistanceMeter, 0.1)
+	assert.Equal(t, course.DistanceMeter, course.GeoPoints[3].Distance)
+	assert.Equal(t, 10.0, course.ElevationGainMeter)
+	assert.Equal(t, 5.0, course.ElevationLossMeter)
+}
+
+func TestReadGPXCourse_Invalid(t *testing.T) {
+	_, err := garmin.ReadGPXCourse(strings.NewReader(`<gpx><trk><trkseg><trkpt lat="1" lon="2"/></trkseg></trk></gpx>`), garmin.CourseOptions{})
+	assert.Error(t, err)
+
+	_, err = garmin.ReadGPXCourse(strings.NewReader(testGPX), garmin.CourseOptions{ActivityType: "skydiving"})
+	assert.ErrorContains(t, err, "unknown activity type")
+}
+
+func TestCreateCourseFromGPX(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "route.gpx")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Replace(testGPX, "<name>Hill Repeats</name>", "", 1)), 0644))
+
+	var sent garmin.Course
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST /course-service/course", r.Method+" "+r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(body, &sent))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"courseId": 77, "courseName": "route"}`))
+	}))
+	defer server.Close()
+
+	course, err := newTestClient(t, server).CreateCourseFromGPX(path, garmin.CourseOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(77), course.CourseID)
+	assert.Equal(t, "route", sent.CourseName)
+	assert.Len(t, sent.GeoPoints, 4)
+}
+
+func TestDownloadCourse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/course-service/course/gpx/77":
+			w.Header().Set("Content-Type", "application/gpx+xml")
+			w.Write([]byte(testGPX))
+		case "/course-service/course/fit/77/0":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<html>Not found</html>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	client := newTestClient(t, server)
+	path, err := client.DownloadCourse(77, garmin.DownloadOptions{OutputDir: dir})
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "77.gpx"), path)
+
+	_, err = client.DownloadCourse(77, garmin.DownloadOptions{Format: "fit", OutputDir: dir})
+	assert.Error(t, err)
+	_, err = client.DownloadCourse(77, garmin.DownloadOptions{Format: "tcx", OutputDir: dir})
+	assert.Error(t, err)
+}
@@ -28,6 +28,15 @@
 //	steps := garth.NewDailySteps()
 //	stepData, err := steps.List(time.Now(), 7, client)
 //
+//	// Stream every running activity, one page at a time
+//	opts := garmin.ActivityOptions{ActivityType: "running"}
+//	for activity, err := range client.Activities(ctx, opts) {
+//	    if err != nil {
+//	        log.Fatal(err)
+//	    }
+//	    fmt.Println(activity.ActivityName)
+//	}
+//
 // Error Handling:
 // The package defines several error types that implement the GarthError interface:
 //   - APIError: HTTP/API failures (includes status code and response body)
@@ -0,0 +1,232 @@
+package garmin
+
+import (
+	"archive/zip"
+	"bytes"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sstent/go-garth/errors"
+)
+
+// ErrNoOriginalFile is returned by DownloadActivityFiles when an activity has
+// no original file, as for manually entered activities
+var ErrNoOriginalFile = stderrors.New("no original file")
+
+// downloadFormats describes the export formats offered by the download
+// service and the content types the server returns for them
+var downloadFormats = map[string]struct {
+	contentTypes []string
+	xml          bool
+}{
+	"gpx": {[]string{"application/gpx+xml", "application/xml", "text/xml"}, true},
+	"tcx": {[]string{"application/vnd.garmin.tcx+xml", "application/xml", "text/xml"}, true},
+	"kml": {[]string{"application/vnd.google-earth.kml+xml", "application/xml", "text/xml"}, true},
+	"csv": {[]string{"text/csv", "text/plain", "application/csv"}, false},
+}
+
+// DownloadActivityFiles downloads activity data and returns the paths of the
+// files written.
+//
+// The "fit" format (or opts.Original) downloads the original uploaded file.
+// Garmin serves it as a zip archive, which is extracted so the returned paths
+// are the real inner files, e.g. 12345_ACTIVITY.fit. The "gpx", "tcx", "kml"
+// and "csv" formats are exported by the server. If the server answers with
+// something other than the requested format, typically an HTML error page for
+// an activity without GPS data, an error is returned and nothing is written.
+// Errors for activities without an original file wrap ErrNoOriginalFile.
+func (c *Client) DownloadActivityFiles(activityID int, opts DownloadOptions) ([]string, error) {
+	format := strings.ToLower(opts.Format)
+	if opts.Original {
+		format = "fit"
+	}
+
+	if format == "fit" {
+		data, contentType, err := c.Client.DownloadFile(fmt.Sprintf("/download-service/files/activity/%d", activityID), nil)
+		var apiErr *errors.APIError
+		if stderrors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("activity %d has %w: %w", activityID, ErrNoOriginalFile, err)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return writeOriginalFiles(activityID, data, contentType, opts)
+	}
+
+	spec, ok := downloadFormats[format]
+	if !ok {
+		return nil, &errors.ValidationError{
+			GarthError: errors.GarthError{
+				Message: fmt.Sprintf("unsupported download format: %s", opts.Format),
+			},
+			Field: "format",
+		}
+	}
+
+	data, contentType, err := c.Client.DownloadFile(fmt.Sprintf("/download-service/export/%s/activity/%d", format, activityID), nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkDownloadContent(data, contentType, spec.contentTypes, spec.xml); err != nil {
+		return nil, fmt.Errorf("activity %d is not available as %s: %w", activityID, format, err)
+	}
+
+	filename := opts.Filename
+	if filename == "" {
+		filename = fmt.Sprintf("%d.%s", activityID, format)
+	}
+	outputPath := filepath.Join(opts.OutputDir, filename)
+	if err := writeDownload(outputPath, data); err != nil {
+		return nil, err
+	}
+	return []string{outputPath}, nil
+}
+
+// writeOriginalFiles writes an original activity download. Zip archives are
+// extracted; a bare FIT file is written as is.
+func writeOriginalFiles(activityID int, data []byte, contentType string, opts DownloadOptions) ([]string, error) {
+	if isHTML(data, contentType) {
+		return nil, fmt.Errorf("activity %d has %w: server returned %s", activityID, ErrNoOriginalFile, contentType)
+	}
+
+	if !bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		if !isFIT(data) {
+			return nil, fmt.Errorf("activity %d: unexpected original file content (%s)", activityID, contentType)
+		}
+		filename := opts.Filename
+		if filename == "" {
+			filename = fmt.Sprintf("%d_ACTIVITY.fit", activityID)
+		}
+		outputPath := filepath.Join(opts.OutputDir, filename)
+		if err := writeDownload(outputPath, data); err != nil {
+			return nil, err
+		}
+		return []string{outputPath}, nil
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, &errors.IOError{
+			GarthError: errors.GarthError{
+				Message: "Failed to open original file archive",
+				Cause:   err,
+			},
+		}
+	}
+
+	var files []*zip.File
+	for _, f := range archive.File {
+		if !f.FileInfo().IsDir() {
+			files = append(files, f)
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("activity %d has %w: archive is empty", activityID, ErrNoOriginalFile)
+	}
+
+	var paths []string
+	for _, f := range files {
+		// Only the base name is used so archive entries cannot escape OutputDir
+		filename := filepath.Base(f.Name)
+		if opts.Filename != "" && len(files) == 1 {
+			filename = opts.Filename
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return paths, &errors.IOError{
+				GarthError: errors.GarthError{
+					Message: fmt.Sprintf("Failed to read %s from archive", f.Name),
+					Cause:   err,
+				},
+			}
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return paths, &errors.IOError{
+				GarthError: errors.GarthError{
+					Message: fmt.Sprintf("Failed to read %s from archive", f.Name),
+					Cause:   err,
+				},
+			}
+		}
+
+		outputPath := filepath.Join(opts.OutputDir, filename)
+		if err := writeDownload(outputPath, content); err != nil {
+			return paths, err
+		}
+		paths = append(paths, outputPath)
+	}
+	return paths, nil
+}
+
+// checkDownloadContent verifies that a download holds the requested format
+// rather than an error page
+func checkDownloadContent(data []byte, contentType string, accepted []string, xml bool) error {
+	if len(data) == 0 {
+		return fmt.Errorf("server returned an empty file")
+	}
+	if isHTML(data, contentType) {
+		return fmt.Errorf("server returned an HTML page")
+	}
+
+	mediaType := strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	if mediaType != "" && mediaType != "application/octet-stream" {
+		known := false
+		for _, ct := range accepted {
+			if mediaType == ct {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("unexpected content type %s", mediaType)
+		}
+	}
+
+	if xml && !bytes.HasPrefix(bytes.TrimSpace(data), []byte("<")) {
+		return fmt.Errorf("response is not an XML document")
+	}
+	return nil
+}
+
+func isHTML(data []byte, contentType string) bool {
+	if strings.Contains(strings.ToLower(contentType), "text/html") {
+		return true
+	}
+	head := bytes.ToLower(bytes.TrimSpace(data))
+	if len(head) > 64 {
+		head = head[:64]
+	}
+	return bytes.HasPrefix(head, []byte("<!doctype html")) || bytes.HasPrefix(head, []byte("<html"))
+}
+
+// isFIT reports whether data starts with a FIT file header
+func isFIT(data []byte) bool {
+	return len(data) >= 12 && string(data[8:12]) == ".FIT"
+}
+
+func writeDownload(outputPath string, data []byte) error {
+	if len(data) == 0 {
+		return &errors.IOError{
+			GarthError: errors.GarthError{
+				Message: "Downloaded file is empty",
+			},
+		}
+	}
+	if err := os.WriteFile(outputPath, data, 0644); err != nil {
+		return &errors.IOError{
+			GarthError: errors.GarthError{
+				Message: "Failed to save file",
+				Cause:   err,
+			},
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package garmin_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+func TestDownloadActivityFiles_OriginalZip(t *testing.T) {
+	fit := append([]byte{14, 0x10, 0, 0, 0, 0, 0, 0}, []byte(".FIT")...)
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	w, err := zw.Create("42_ACTIVITY.fit")
+	require.NoError(t, err)
+	w.Write(fit)
+	require.NoError(t, zw.Close())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/download-service/files/activity/42", r.URL.Path)
+		w.Header().Set("Content-Type", "application/x-zip-compressed")
+		w.Write(archive.Bytes())
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	paths, err := newTestClient(t, server).DownloadActivityFiles(42, garmin.DownloadOptions{Original: true, OutputDir: dir})
+	require.NoError(t, err)
+	require.Equal(t, []string{filepath.Join(dir, "42_ACTIVITY.fit")}, paths)
+
+	data, err := os.ReadFile(paths[0])
+	require.NoError(t, err)
+	assert.Equal(t, fit, data)
+}
+
+func TestDownloadActivityFiles_Export(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/download-service/export/gpx/activity/42", r.URL.Path)
+		w.Header().Set("Content-Type", "application/gpx+xml")
+		w.Write([]byte(`<?xml version="1.0"?><gpx></gpx>`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	paths, err := newTestClient(t, server).DownloadActivityFiles(42, garmin.DownloadOptions{Format: "gpx", OutputDir: dir})
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "42.gpx")}, paths)
+}
+
+func TestDownloadActivityFiles_RejectsHTML(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(`<!DOCTYPE html><html><body>Not available</body></html>`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	_, err := newTestClient(t, server).DownloadActivityFiles(42, garmin.DownloadOptions{Format: "tcx", OutputDir: dir})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not available as tcx")
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "no file should be written for a rejected download")
+}
+
+func TestDownloadActivityFiles_UnsupportedFormat(t *testing.T) {
+	c, err := garmin.NewClient("example.com")
+	require.NoError(t, err)
+	_, err = c.DownloadActivityFiles(42, garmin.DownloadOptions{Format: "pdf"})
+	assert.Error(t, err)
+}
+
+func TestDownloadActivityFiles_NoOriginal(t *testing.T) {
+	// An archive holding only a directory has no activity file
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	_, err := zw.Create("42/")
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/download-service/files/activity/42":
+			http.NotFound(w, r)
+		case "/download-service/files/activity/43":
+			w.Header().Set("Content-Type", "application/x-zip-compressed")
+			w.Write(archive.Bytes())
+		default:
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	client := newTestClient(t, server)
+	opts := garmin.DownloadOptions{Original: true, OutputDir: t.TempDir()}
+
+	_, err = client.DownloadActivityFiles(42, opts)
+	assert.ErrorIs(t, err, garmin.ErrNoOriginalFile)
+
+	_, err = client.DownloadActivityFiles(43, opts)
+	assert.ErrorIs(t, err, garmin.ErrNoOriginalFile)
+
+	_, err = client.DownloadActivityFiles(44, opts)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, garmin.ErrNoOriginalFile)
+}
@@ -0,0 +1,8 @@
+package garmin
+
+// The typed methods in endpoints_gen.go, the `garth api` subcommands and
+// EndpointCatalog.md are generated from the declarative catalog in
+// endpoints.yaml. Add new endpoints there rather than writing the request
+// boilerplate by hand.
+
+//go:generate go run ../../tools/endpointgen -spec endpoints.yaml -client endpoints_gen.go -cli ../../cmd/garth/api_gen.go -docs ../../EndpointCatalog.md
@@ -0,0 +1,744 @@
+// Code generated by endpointgen from pkg/garmin/endpoints.yaml. DO NOT EDIT.
+
+package garmin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// GetDevices implements the "devices" catalog endpoint.
+// List the devices registered to the user's account.
+//
+//	GET /device-service/deviceregistration/devices
+func (c *Client) GetDevices() ([]Device, error) {
+	var result []Device
+	path := "/device-service/deviceregistration/devices"
+	data, err := c.Client.ConnectAPI(path, "GET", nil, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to get devices: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse devices response: %w", err)
+	}
+	return result, nil
+}
+
+// GetDeviceSettings implements the "device-settings" catalog endpoint.
+// Get the settings of a registered device.
+//
+//	GET /device-service/deviceservice/device-info/settings/{deviceId}
+func (c *Client) GetDeviceSettings(deviceID int64) (json.RawMessage, error) {
+	var result json.RawMessage
+	path := fmt.Sprintf("/device-service/deviceservice/device-info/settings/%d", deviceID)
+	data, err := c.Client.ConnectAPI(path, "GET", nil, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to get device settings: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse device settings response: %w", err)
+	}
+	return result, nil
+}
+
+// GetDeviceSyncStatus implements the "device-sync-status" catalog endpoint.
+// Get the synchronization status of the user's devices.
+//
+//	GET /device-service/deviceservice/device-info/sync-status
+func (c *Client) GetDeviceSyncStatus() (json.RawMessage, error) {
+	var result json.RawMessage
+	path := "/device-service/deviceservice/device-info/sync-status"
+	data, err := c.Client.ConnectAPI(path, "GET", nil, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to get device sync status: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse device sync status response: %w", err)
+	}
+	return result, nil
+}
+
+// GetSocialProfile implements the "social-profile" catalog endpoint.
+// Get the public profile of a user by display name.
+//
+//	GET /userprofile-service/socialProfile/{displayName}
+func (c *Client) GetSocialProfile(displayName string) (*UserProfile, error) {
+	var result *UserProfile
+	path := fmt.Sprintf("/userprofile-service/socialProfile/%s", url.PathEscape(displayName))
+	data, err := c.Client.ConnectAPI(path, "GET", nil, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to get social profile: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse social profile response: %w", err)
+	}
+	return result, nil
+}
+
+// GetConnections implements the "connections" catalog endpoint.
+// List the user's connections.
+//
+//	GET /userprofile-service/connection-service/connections
+func (c *Client) GetConnections() (json.RawMessage, error) {
+	var result json.RawMessage
+	path := "/userprofile-service/connection-service/connections"
+	data, err := c.Client.ConnectAPI(path, "GET", nil, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to get connections: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse connections response: %w", err)
+	}
+	return result, nil
+}
+
+// GetGoals implements the "goals" catalog endpoint.
+// Get the user's fitness goals.
+//
+//	GET /userprofile-service/userprofile/personal-information/goals
+func (c *Client) GetGoals() (*Goals, error) {
+	var result *Goals
+	path := "/userprofile-service/userprofile/personal-information/goals"
+	data, err := c.Client.ConnectAPI(path, "GET", nil, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to get goals: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse goals response: %w", err)
+	}
+	return result, nil
+}
+
+// GetEarnedBadges implements the "badges" catalog endpoint.
+// List the badges earned by the user.
+//
+//	GET /badge-service/badge/earned
+func (c *Client) GetEarnedBadges() ([]Badge, error) {
+	var result []Badge
+	path := "/badge-service/badge/earned"
+	data, err := c.Client.ConnectAPI(path, "GET", nil, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to get earned badges: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse earned badges response: %w", err)
+	}
+	return result, nil
+}
+
+// GetPersonalRecords implements the "personal-records" catalog endpoint.
+// List the personal records of a user.
+//
+//	GET /personalrecord-service/personalrecord/prs/{displayName}
+func (c *Client) GetPersonalRecords(displayName string) ([]PersonalRecord, error) {
+	var result []PersonalRecord
+	path := fmt.Sprintf("/personalrecord-service/personalrecord/prs/%s", url.PathEscape(displayName))
+	data, err := c.Client.ConnectAPI(path, "GET", nil, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to get personal records: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse personal records response: %w", err)
+	}
+	return result, nil
+}
+
+// GetActivityWeather implements the "activity-weather" catalog endpoint.
+// Get the weather report of an activity.
+//
+//	GET /activity-service/activity/{activityId}/weather
+func (c *Client) GetActivityWeather(activityID int64) (*ActivityWeather, error) {
+	var result *ActivityWeather
+	path := fmt.Sprintf("/activity-service/activity/%d/weather", activityID)
+	data, err := c.Client.ConnectAPI(path, "GET", nil, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to get activity weather: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse activity weather response: %w", err)
+	}
+	return result, nil
+}
+
+// GetActivityTypes implements the "activity-types" catalog endpoint.
+// List every activity type with its ID and parent type.
+//
+//	GET /activity-service/activity/activityTypes
+func (c *Client) GetActivityTypes() ([]ActivityType, error) {
+	var result []ActivityType
+	path := "/activity-service/activity/activityTypes"
+	data, err := c.Client.ConnectAPI(path, "GET", nil, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to get activity types: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse activity types response: %w", err)
+	}
+	return result, nil
+}
+
+// GetUserGear implements the "gear" catalog endpoint.
+// List the gear of a user, active and retired.
+//
+//	GET /gear-service/gear/filterGear
+func (c *Client) GetUserGear(userProfilePk int64) ([]Gear, error) {
+	var result []Gear
+	path := "/gear-service/gear/filterGear"
+	params := url.Values{}
+	if userProfilePk != 0 {
+		params.Set("userProfilePk", strconv.FormatInt(userProfilePk, 10))
+	}
+	data, err := c.Client.ConnectAPI(path, "GET", params, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to get user gear: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse user gear response: %w", err)
+	}
+	return result, nil
+}
+
+// GetActivityGear implements the "activity-gear" catalog endpoint.
+// List the gear linked to an activity.
+//
+//	GET /gear-service/gear/filterGear
+func (c *Client) GetActivityGear(activityID int64) ([]Gear, error) {
+	var result []Gear
+	path := "/gear-service/gear/filterGear"
+	params := url.Values{}
+	if activityID != 0 {
+		params.Set("activityId", strconv.FormatInt(activityID, 10))
+	}
+	data, err := c.Client.ConnectAPI(path, "GET", params, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to get activity gear: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse activity gear response: %w", err)
+	}
+	return result, nil
+}
+
+// GetGearStats implements the "gear-stats" catalog endpoint.
+// Get the total distance and activity count of a piece of gear.
+//
+//	GET /gear-service/gear/stats/{uuid}
+func (c *Client) GetGearStats(uuid string) (*GearStats, error) {
+	var result *GearStats
+	path := fmt.Sprintf("/gear-service/gear/stats/%s", url.PathEscape(uuid))
+	data, err := c.Client.ConnectAPI(path, "GET", nil, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to get gear stats: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse gear stats response: %w", err)
+	}
+	return result, nil
+}
+
+// GetGearActivities implements the "gear-activities" catalog endpoint.
+// List the activities a piece of gear was used for.
+//
+//	GET /activitylist-service/activities/{uuid}/gear
+func (c *Client) GetGearActivities(uuid string, start int, limit int) ([]Activity, error) {
+	var result []Activity
+	path := fmt.Sprintf("/activitylist-service/activities/%s/gear", url.PathEscape(uuid))
+	params := url.Values{}
+	if start != 0 {
+		params.Set("start", strconv.Itoa(start))
+	}
+	if limit != 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+	data, err := c.Client.ConnectAPI(path, "GET", params, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to get gear activities: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse gear activities response: %w", err)
+	}
+	return result, nil
+}
+
+// GetCourses implements the "courses" catalog endpoint.
+// List the user's courses.
+//
+//	GET /course-service/course
+func (c *Client) GetCourses() ([]Course, error) {
+	var result []Course
+	path := "/course-service/course"
+	data, err := c.Client.ConnectAPI(path, "GET", nil, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to get courses: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse courses response: %w", err)
+	}
+	return result, nil
+}
+
+// GetCourse implements the "course" catalog endpoint.
+// Get a course with its track points.
+//
+//	GET /course-service/course/{courseId}
+func (c *Client) GetCourse(courseID int64) (*Course, error) {
+	var result *Course
+	path := fmt.Sprintf("/course-service/course/%d", courseID)
+	data, err := c.Client.ConnectAPI(path, "GET", nil, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to get course: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse course response: %w", err)
+	}
+	return result, nil
+}
+
+// CreateCourse implements the "create-course" catalog endpoint.
+// Create a course.
+//
+//	POST /course-service/course
+func (c *Client) CreateCourse(body interface{}) (*Course, error) {
+	var result *Course
+	path := "/course-service/course"
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return result, fmt.Errorf("failed to encode create course request: %w", err)
+	}
+	data, err := c.Client.ConnectAPI(path, "POST", nil, bytes.NewReader(payload))
+	if err != nil {
+		return result, fmt.Errorf("failed to create course: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse course response: %w", err)
+	}
+	return result, nil
+}
+
+// DeleteCourse implements the "delete-course" catalog endpoint.
+// Delete a course.
+//
+//	DELETE /course-service/course/{courseId}
+func (c *Client) DeleteCourse(courseID int64) (json.RawMessage, error) {
+	var result json.RawMessage
+	path := fmt.Sprintf("/course-service/course/%d", courseID)
+	data, err := c.Client.ConnectAPI(path, "DELETE", nil, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to delete course: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse course response: %w", err)
+	}
+	return result, nil
+}
+
+// GetWorkouts implements the "workouts" catalog endpoint.
+// List the user's workouts.
+//
+//	GET /workout-service/workouts
+func (c *Client) GetWorkouts(start int, limit int) ([]Workout, error) {
+	var result []Workout
+	path := "/workout-service/workouts"
+	params := url.Values{}
+	if start != 0 {
+		params.Set("start", strconv.Itoa(start))
+	}
+	if limit != 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+	data, err := c.Client.ConnectAPI(path, "GET", params, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to get workouts: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse workouts response: %w", err)
+	}
+	return result, nil
+}
+
+// GetWorkout implements the "workout" catalog endpoint.
+// Get a workout with its steps.
+//
+//	GET /workout-service/workout/{workoutId}
+func (c *Client) GetWorkout(workoutID int64) (*Workout, error) {
+	var result *Workout
+	path := fmt.Sprintf("/workout-service/workout/%d", workoutID)
+	data, err := c.Client.ConnectAPI(path, "GET", nil, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to get workout: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse workout response: %w", err)
+	}
+	return result, nil
+}
+
+// CreateWorkout implements the "create-workout" catalog endpoint.
+// Create a workout.
+//
+//	POST /workout-service/workout
+func (c *Client) CreateWorkout(body interface{}) (*Workout, error) {
+	var result *Workout
+	path := "/workout-service/workout"
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return result, fmt.Errorf("failed to encode create workout request: %w", err)
+	}
+	data, err := c.Client.ConnectAPI(path, "POST", nil, bytes.NewReader(payload))
+	if err != nil {
+		return result, fmt.Errorf("failed to create workout: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse workout response: %w", err)
+	}
+	return result, nil
+}
+
+// DeleteWorkout implements the "delete-workout" catalog endpoint.
+// Delete a workout.
+//
+//	DELETE /workout-service/workout/{workoutId}
+func (c *Client) DeleteWorkout(workoutID int64) (json.RawMessage, error) {
+	var result json.RawMessage
+	path := fmt.Sprintf("/workout-service/workout/%d", workoutID)
+	data, err := c.Client.ConnectAPI(path, "DELETE", nil, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to delete workout: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse workout response: %w", err)
+	}
+	return result, nil
+}
+
+// CreateWorkoutSchedule implements the "schedule-workout" catalog endpoint.
+// Schedule a workout on a calendar date.
+//
+//	POST /workout-service/schedule/{workoutId}
+func (c *Client) CreateWorkoutSchedule(workoutID int64, body interface{}) (*ScheduledWorkout, error) {
+	var result *ScheduledWorkout
+	path := fmt.Sprintf("/workout-service/schedule/%d", workoutID)
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return result, fmt.Errorf("failed to encode create workout schedule request: %w", err)
+	}
+	data, err := c.Client.ConnectAPI(path, "POST", nil, bytes.NewReader(payload))
+	if err != nil {
+		return result, fmt.Errorf("failed to create workout schedule: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse workout schedule response: %w", err)
+	}
+	return result, nil
+}
+
+// GetCalendarMonth implements the "calendar-month" catalog endpoint.
+// Get the calendar items of a month.
+//
+//	GET /calendar-service/year/{year}/month/{month}
+func (c *Client) GetCalendarMonth(year int, month int) (*CalendarMonth, error) {
+	var result *CalendarMonth
+	path := fmt.Sprintf("/calendar-service/year/%d/month/%d", year, month)
+	data, err := c.Client.ConnectAPI(path, "GET", nil, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to get calendar month: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse calendar month response: %w", err)
+	}
+	return result, nil
+}
+
+// GetCalendarWeek implements the "calendar-week" catalog endpoint.
+// Get the calendar items of the week containing a day.
+//
+//	GET /calendar-service/year/{year}/month/{month}/day/{day}/start/{firstDayOfWeek}
+func (c *Client) GetCalendarWeek(year int, month int, day int, firstDayOfWeek int) (*CalendarMonth, error) {
+	var result *CalendarMonth
+	path := fmt.Sprintf("/calendar-service/year/%d/month/%d/day/%d/start/%d", year, month, day, firstDayOfWeek)
+	data, err := c.Client.ConnectAPI(path, "GET", nil, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to get calendar week: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse calendar week response: %w", err)
+	}
+	return result, nil
+}
+
+// GetDailySummary implements the "daily-summary" catalog endpoint.
+// Get the daily activity summary for a user and date.
+//
+//	GET /usersummary-service/usersummary/daily/{displayName}
+func (c *Client) GetDailySummary(displayName string, calendarDate time.Time) (json.RawMessage, error) {
+	var result json.RawMessage
+	path := fmt.Sprintf("/usersummary-service/usersummary/daily/%s", url.PathEscape(displayName))
+	params := url.Values{}
+	if !calendarDate.IsZero() {
+		params.Set("calendarDate", calendarDate.Format("2006-01-02"))
+	}
+	data, err := c.Client.ConnectAPI(path, "GET", params, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to get daily summary: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse daily summary response: %w", err)
+	}
+	return result, nil
+}
+
+// GetDailyHydration implements the "hydration" catalog endpoint.
+// Get the hydration log for a date.
+//
+//	GET /usersummary-service/usersummary/hydration/daily/{date}
+func (c *Client) GetDailyHydration(date time.Time) (*DailyHydration, error) {
+	var result *DailyHydration
+	path := fmt.Sprintf("/usersummary-service/usersummary/hydration/daily/%s", date.Format("2006-01-02"))
+	data, err := c.Client.ConnectAPI(path, "GET", nil, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to get daily hydration: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse daily hydration response: %w", err)
+	}
+	return result, nil
+}
+
+// LogHydration implements the "log-hydration" catalog endpoint.
+// Add a hydration entry.
+//
+//	PUT /usersummary-service/usersummary/hydration/log
+func (c *Client) LogHydration(body interface{}) (json.RawMessage, error) {
+	var result json.RawMessage
+	path := "/usersummary-service/usersummary/hydration/log"
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return result, fmt.Errorf("failed to encode log hydration request: %w", err)
+	}
+	data, err := c.Client.ConnectAPI(path, "PUT", nil, bytes.NewReader(payload))
+	if err != nil {
+		return result, fmt.Errorf("failed to log hydration: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse hydration response: %w", err)
+	}
+	return result, nil
+}
+
+// GetWellnessDashboard implements the "wellness-dashboard" catalog endpoint.
+// Get the wellness dashboard for a date.
+//
+//	GET /wellness-service/wellness/wellness-dashboard/{date}
+func (c *Client) GetWellnessDashboard(date time.Time) (json.RawMessage, error) {
+	var result json.RawMessage
+	path := fmt.Sprintf("/wellness-service/wellness/wellness-dashboard/%s", date.Format("2006-01-02"))
+	data, err := c.Client.ConnectAPI(path, "GET", nil, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to get wellness dashboard: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse wellness dashboard response: %w", err)
+	}
+	return result, nil
+}
+
+// GetRespiration implements the "respiration" catalog endpoint.
+// Get respiration data for a date.
+//
+//	GET /wellness-service/wellness/daily/respiration/{date}
+func (c *Client) GetRespiration(date time.Time) (json.RawMessage, error) {
+	var result json.RawMessage
+	path := fmt.Sprintf("/wellness-service/wellness/daily/respiration/%s", date.Format("2006-01-02"))
+	data, err := c.Client.ConnectAPI(path, "GET", nil, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to get respiration: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse respiration response: %w", err)
+	}
+	return result, nil
+}
+
+// GetSpO2 implements the "spo2" catalog endpoint.
+// Get pulse oximetry data for a date.
+//
+//	GET /wellness-service/wellness/daily/spo2/{date}
+func (c *Client) GetSpO2(date time.Time) (json.RawMessage, error) {
+	var result json.RawMessage
+	path := fmt.Sprintf("/wellness-service/wellness/daily/spo2/%s", date.Format("2006-01-02"))
+	data, err := c.Client.ConnectAPI(path, "GET", nil, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to get sp o2: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse sp o2 response: %w", err)
+	}
+	return result, nil
+}
+
+// GetFloors implements the "floors" catalog endpoint.
+// Get floors climbed and descended for a date.
+//
+//	GET /wellness-service/wellness/floorsChartData/daily/{date}
+func (c *Client) GetFloors(date time.Time) (json.RawMessage, error) {
+	var result json.RawMessage
+	path := fmt.Sprintf("/wellness-service/wellness/floorsChartData/daily/%s", date.Format("2006-01-02"))
+	data, err := c.Client.ConnectAPI(path, "GET", nil, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to get floors: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse floors response: %w", err)
+	}
+	return result, nil
+}
+
+// GetRestingHeartRate implements the "resting-hr" catalog endpoint.
+// Get resting heart rate values for a date range.
+//
+//	GET /userstats-service/wellness/daily/{displayName}
+func (c *Client) GetRestingHeartRate(displayName string, fromDate time.Time, untilDate time.Time, metricID int) (json.RawMessage, error) {
+	var result json.RawMessage
+	path := fmt.Sprintf("/userstats-service/wellness/daily/%s", url.PathEscape(displayName))
+	params := url.Values{}
+	if !fromDate.IsZero() {
+		params.Set("fromDate", fromDate.Format("2006-01-02"))
+	}
+	if !untilDate.IsZero() {
+		params.Set("untilDate", untilDate.Format("2006-01-02"))
+	}
+	if metricID != 0 {
+		params.Set("metricId", strconv.Itoa(metricID))
+	}
+	data, err := c.Client.ConnectAPI(path, "GET", params, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to get resting heart rate: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse resting heart rate response: %w", err)
+	}
+	return result, nil
+}
+
+// GetGolfScorecard implements the "golf-scorecard" catalog endpoint.
+// Get the details of a golf scorecard.
+//
+//	GET /golf-service/golf/scorecard/{scorecardId}
+func (c *Client) GetGolfScorecard(scorecardID int64) (json.RawMessage, error) {
+	var result json.RawMessage
+	path := fmt.Sprintf("/golf-service/golf/scorecard/%d", scorecardID)
+	data, err := c.Client.ConnectAPI(path, "GET", nil, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to get golf scorecard: %w", err)
+	}
+	if len(data) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse golf scorecard response: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,119 @@
+package garmin
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GearStatusRetired is the status of gear that is no longer in use
+const GearStatusRetired = "retired"
+
+// GearName returns the name shown for a piece of gear: its display name, or
+// its make and model
+func GearName(gear Gear) string {
+	switch {
+	case gear.DisplayName != "":
+		return gear.DisplayName
+	case gear.CustomMakeModel != "":
+		return gear.CustomMakeModel
+	default:
+		return strings.TrimSpace(gear.GearMakeName + " " + gear.GearModelName)
+	}
+}
+
+// GearUsage holds the usage totals of a piece of gear. Alert is set once
+// the gear has covered its distance limit.
+type GearUsage struct {
+	UUID       string  `json:"uuid"`
+	Name       string  `json:"name"`
+	Type       string  `json:"type"`
+	Status     string  `json:"status"`
+	Activities int     `json:"activities"`
+	Distance   float64 `json:"distance"` // meters
+	Seconds    float64 `json:"seconds"`
+	Limit      float64 `json:"limit,omitempty"` // meters
+	Alert      bool    `json:"alert"`
+}
+
+// ListGear retrieves the user's gear, active and retired
+func (c *Client) ListGear() ([]Gear, error) {
+	profile, err := c.GetUserProfile()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user profile: %w", err)
+	}
+	return c.GetUserGear(profile.ProfileID)
+}
+
+// FindGear returns the piece of gear whose UUID or name matches ref, ignoring
+// case
+func FindGear(gear []Gear, ref string) (Gear, error) {
+	var matches []Gear
+	for _, g := range gear {
+		if strings.EqualFold(g.UUID, ref) {
+			return g, nil
+		}
+		if strings.EqualFold(GearName(g), ref) {
+			matches = append(matches, g)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return Gear{}, fmt.Errorf("no gear named %q", ref)
+	case 1:
+		return matches[0], nil
+	default:
+		return Gear{}, fmt.Errorf("%d pieces of gear are named %q; use the UUID", len(matches), ref)
+	}
+}
+
+// GetGearUsage computes the usage totals of a piece of gear. The distance
+// and activity count come from Garmin Connect; the time is summed over the
+// gear's activities. A limit of zero uses the maximum distance set for the
+// gear on Garmin Connect.
+func (c *Client) GetGearUsage(gear Gear, limit float64) (*GearUsage, error) {
+	stats, err := c.GetGearStats(gear.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &GearUsage{
+		UUID:   gear.UUID,
+		Name:   GearName(gear),
+		Type:   gear.GearTypeName,
+		Status: gear.GearStatusName,
+		Limit:  limit,
+	}
+	if stats != nil {
+		usage.Activities = stats.TotalActivities
+		usage.Distance = stats.TotalDistance
+	}
+
+	for start := 0; ; start += activityPageSize {
+		activities, err := c.GetGearActivities(gear.UUID, start, activityPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, activity := range activities {
+			usage.Seconds += activity.Duration
+		}
+		if len(activities) < activityPageSize {
+			break
+		}
+	}
+
+	if usage.Limit <= 0 {
+		usage.Limit = gear.MaximumMeters
+	}
+	usage.Alert = usage.Limit > 0 && usage.Distance >= usage.Limit
+	return usage, nil
+}
+
+// LinkGear links a piece of gear to an activity
+func (c *Client) LinkGear(gearUUID string, activityID int) error {
+	return c.Client.LinkGear(gearUUID, int64(activityID))
+}
+
+// UnlinkGear removes a piece of gear from an activity
+func (c *Client) UnlinkGear(gearUUID string, activityID int) error {
+	return c.Client.UnlinkGear(gearUUID, int64(activityID))
+}
@@ -0,0 +1,80 @@
+package garmin_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+func TestGearUsage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/userprofile-service/socialProfile":
+			w.Write([]byte(`{"profileId": 1234, "displayName": "runner42"}`))
+		case "/gear-service/gear/filterGear":
+			assert.Equal(t, "1234", r.URL.Query().Get("userProfilePk"))
+			w.Write([]byte(`[
+				{"uuid": "aaa", "displayName": "Race Shoes", "gearTypeName": "Shoes", "gearStatusName": "active", "maximumMeters": 600000},
+				{"uuid": "bbb", "gearMakeName": "Trek", "gearModelName": "Domane", "gearTypeName": "Bike", "gearStatusName": "retired"}
+			]`))
+		case "/gear-service/gear/stats/aaa":
+			w.Write([]byte(`{"uuid": "aaa", "totalActivities": 101, "totalDistance": 650000}`))
+		case "/activitylist-service/activities/aaa/gear":
+			page := make([]map[string]interface{}, 0, 100)
+			count := 100
+			if r.URL.Query().Get("start") == "100" {
+				count = 1
+			}
+			for i := 0; i < count; i++ {
+				page = append(page, map[string]interface{}{"activityId": i, "duration": 1800})
+			}
+			json.NewEncoder(w).Encode(page)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	client := newTestClient(t, server)
+
+	gear, err := client.ListGear()
+	require.NoError(t, err)
+	require.Len(t, gear, 2)
+	assert.Equal(t, "Trek Domane", garmin.GearName(gear[1]))
+
+	shoes, err := garmin.FindGear(gear, "race shoes")
+	require.NoError(t, err)
+	_, err = garmin.FindGear(gear, "Commuter")
+	assert.Error(t, err)
+
+	usage, err := client.GetGearUsage(shoes, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 101, usage.Activities)
+	assert.Equal(t, 650000.0, usage.Distance)
+	assert.Equal(t, 101*1800.0, usage.Seconds)
+	assert.Equal(t, 600000.0, usage.Limit)
+	assert.True(t, usage.Alert)
+
+	usage, err = client.GetGearUsage(shoes, 800000)
+	require.NoError(t, err)
+	assert.False(t, usage.Alert)
+}
+
+func TestGetGearStats_EscapesUUID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/gear-service/gear/stats/a%2Fb%3Fc", r.URL.EscapedPath())
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"uuid": "a/b?c", "totalActivities": 3}`))
+	}))
+	defer server.Close()
+
+	stats, err := newTestClient(t, server).GetGearStats("a/b?c")
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.TotalActivities)
+}
@@ -0,0 +1,288 @@
+package garmin
+
+import (
+	"encoding/csv"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sstent/go-garth/errors"
+	types "github.com/sstent/go-garth/models/types"
+)
+
+// ManualActivity describes an activity entered by hand rather than recorded
+// by a device
+type ManualActivity struct {
+	Name        string
+	Type        string    // Activity type key, e.g. "strength_training"
+	Start       time.Time // Start time; its location is the activity's time zone
+	Duration    time.Duration
+	Distance    float64 // Meters
+	Calories    float64 // Kilocalories; zero lets Garmin estimate them
+	Description string
+	Privacy     string // "public", "subscribers" or "private"; empty uses the account default
+}
+
+// Validate checks that the activity has a known type, a start and a duration
+func (a ManualActivity) Validate() error {
+	if _, ok := lookupActivityType(strings.ToLower(a.Type)); !ok {
+		return unknownKey("type", "activity type", a.Type, ActivityTypeKeys())
+	}
+	if a.Privacy != "" {
+		if _, ok := privacyRules[strings.ToLower(a.Privacy)]; !ok {
+			return unknownKey("privacy", "privacy setting", a.Privacy, PrivacyKeys())
+		}
+	}
+	if a.Start.IsZero() {
+		return manualError("start", "start time is required")
+	}
+	if a.Duration <= 0 {
+		return manualError("duration", "duration must be positive")
+	}
+	if a.Distance < 0 {
+		return manualError("distance", "distance cannot be negative")
+	}
+	if a.Calories < 0 {
+		return manualError("calories", "calories cannot be negative")
+	}
+	return nil
+}
+
+func manualError(field, message string) error {
+	return &errors.ValidationError{
+		GarthError: errors.GarthError{
+			Message: message,
+		},
+		Field: field,
+	}
+}
+
+// CreateManualActivity creates an activity without a recorded file, such as
+// a gym session or a race recorded on another device
+func (c *Client) CreateManualActivity(activity ManualActivity) (*Activity, error) {
+	if err := c.ensureActivityType(activity.Type); err != nil {
+		return nil, err
+	}
+	if err := activity.Validate(); err != nil {
+		return nil, err
+	}
+
+	typeKey := strings.ToLower(activity.Type)
+	typeID, _ := lookupActivityType(typeKey)
+	start := activity.Start
+	zone := timeZoneName(start)
+	if zone == "" {
+		// Sending the start in UTC keeps the right instant when the local
+		// zone has no known name
+		start = start.UTC()
+		zone = "UTC"
+	}
+	name := activity.Name
+	if name == "" {
+		name = strings.ReplaceAll(typeKey, "_", " ")
+	}
+
+	request := &types.ManualActivity{
+		ActivityName:    name,
+		Description:     activity.Description,
+		ActivityTypeDTO: types.ActivityType{TypeID: typeID, TypeKey: typeKey},
+		TimeZoneUnitDTO: types.TimeZoneUnit{UnitKey: zone},
+		MetadataDTO:     types.ManualActivityMetadata{AutoCalcCalories: activity.Calories == 0},
+		SummaryDTO: types.ManualActivitySummary{
+			StartTimeLocal: start.Format("2006-01-02T15:04:05.000"),
+			Distance:       activity.Distance,
+			Duration:       activity.Duration.Seconds(),
+			Calories:       activity.Calories,
+		},
+	}
+	if activity.Privacy != "" {
+		key := strings.ToLower(activity.Privacy)
+		request.AccessControlRuleDTO = &types.AccessControlRule{TypeID: privacyRules[key], TypeKey: key}
+	}
+
+	details, err := c.Client.CreateManualActivity(request)
+	if err != nil {
+		return nil, err
+	}
+	created := activityFromDetails(details)
+	return &created, nil
+}
+
+// timeZoneName returns the IANA name of the time zone of t. The local time
+// zone is only named "Local", so its name is taken from $TZ or the
+// /etc/localtime link when that zone has the same offset at t. An empty
+// string means the name could not be determined.
+func timeZoneName(t time.Time) string {
+	loc := t.Location()
+	if loc.String() != "Local" {
+		return loc.String()
+	}
+
+	var candidates []string
+	if tz, ok := os.LookupEnv("TZ"); ok {
+		tz = strings.TrimPrefix(tz, ":")
+		if tz == "" {
+			tz = "UTC"
+		}
+		candidates = append(candidates, tz)
+	}
+	if target, err := os.Readlink("/etc/localtime"); err == nil {
+		if i := strings.Index(target, "zoneinfo/"); i >= 0 {
+			candidates = append(candidates, target[i+len("zoneinfo/"):])
+		}
+	}
+
+	_, offset := t.Zone()
+	for _, name := range candidates {
+		candidate, err := time.LoadLocation(name)
+		if err != nil {
+			continue
+		}
+		if _, candidateOffset := t.In(candidate).Zone(); candidateOffset == offset {
+			return name
+		}
+	}
+	return ""
+}
+
+// ParseDistance parses a distance such as "10km", "5mi" or "800m" into
+// meters. Bare numbers are kilometers.
+func ParseDistance(value string) (float64, error) {
+	return parseQueryDistance(strings.TrimSpace(value))
+}
+
+// ParseDuration parses a duration such as "45m", "1h30m", "90min" or
+// "1:02:03". Bare numbers are minutes.
+func ParseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if d, err := time.ParseDuration(value); err == nil {
+		return d, nil
+	}
+	seconds, err := parseQueryDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// startTimeLayouts are the accepted formats of a manual activity start time
+var startTimeLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ParseStartTime parses a local start time such as "2024-05-01 18:30" in the
+// given time zone
+func ParseStartTime(value string, loc *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range startTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid start time %q (expected YYYY-MM-DD HH:MM[:SS])", value)
+}
+
+// manualCSVColumns are the columns read by ReadManualActivitiesCSV
+var manualCSVColumns = []string{"type", "name", "start", "duration", "distance", "calories", "description", "privacy"}
+
+// ReadManualActivitiesCSV reads manual activities from CSV with a header row.
+// The type, start and duration columns are required; name, distance,
+// calories, description and privacy are optional. Values use the formats of
+// ParseStartTime, ParseDuration and ParseDistance, and start times are in
+// loc. Every row is validated, and all invalid rows are reported together
+// with their line numbers.
+func ReadManualActivitiesCSV(r io.Reader, loc *time.Location) ([]ManualActivity, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !slices.Contains(manualCSVColumns, name) {
+			return nil, fmt.Errorf("unknown CSV column %q (known: %s)", name, strings.Join(manualCSVColumns, ", "))
+		}
+		columns[name] = i
+	}
+	for _, required := range []string{"type", "start", "duration"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("CSV is missing the %s column", required)
+		}
+	}
+
+	var activities []ManualActivity
+	var rowErrors []error
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		activity, err := manualActivityFromRecord(record, columns, loc)
+		if err == nil {
+			err = activity.Validate()
+		}
+		if err != nil {
+			rowErrors = append(rowErrors, fmt.Errorf("line %d: %w", line, err))
+			continue
+		}
+		activities = append(activities, activity)
+	}
+
+	if len(rowErrors) > 0 {
+		return nil, stderrors.Join(rowErrors...)
+	}
+	return activities, nil
+}
+
+func manualActivityFromRecord(record []string, columns map[string]int, loc *time.Location) (ManualActivity, error) {
+	field := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	activity := ManualActivity{
+		Name:        field("name"),
+		Type:        field("type"),
+		Description: field("description"),
+		Privacy:     field("privacy"),
+	}
+
+	var err error
+	if activity.Start, err = ParseStartTime(field("start"), loc); err != nil {
+		return activity, err
+	}
+	if activity.Duration, err = ParseDuration(field("duration")); err != nil {
+		return activity, fmt.Errorf("invalid duration: %w", err)
+	}
+	if v := field("distance"); v != "" {
+		if activity.Distance, err = ParseDistance(v); err != nil {
+			return activity, fmt.Errorf("invalid distance: %w", err)
+		}
+	}
+	if v := field("calories"); v != "" {
+		if activity.Calories, err = parseQueryNumber(v); err != nil {
+			return activity, fmt.Errorf("invalid calories: %w", err)
+		}
+	}
+	return activity, nil
+}
@@ -0,0 +1,122 @@
+package garmin_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+func TestCreateManualActivity(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST /activity-service/activity", r.Method+" "+r.URL.Path)
+		data, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(data, &body))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"activityId": 99, "activityName": "Leg day", "activityTypeDTO": {"typeId": 13, "typeKey": "strength_training"}}`))
+	}))
+	defer server.Close()
+
+	loc, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	activity, err := newTestClient(t, server).CreateManualActivity(garmin.ManualActivity{
+		Name:     "Leg day",
+		Type:     "strength_training",
+		Start:    time.Date(2024, 5, 1, 18, 30, 0, 0, loc),
+		Duration: 45 * time.Minute,
+		Calories: 320,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(99), activity.ActivityID)
+
+	assert.Equal(t, "Leg day", body["activityName"])
+	assert.Equal(t, map[string]interface{}{"typeId": 13.0, "typeKey": "strength_training"}, body["activityTypeDTO"])
+	assert.Equal(t, map[string]interface{}{"unitKey": "Europe/Berlin"}, body["timeZoneUnitDTO"])
+	assert.Equal(t, map[string]interface{}{
+		"startTimeLocal": "2024-05-01T18:30:00.000",
+		"duration":       2700.0,
+		"calories":       320.0,
+	}, body["summaryDTO"])
+}
+
+func TestParseDuration(t *testing.T) {
+	for input, want := range map[string]time.Duration{
+		"45m":     45 * time.Minute,
+		"1h30m":   90 * time.Minute,
+		"90min":   90 * time.Minute,
+		"1:02:03": time.Hour + 2*time.Minute + 3*time.Second,
+		"30":      30 * time.Minute,
+	} {
+		got, err := garmin.ParseDuration(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+}
+
+func TestReadManualActivitiesCSV(t *testing.T) {
+	input := `type,name,start,duration,distance,calories
+strength_training,Leg day,2024-05-01 18:30,45m,,320
+open_water_swimming,Lake swim,2024-05-02T07:00,0:40:00,1.5km,
+`
+	activities, err := garmin.ReadManualActivitiesCSV(strings.NewReader(input), time.UTC)
+	require.NoError(t, err)
+	require.Len(t, activities, 2)
+
+	assert.Equal(t, "Leg day", activities[0].Name)
+	assert.Equal(t, time.Date(2024, 5, 1, 18, 30, 0, 0, time.UTC), activities[0].Start)
+	assert.Equal(t, 45*time.Minute, activities[0].Duration)
+	assert.Equal(t, 320.0, activities[0].Calories)
+	assert.Equal(t, 1500.0, activities[1].Distance)
+}
+
+func TestReadManualActivitiesCSV_ReportsEveryInvalidRow(t *testing.T) {
+	input := `type,start,duration
+jogging,2024-05-01 18:30,45m
+running,2024-05-01 18:30,45m
+running,yesterday,45m
+`
+	_, err := garmin.ReadManualActivitiesCSV(strings.NewReader(input), time.UTC)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `line 2: validation error for type: unknown activity type "jogging"`)
+	assert.Contains(t, err.Error(), `line 4: invalid start time "yesterday"`)
+	assert.NotContains(t, err.Error(), "line 3")
+}
+
+func TestCreateManualActivity_LocalTimeZone(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(data, &body))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"activityId": 99}`))
+	}))
+	defer server.Close()
+	client := newTestClient(t, server)
+
+	create := func(start time.Time) {
+		_, err := client.CreateManualActivity(garmin.ManualActivity{
+			Type: "running", Start: start, Duration: 30 * time.Minute,
+		})
+		require.NoError(t, err)
+	}
+
+	// A local zone is named after $TZ when its offset matches
+	t.Setenv("TZ", "Europe/Berlin")
+	create(time.Date(2024, 7, 1, 18, 30, 0, 0, time.FixedZone("Local", 2*60*60)))
+	assert.Equal(t, map[string]interface{}{"unitKey": "Europe/Berlin"}, body["timeZoneUnitDTO"])
+	assert.Equal(t, "2024-07-01T18:30:00.000", body["summaryDTO"].(map[string]interface{})["startTimeLocal"])
+
+	// Otherwise the start is sent in UTC rather than as a wrong local time
+	create(time.Date(2024, 7, 1, 18, 30, 0, 0, time.FixedZone("Local", 5*60*60+30*60)))
+	assert.Equal(t, map[string]interface{}{"unitKey": "UTC"}, body["timeZoneUnitDTO"])
+	assert.Equal(t, "2024-07-01T13:00:00.000", body["summaryDTO"].(map[string]interface{})["startTimeLocal"])
+}
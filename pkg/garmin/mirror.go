@@ -0,0 +1,420 @@
+package garmin
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sstent/go-garth/errors"
+)
+
+// DefaultMirrorTemplate is the default layout of a mirror directory
+const DefaultMirrorTemplate = "{year}/{month}/{date}_{type}_{id}.{ext}"
+
+// MirrorManifestFile is the name of the manifest kept in a mirror directory
+const MirrorManifestFile = ".garth-mirror.json"
+
+// MirrorOptions configures Mirror
+type MirrorOptions struct {
+	Dir     string
+	Formats []string // Download formats; defaults to the original FIT file
+	// Template is the path of each file relative to Dir. Placeholders are
+	// {id}, {year}, {month}, {day}, {date}, {type}, {name} and {ext}; {id}
+	// and {ext} are required. Defaults to DefaultMirrorTemplate.
+	Template string
+	Filters  ActivityOptions // Limits the activities mirrored
+	// Prune removes activities that no longer exist on Garmin Connect from
+	// the mirror. It cannot be combined with an activity type filter.
+	Prune bool
+	// OnResult, if set, is called as each activity is processed
+	OnResult func(MirrorResult)
+}
+
+// MirrorStatus is the outcome of mirroring one activity
+type MirrorStatus string
+
+const (
+	MirrorNew       MirrorStatus = "new"
+	MirrorUpdated   MirrorStatus = "updated"
+	MirrorUnchanged MirrorStatus = "unchanged"
+	MirrorPruned    MirrorStatus = "pruned"
+	MirrorFailed    MirrorStatus = "failed"
+)
+
+// MirrorResult describes what Mirror did with one activity
+type MirrorResult struct {
+	ActivityID int64        `json:"activityId"`
+	Name       string       `json:"name"`
+	Status     MirrorStatus `json:"status"`
+	Files      []string     `json:"files,omitempty"`
+	Reason     string       `json:"reason,omitempty"`
+}
+
+// MirrorManifest records the mirrored activities and their files
+type MirrorManifest struct {
+	Template   string                 `json:"template"`
+	Activities map[int64]*MirrorEntry `json:"activities"`
+}
+
+// MirrorEntry records one mirrored activity
+type MirrorEntry struct {
+	ActivityID int64     `json:"activityId"`
+	Name       string    `json:"name"`
+	Type       string    `json:"type"`
+	StartTime  time.Time `json:"startTime"`
+	// Fingerprint is a hash of the activity summary; it changes when the
+	// activity is edited on Garmin Connect
+	Fingerprint string       `json:"fingerprint"`
+	Files       []MirrorFile `json:"files"`
+	SyncedAt    time.Time    `json:"syncedAt"`
+}
+
+// MirrorFile records one downloaded file. Path is relative to the mirror
+// directory.
+type MirrorFile struct {
+	Format  string    `json:"format"`
+	Path    string    `json:"path"`
+	SHA256  string    `json:"sha256"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"modTime"`
+}
+
+// Mirror keeps a local directory in sync with the activities on Garmin
+// Connect. A manifest in the directory records every activity's files with
+// their checksums and modification times, so each run only downloads
+// activities that are new, were edited on Garmin Connect, or whose local
+// files are missing or modified.
+func (c *Client) Mirror(ctx context.Context, opts MirrorOptions) ([]MirrorResult, error) {
+	if opts.Template == "" {
+		opts.Template = DefaultMirrorTemplate
+	}
+	if len(opts.Formats) == 0 {
+		opts.Formats = []string{"fit"}
+	}
+	if err := validateMirrorOptions(opts); err != nil {
+		return nil, err
+	}
+
+	manifestPath := filepath.Join(opts.Dir, MirrorManifestFile)
+	manifest, err := loadMirrorManifest(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	manifest.Template = opts.Template
+
+	// List everything first so a failed listing never prunes the mirror
+	var activities []Activity
+	for activity, err := range c.Activities(ctx, opts.Filters) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list activities: %w", err)
+		}
+		activities = append(activities, activity)
+	}
+
+	var results []MirrorResult
+	report := func(result MirrorResult) {
+		results = append(results, result)
+		if opts.OnResult != nil {
+			opts.OnResult(result)
+		}
+	}
+
+	seen := map[int64]bool{}
+	for _, activity := range activities {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		seen[activity.ActivityID] = true
+
+		result := c.mirrorActivity(manifest, activity, opts)
+		report(result)
+		if result.Status == MirrorNew || result.Status == MirrorUpdated {
+			if err := manifest.save(manifestPath); err != nil {
+				return results, err
+			}
+		}
+	}
+
+	if opts.Prune {
+		for id, entry := range manifest.Activities {
+			if seen[id] || !inMirrorScope(entry, opts.Filters) {
+				continue
+			}
+			result := MirrorResult{ActivityID: id, Name: entry.Name, Status: MirrorPruned}
+			for _, f := range entry.Files {
+				path := filepath.Join(opts.Dir, f.Path)
+				if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+					result.Status = MirrorFailed
+					result.Reason = err.Error()
+					continue
+				}
+				removeEmptyDirs(opts.Dir, filepath.Dir(path))
+				result.Files = append(result.Files, f.Path)
+			}
+			if result.Status == MirrorPruned {
+				delete(manifest.Activities, id)
+			}
+			report(result)
+		}
+		if err := manifest.save(manifestPath); err != nil {
+			return results, err
+		}
+	}
+
+	return results, nil
+}
+
+// mirrorActivity downloads an activity unless its mirrored files are current
+func (c *Client) mirrorActivity(manifest *MirrorManifest, activity Activity, opts MirrorOptions) MirrorResult {
+	result := MirrorResult{ActivityID: activity.ActivityID, Name: activity.ActivityName}
+	fingerprint := activityFingerprint(activity)
+
+	expected := map[string]string{}
+	for _, format := range opts.Formats {
+		expected[format] = expandMirrorTemplate(opts.Template, activity, format)
+	}
+
+	entry, exists := manifest.Activities[activity.ActivityID]
+	if exists && entry.Fingerprint == fingerprint && mirrorFilesIntact(opts.Dir, entry, expected) {
+		result.Status = MirrorUnchanged
+		for _, f := range entry.Files {
+			result.Files = append(result.Files, f.Path)
+		}
+		return result
+	}
+
+	updated := &MirrorEntry{
+		ActivityID:  activity.ActivityID,
+		Name:        activity.ActivityName,
+		Type:        activity.ActivityType.TypeKey,
+		StartTime:   activity.StartTimeLocal.Time,
+		Fingerprint: fingerprint,
+		SyncedAt:    time.Now().UTC(),
+	}
+	for _, format := range opts.Formats {
+		path := filepath.Join(opts.Dir, expected[format])
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			result.Status, result.Reason = MirrorFailed, err.Error()
+			return result
+		}
+
+		paths, err := c.DownloadActivityFiles(int(activity.ActivityID), DownloadOptions{
+			Format:    format,
+			OutputDir: filepath.Dir(path),
+			Filename:  filepath.Base(path),
+		})
+		if err != nil {
+			result.Status, result.Reason = MirrorFailed, err.Error()
+			return result
+		}
+
+		for _, p := range paths {
+			file, err := statMirrorFile(opts.Dir, p)
+			if err != nil {
+				result.Status, result.Reason = MirrorFailed, err.Error()
+				return result
+			}
+			file.Format = format
+			updated.Files = append(updated.Files, file)
+			result.Files = append(result.Files, file.Path)
+		}
+	}
+
+	// Remove files the new layout no longer uses, e.g. after a type change
+	if exists {
+		for _, old := range entry.Files {
+			if !slices.ContainsFunc(updated.Files, func(f MirrorFile) bool { return f.Path == old.Path }) {
+				os.Remove(filepath.Join(opts.Dir, old.Path))
+				removeEmptyDirs(opts.Dir, filepath.Dir(filepath.Join(opts.Dir, old.Path)))
+			}
+		}
+	}
+
+	manifest.Activities[activity.ActivityID] = updated
+	result.Status = MirrorNew
+	if exists {
+		result.Status = MirrorUpdated
+	}
+	return result
+}
+
+func validateMirrorOptions(opts MirrorOptions) error {
+	invalid := func(field, message string) error {
+		return &errors.ValidationError{
+			GarthError: errors.GarthError{Message: message},
+			Field:      field,
+		}
+	}
+
+	if opts.Dir == "" {
+		return invalid("dir", "mirror directory is required")
+	}
+	for _, format := range opts.Formats {
+		if _, ok := downloadFormats[format]; !ok && format != "fit" {
+			return invalid("formats", fmt.Sprintf("unsupported download format: %s", format))
+		}
+	}
+	if !strings.Contains(opts.Template, "{id}") || !strings.Contains(opts.Template, "{ext}") {
+		return invalid("template", "template must contain {id} and {ext}")
+	}
+	if filepath.IsAbs(opts.Template) || slices.Contains(strings.Split(filepath.ToSlash(opts.Template), "/"), "..") {
+		return invalid("template", "template must be a relative path inside the mirror directory")
+	}
+	if opts.Prune && opts.Filters.ActivityType != "" {
+		return invalid("prune", "pruning cannot be combined with an activity type filter")
+	}
+	return nil
+}
+
+// unsafePathChars matches characters that are replaced in template values
+var unsafePathChars = regexp.MustCompile(`[^\p{L}\p{N}._-]+`)
+
+// expandMirrorTemplate returns the relative path of an activity file
+func expandMirrorTemplate(template string, activity Activity, ext string) string {
+	start := activity.StartTimeLocal.Time
+	clean := func(value string) string {
+		return strings.Trim(unsafePathChars.ReplaceAllString(value, "-"), "-.")
+	}
+	path := strings.NewReplacer(
+		"{id}", strconv.FormatInt(activity.ActivityID, 10),
+		"{year}", start.Format("2006"),
+		"{month}", start.Format("01"),
+		"{day}", start.Format("02"),
+		"{date}", start.Format("2006-01-02"),
+		"{type}", clean(activity.ActivityType.TypeKey),
+		"{name}", clean(activity.ActivityName),
+		"{ext}", ext,
+	).Replace(template)
+	return filepath.FromSlash(path)
+}
+
+// activityFingerprint hashes the activity summary returned by the list
+func activityFingerprint(activity Activity) string {
+	data, _ := json.Marshal(activity)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// mirrorFilesIntact reports whether every format has a file at its expected
+// path and each file is unchanged. Files whose size or modification time
+// differ are checked against their checksum.
+func mirrorFilesIntact(dir string, entry *MirrorEntry, expected map[string]string) bool {
+	for format, path := range expected {
+		var files []MirrorFile
+		for _, f := range entry.Files {
+			if f.Format == format {
+				files = append(files, f)
+			}
+		}
+		// Archives holding several files keep their own names
+		if len(files) == 0 || (len(files) == 1 && files[0].Path != filepath.ToSlash(path)) {
+			return false
+		}
+	}
+	for _, f := range entry.Files {
+		info, err := os.Stat(filepath.Join(dir, f.Path))
+		if err != nil || info.Size() != f.Size {
+			return false
+		}
+		if !info.ModTime().Equal(f.ModTime) {
+			hash, err := HashFile(filepath.Join(dir, f.Path))
+			if err != nil || hash != f.SHA256 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func statMirrorFile(dir, path string) (MirrorFile, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return MirrorFile{}, err
+	}
+	hash, err := HashFile(path)
+	if err != nil {
+		return MirrorFile{}, err
+	}
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return MirrorFile{}, err
+	}
+	return MirrorFile{
+		Path:    filepath.ToSlash(rel),
+		SHA256:  hash,
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+	}, nil
+}
+
+// inMirrorScope reports whether an entry would have been listed with the
+// filters, so its absence means the activity was deleted. Paged or searched
+// listings are incomplete and never prune.
+func inMirrorScope(entry *MirrorEntry, filters ActivityOptions) bool {
+	if filters.Limit > 0 || filters.Offset > 0 || filters.Search != "" {
+		return false
+	}
+	day := entry.StartTime.Format("2006-01-02")
+	if !filters.DateFrom.IsZero() && day < filters.DateFrom.Format("2006-01-02") {
+		return false
+	}
+	if !filters.DateTo.IsZero() && day > filters.DateTo.Format("2006-01-02") {
+		return false
+	}
+	return true
+}
+
+// removeEmptyDirs removes dir and its parents up to root while they are empty
+func removeEmptyDirs(root, dir string) {
+	root = filepath.Clean(root)
+	for dir = filepath.Clean(dir); dir != root && strings.HasPrefix(dir, root); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			return
+		}
+	}
+}
+
+func loadMirrorManifest(path string) (*MirrorManifest, error) {
+	manifest := &MirrorManifest{Activities: map[int64]*MirrorEntry{}}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mirror manifest: %w", err)
+	}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse mirror manifest %s: %w", path, err)
+	}
+	if manifest.Activities == nil {
+		manifest.Activities = map[int64]*MirrorEntry{}
+	}
+	return manifest, nil
+}
+
+func (m *MirrorManifest) save(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create mirror directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write mirror manifest: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write mirror manifest: %w", err)
+	}
+	return nil
+}
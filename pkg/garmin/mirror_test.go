@@ -0,0 +1,100 @@
+package garmin_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+func TestMirror_Incremental(t *testing.T) {
+	activities := []map[string]interface{}{
+		{"activityId": 1, "activityName": "Morning Run", "startTimeLocal": "2024-05-01T07:00:00", "activityType": map[string]interface{}{"typeKey": "running"}},
+		{"activityId": 2, "activityName": "Ride", "startTimeLocal": "2024-06-02T18:00:00", "activityType": map[string]interface{}{"typeKey": "cycling"}},
+	}
+	var downloads []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/activitylist-service/"):
+			page := activities
+			if r.URL.Query().Get("start") != "0" {
+				page = nil
+			}
+			json.NewEncoder(w).Encode(page)
+		case strings.HasPrefix(r.URL.Path, "/download-service/export/gpx/activity/"):
+			downloads = append(downloads, r.URL.Path)
+			w.Header().Set("Content-Type", "application/gpx+xml")
+			w.Write([]byte(`<?xml version="1.0"?><gpx></gpx>`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	client := newTestClient(t, server)
+
+	dir := t.TempDir()
+	opts := garmin.MirrorOptions{Dir: dir, Formats: []string{"gpx"}, Prune: true}
+	statuses := func(results []garmin.MirrorResult) map[int64]garmin.MirrorStatus {
+		m := map[int64]garmin.MirrorStatus{}
+		for _, r := range results {
+			m[r.ActivityID] = r.Status
+		}
+		return m
+	}
+
+	results, err := client.Mirror(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]garmin.MirrorStatus{1: garmin.MirrorNew, 2: garmin.MirrorNew}, statuses(results))
+	runPath := filepath.Join(dir, "2024", "05", "2024-05-01_running_1.gpx")
+	assert.FileExists(t, runPath)
+	assert.FileExists(t, filepath.Join(dir, garmin.MirrorManifestFile))
+
+	// A second run downloads nothing
+	downloads = nil
+	results, err = client.Mirror(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]garmin.MirrorStatus{1: garmin.MirrorUnchanged, 2: garmin.MirrorUnchanged}, statuses(results))
+	assert.Empty(t, downloads)
+
+	// Renamed on Garmin Connect, modified locally, deleted on Garmin Connect
+	activities[0]["activityName"] = "Tempo Run"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "2024", "06", "2024-06-02_cycling_2.gpx"), []byte("edited"), 0644))
+	results, err = client.Mirror(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]garmin.MirrorStatus{1: garmin.MirrorUpdated, 2: garmin.MirrorUpdated}, statuses(results))
+
+	activities = activities[1:]
+	results, err = client.Mirror(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]garmin.MirrorStatus{1: garmin.MirrorPruned, 2: garmin.MirrorUnchanged}, statuses(results))
+	assert.NoFileExists(t, runPath)
+	assert.NoDirExists(t, filepath.Join(dir, "2024", "05"))
+}
+
+func TestMirror_Template(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(t, server).Mirror(context.Background(), garmin.MirrorOptions{
+		Dir:      t.TempDir(),
+		Template: "{date}.{ext}",
+	})
+	assert.ErrorContains(t, err, "{id}")
+
+	_, err = newTestClient(t, server).Mirror(context.Background(), garmin.MirrorOptions{
+		Dir:      t.TempDir(),
+		Template: "../{id}.{ext}",
+	})
+	assert.ErrorContains(t, err, "inside the mirror directory")
+}
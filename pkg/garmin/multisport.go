@@ -0,0 +1,115 @@
+package garmin
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MultisportMode selects how ListActivities returns multisport activities
+type MultisportMode string
+
+const (
+	// MultisportParents lists the parent activity only, as Garmin Connect does
+	MultisportParents MultisportMode = ""
+	// MultisportGroup lists each parent followed by its legs
+	MultisportGroup MultisportMode = "group"
+	// MultisportFlatten replaces each parent by its legs
+	MultisportFlatten MultisportMode = "flatten"
+)
+
+// IsTransition reports whether an activity is a transition between the legs
+// of a multisport activity
+func IsTransition(activity Activity) bool {
+	return strings.Contains(activity.ActivityType.TypeKey, "transition")
+}
+
+// LegNames names the legs of a multisport activity: "swim", "bike" and "run"
+// for those sports, T1, T2 and so on for transitions, and the activity type
+// otherwise
+func LegNames(legs []Activity) []string {
+	names := make([]string, len(legs))
+	transitions := 0
+	for i, leg := range legs {
+		key := leg.ActivityType.TypeKey
+		switch {
+		case IsTransition(leg):
+			transitions++
+			names[i] = fmt.Sprintf("T%d", transitions)
+		case strings.Contains(key, "swim"):
+			names[i] = "swim"
+		case strings.Contains(key, "cycling") || strings.Contains(key, "biking"):
+			names[i] = "bike"
+		case strings.Contains(key, "running"):
+			names[i] = "run"
+		default:
+			names[i] = key
+		}
+	}
+	return names
+}
+
+// MultisportLegs retrieves the legs of a multisport activity in the order
+// they were done. The legs have ParentID set to the parent's ID.
+func (c *Client) MultisportLegs(parent Activity) ([]Activity, error) {
+	childIDs := parent.ChildIDs
+	if len(childIDs) == 0 {
+		// Activity list entries do not always carry the child IDs
+		details, err := c.Client.GetActivity(parent.ActivityID)
+		if err != nil {
+			return nil, err
+		}
+		childIDs = details.MetadataDTO.ChildIDs
+	}
+
+	legs := make([]Activity, 0, len(childIDs))
+	for _, id := range childIDs {
+		details, err := c.Client.GetActivity(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get leg %d of activity %d: %w", id, parent.ActivityID, err)
+		}
+		leg := activityFromDetails(details)
+		parentID := parent.ActivityID
+		leg.ParentID = &parentID
+		legs = append(legs, leg)
+	}
+	sort.SliceStable(legs, func(i, j int) bool {
+		return legs[i].StartTimeGMT.Before(legs[j].StartTimeGMT.Time)
+	})
+	return legs, nil
+}
+
+// expandMultisport groups or flattens the multisport activities of a list
+func (c *Client) expandMultisport(activities []Activity, mode MultisportMode) ([]Activity, error) {
+	if mode == MultisportParents {
+		return activities, nil
+	}
+
+	expanded := make([]Activity, 0, len(activities))
+	for _, activity := range activities {
+		if !activity.IsMultiSportParent {
+			expanded = append(expanded, activity)
+			continue
+		}
+		legs, err := c.MultisportLegs(activity)
+		if err != nil {
+			return nil, err
+		}
+		if mode == MultisportGroup {
+			expanded = append(expanded, activity)
+		}
+		expanded = append(expanded, legs...)
+	}
+	return expanded, nil
+}
+
+// validateMultisportMode checks the multisport mode of ActivityOptions
+func validateMultisportMode(mode MultisportMode) error {
+	switch mode {
+	case MultisportParents, MultisportGroup, MultisportFlatten:
+		return nil
+	default:
+		return unknownKey("multisport", "multisport mode", string(mode),
+			[]string{string(MultisportGroup), string(MultisportFlatten)})
+	}
+}
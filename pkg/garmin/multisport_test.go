@@ -0,0 +1,104 @@
+package garmin_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+// newMultisportServer serves a triathlon (100) with its legs, listed out of
+// order, and a single-sport run (200)
+func newMultisportServer(t *testing.T) *httptest.Server {
+	legs := map[string]string{
+		"101": `"typeKey": "open_water_swimming"}, "summaryDTO": {"startTimeGMT": "2024-06-01T07:00:00.0", "distance": 1500`,
+		"102": `"typeKey": "transition_v2"}, "summaryDTO": {"startTimeGMT": "2024-06-01T07:30:00.0"`,
+		"103": `"typeKey": "road_biking"}, "summaryDTO": {"startTimeGMT": "2024-06-01T07:33:00.0", "distance": 40000`,
+		"104": `"typeKey": "transition_v2"}, "summaryDTO": {"startTimeGMT": "2024-06-01T08:40:00.0"`,
+		"105": `"typeKey": "running"}, "summaryDTO": {"startTimeGMT": "2024-06-01T08:42:00.0", "distance": 10000`,
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch path := r.URL.Path; path {
+		case "/activitylist-service/activities/search/activities":
+			w.Write([]byte(`[
+				{"activityId": 100, "activityName": "Olympic Tri", "activityType": {"typeKey": "multi_sport"}, "isMultiSportParent": true},
+				{"activityId": 200, "activityName": "Easy Run", "activityType": {"typeKey": "running"}}
+			]`))
+		case "/activity-service/activity/100":
+			w.Write([]byte(`{"activityId": 100, "activityName": "Olympic Tri",
+				"activityTypeDTO": {"typeKey": "multi_sport"},
+				"metadataDTO": {"isMultiSportParent": true, "childIds": [105, 101, 102, 103, 104]}}`))
+		case "/activity-service/activity/100/splits":
+			w.Write([]byte(`{"activityId": 100, "lapDTOs": []}`))
+		default:
+			for id, leg := range legs {
+				switch path {
+				case "/activity-service/activity/" + id:
+					fmt.Fprintf(w, `{"activityId": %s, "activityTypeDTO": {%s}}`, id, leg)
+					return
+				case "/activity-service/activity/" + id + "/splits":
+					fmt.Fprintf(w, `{"activityId": %s, "lapDTOs": []}`, id)
+					return
+				}
+			}
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestGetActivity_MultisportLegs(t *testing.T) {
+	server := newMultisportServer(t)
+	defer server.Close()
+
+	detail, err := newTestClient(t, server).GetActivity(100)
+	require.NoError(t, err)
+	assert.True(t, detail.IsMultiSportParent)
+	require.Len(t, detail.Children, 5)
+
+	var legs []string
+	for _, child := range detail.Children {
+		legs = append(legs, child.Leg)
+		require.NotNil(t, child.ParentID)
+		assert.Equal(t, int64(100), *child.ParentID)
+	}
+	assert.Equal(t, []string{"swim", "T1", "bike", "T2", "run"}, legs)
+	assert.Equal(t, int64(101), detail.Children[0].ActivityID)
+	assert.Equal(t, 40000.0, detail.Children[2].Distance)
+}
+
+func TestListActivities_Multisport(t *testing.T) {
+	server := newMultisportServer(t)
+	defer server.Close()
+	client := newTestClient(t, server)
+
+	ids := func(activities []garmin.Activity) []int64 {
+		var ids []int64
+		for _, activity := range activities {
+			ids = append(ids, activity.ActivityID)
+		}
+		return ids
+	}
+
+	activities, err := client.ListActivities(garmin.ActivityOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{100, 200}, ids(activities))
+
+	activities, err = client.ListActivities(garmin.ActivityOptions{Multisport: garmin.MultisportGroup})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{100, 101, 102, 103, 104, 105, 200}, ids(activities))
+	assert.Nil(t, activities[0].ParentID)
+	assert.Equal(t, int64(100), *activities[1].ParentID)
+
+	activities, err = client.ListActivities(garmin.ActivityOptions{Multisport: garmin.MultisportFlatten})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{101, 102, 103, 104, 105, 200}, ids(activities))
+
+	_, err = client.ListActivities(garmin.ActivityOptions{Multisport: "nested"})
+	assert.ErrorContains(t, err, "unknown multisport mode")
+}
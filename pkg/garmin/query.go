@@ -0,0 +1,387 @@
+package garmin
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/sstent/go-garth/errors"
+)
+
+// ActivityQuery is a parsed activity search query.
+//
+// A query is a whitespace-separated list of terms that must all match:
+//
+//	type:running distance>10km date:2024-05.. name~"tempo" hr_avg<150
+//
+// Each term is field, operator and value. Supported fields:
+//
+//	type       activity type key (:, =, !=)
+//	name       activity name (~ contains, :, = and != compare case-insensitively)
+//	date       start date as YYYY, YYYY-MM or YYYY-MM-DD, or a range A..B, A.. or ..B
+//	           (:, =, >, >=, <, <=)
+//	distance   m, km or mi; plain numbers are kilometers
+//	duration   s, min or h, or h:mm:ss; plain numbers are minutes
+//	hr_avg     average heart rate in bpm
+//	hr_max     maximum heart rate in bpm
+//	calories   kilocalories
+//	elevation  elevation gain in meters
+//
+// Numeric fields accept :, =, !=, >, >=, < and <=. Values containing spaces
+// can be quoted. A term without an operator matches names containing it.
+//
+// Activity type, date and name~ terms are pushed down to the activity search
+// endpoint through Options; all other terms are evaluated client-side by Match.
+type ActivityQuery struct {
+	Options    ActivityOptions
+	conditions []queryCondition
+}
+
+type queryCondition struct {
+	field string
+	op    string
+	text  string
+	num   float64
+	from  time.Time // inclusive, zero if unbounded
+	to    time.Time // exclusive, zero if unbounded
+}
+
+// numericFields maps numeric query fields onto activity values
+var numericFields = map[string]struct {
+	parse func(string) (float64, error)
+	value func(Activity) float64
+}{
+	"distance":  {parseQueryDistance, func(a Activity) float64 { return a.Distance }},
+	"duration":  {parseQueryDuration, func(a Activity) float64 { return a.Duration }},
+	"hr_avg":    {parseQueryNumber, func(a Activity) float64 { return a.AverageHR }},
+	"hr_max":    {parseQueryNumber, func(a Activity) float64 { return a.MaxHR }},
+	"calories":  {parseQueryNumber, func(a Activity) float64 { return a.Calories }},
+	"elevation": {parseQueryNumber, func(a Activity) float64 { return a.ElevationGain }},
+}
+
+// queryOperators is ordered so that two-character operators match first
+var queryOperators = []string{">=", "<=", "!=", ":", "=", ">", "<", "~"}
+
+// ParseActivityQuery parses a search query into server-side options and
+// client-side conditions
+func ParseActivityQuery(query string) (*ActivityQuery, error) {
+	terms, err := splitQueryTerms(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(terms) == 0 {
+		return nil, queryError("", "query is empty")
+	}
+
+	q := &ActivityQuery{}
+	for _, term := range terms {
+		cond, err := parseQueryTerm(term)
+		if err != nil {
+			return nil, err
+		}
+		if q.pushDown(cond) {
+			continue
+		}
+		q.conditions = append(q.conditions, cond)
+	}
+	return q, nil
+}
+
+// pushDown moves a condition into the server-side options when the search
+// endpoint supports it and reports whether the condition was consumed
+func (q *ActivityQuery) pushDown(cond queryCondition) bool {
+	switch cond.field {
+	case "type":
+		if (cond.op == ":" || cond.op == "=") && q.Options.ActivityType == "" {
+			q.Options.ActivityType = cond.text
+			return true
+		}
+	case "date":
+		if !cond.from.IsZero() && cond.from.After(q.Options.DateFrom) {
+			q.Options.DateFrom = cond.from
+		}
+		if !cond.to.IsZero() {
+			// DateTo is inclusive, the condition bound is exclusive
+			last := cond.to.AddDate(0, 0, -1)
+			if q.Options.DateTo.IsZero() || last.Before(q.Options.DateTo) {
+				q.Options.DateTo = last
+			}
+		}
+		return true
+	case "name":
+		// The server-side search is a hint; the condition is still checked by Match
+		if cond.op == "~" && q.Options.Search == "" {
+			q.Options.Search = cond.text
+		}
+	}
+	return false
+}
+
+// Match reports whether an activity satisfies the client-side conditions of
+// the query
+func (q *ActivityQuery) Match(a Activity) bool {
+	for _, cond := range q.conditions {
+		if !cond.match(a) {
+			return false
+		}
+	}
+	return true
+}
+
+func (cond queryCondition) match(a Activity) bool {
+	switch cond.field {
+	case "type":
+		equal := strings.EqualFold(a.ActivityType.TypeKey, cond.text)
+		if cond.op == "!=" {
+			return !equal
+		}
+		return equal
+	case "name":
+		name := strings.ToLower(a.ActivityName)
+		text := strings.ToLower(cond.text)
+		switch cond.op {
+		case "~":
+			return strings.Contains(name, text)
+		case "!=":
+			return name != text
+		default:
+			return name == text
+		}
+	}
+
+	field, ok := numericFields[cond.field]
+	if !ok {
+		return false
+	}
+	v := field.value(a)
+	switch cond.op {
+	case ">":
+		return v > cond.num
+	case ">=":
+		return v >= cond.num
+	case "<":
+		return v < cond.num
+	case "<=":
+		return v <= cond.num
+	case "!=":
+		return v != cond.num
+	default:
+		return v == cond.num
+	}
+}
+
+func parseQueryTerm(term string) (queryCondition, error) {
+	end := strings.IndexFunc(term, func(r rune) bool {
+		return !(unicode.IsLetter(r) || r == '_')
+	})
+	if end <= 0 {
+		return queryCondition{field: "name", op: "~", text: unquote(term)}, nil
+	}
+
+	field := strings.ToLower(term[:end])
+	rest := term[end:]
+	op := ""
+	for _, candidate := range queryOperators {
+		if strings.HasPrefix(rest, candidate) {
+			op = candidate
+			break
+		}
+	}
+	if op == "" {
+		return queryCondition{field: "name", op: "~", text: unquote(term)}, nil
+	}
+	value := unquote(rest[len(op):])
+	if value == "" {
+		return queryCondition{}, queryError(field, "missing value")
+	}
+
+	cond := queryCondition{field: field, op: op}
+	switch field {
+	case "type":
+		if op != ":" && op != "=" && op != "!=" {
+			return cond, queryError(field, fmt.Sprintf("unsupported operator %s", op))
+		}
+		cond.text = strings.ToLower(value)
+	case "name":
+		if op != ":" && op != "=" && op != "!=" && op != "~" {
+			return cond, queryError(field, fmt.Sprintf("unsupported operator %s", op))
+		}
+		cond.text = value
+	case "date":
+		from, to, err := parseQueryDateRange(op, value)
+		if err != nil {
+			return cond, queryError(field, err.Error())
+		}
+		cond.from, cond.to = from, to
+	default:
+		numeric, ok := numericFields[field]
+		if !ok {
+			return cond, queryError(field, "unknown field")
+		}
+		if op == "~" {
+			return cond, queryError(field, fmt.Sprintf("unsupported operator %s", op))
+		}
+		num, err := numeric.parse(value)
+		if err != nil {
+			return cond, queryError(field, err.Error())
+		}
+		cond.num = num
+	}
+	return cond, nil
+}
+
+// parseQueryDateRange converts a date term into a [from, to) range
+func parseQueryDateRange(op, value string) (time.Time, time.Time, error) {
+	if op == "~" || op == "!=" {
+		return time.Time{}, time.Time{}, fmt.Errorf("unsupported operator %s", op)
+	}
+
+	if op == ":" || op == "=" {
+		if lo, hi, ok := strings.Cut(value, ".."); ok {
+			var from, to time.Time
+			if lo != "" {
+				start, _, err := parseQueryDate(lo)
+				if err != nil {
+					return from, to, err
+				}
+				from = start
+			}
+			if hi != "" {
+				_, end, err := parseQueryDate(hi)
+				if err != nil {
+					return from, to, err
+				}
+				to = end
+			}
+			if from.IsZero() && to.IsZero() {
+				return from, to, fmt.Errorf("empty date range")
+			}
+			return from, to, nil
+		}
+	}
+
+	start, end, err := parseQueryDate(value)
+	if err != nil {
+		return start, end, err
+	}
+	switch op {
+	case ">":
+		return end, time.Time{}, nil
+	case ">=":
+		return start, time.Time{}, nil
+	case "<":
+		return time.Time{}, start, nil
+	case "<=":
+		return time.Time{}, end, nil
+	default:
+		return start, end, nil
+	}
+}
+
+// parseQueryDate parses a year, month or day and returns the period it covers
+func parseQueryDate(value string) (time.Time, time.Time, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, t.AddDate(0, 0, 1), nil
+	}
+	if t, err := time.Parse("2006-01", value); err == nil {
+		return t, t.AddDate(0, 1, 0), nil
+	}
+	if t, err := time.Parse("2006", value); err == nil {
+		return t, t.AddDate(1, 0, 0), nil
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("invalid date %q (expected YYYY, YYYY-MM or YYYY-MM-DD)", value)
+}
+
+// parseQueryDistance parses a distance in meters
+func parseQueryDistance(value string) (float64, error) {
+	units := []struct {
+		suffix string
+		meters float64
+	}{{"km", 1000}, {"mi", 1609.344}, {"m", 1}}
+	for _, unit := range units {
+		if strings.HasSuffix(value, unit.suffix) {
+			n, err := parseQueryNumber(strings.TrimSuffix(value, unit.suffix))
+			return n * unit.meters, err
+		}
+	}
+	n, err := parseQueryNumber(value)
+	return n * 1000, err
+}
+
+// parseQueryDuration parses a duration in seconds
+func parseQueryDuration(value string) (float64, error) {
+	if strings.Contains(value, ":") {
+		var seconds float64
+		for _, part := range strings.Split(value, ":") {
+			n, err := parseQueryNumber(part)
+			if err != nil {
+				return 0, err
+			}
+			seconds = seconds*60 + n
+		}
+		return seconds, nil
+	}
+	units := []struct {
+		suffix  string
+		seconds float64
+	}{{"min", 60}, {"h", 3600}, {"s", 1}}
+	for _, unit := range units {
+		if strings.HasSuffix(value, unit.suffix) {
+			n, err := parseQueryNumber(strings.TrimSuffix(value, unit.suffix))
+			return n * unit.seconds, err
+		}
+	}
+	n, err := parseQueryNumber(value)
+	return n * 60, err
+}
+
+func parseQueryNumber(value string) (float64, error) {
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", value)
+	}
+	return n, nil
+}
+
+// splitQueryTerms splits a query on whitespace, keeping quoted values intact
+func splitQueryTerms(query string) ([]string, error) {
+	var terms []string
+	var term strings.Builder
+	inQuotes := false
+	for _, r := range query {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			term.WriteRune(r)
+		case unicode.IsSpace(r) && !inQuotes:
+			if term.Len() > 0 {
+				terms = append(terms, term.String())
+				term.Reset()
+			}
+		default:
+			term.WriteRune(r)
+		}
+	}
+	if inQuotes {
+		return nil, queryError("", "unterminated quote")
+	}
+	if term.Len() > 0 {
+		terms = append(terms, term.String())
+	}
+	return terms, nil
+}
+
+func unquote(value string) string {
+	return strings.ReplaceAll(value, `"`, "")
+}
+
+func queryError(field, message string) error {
+	return &errors.ValidationError{
+		GarthError: errors.GarthError{
+			Message: message,
+		},
+		Field: field,
+	}
+}
@@ -0,0 +1,127 @@
+package garmin_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+func TestParseActivityQuery_PushDown(t *testing.T) {
+	q, err := garmin.ParseActivityQuery(`type:running distance>10km date:2024-05.. name~"tempo run" hr_avg<150`)
+	require.NoError(t, err)
+
+	assert.Equal(t, "running", q.Options.ActivityType)
+	assert.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), q.Options.DateFrom)
+	assert.True(t, q.Options.DateTo.IsZero())
+	assert.Equal(t, "tempo run", q.Options.Search)
+}
+
+func TestParseActivityQuery_DateRanges(t *testing.T) {
+	tests := []struct {
+		query    string
+		from, to string
+	}{
+		{"date:2024-05", "2024-05-01", "2024-05-31"},
+		{"date:2023..2024-02", "2023-01-01", "2024-02-29"},
+		{"date:..2024-03-10", "", "2024-03-10"},
+		{"date>2024-03-10", "2024-03-11", ""},
+		{"date<2024", "", "2023-12-31"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := garmin.ParseActivityQuery(tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.from, formatOptionalDate(q.Options.DateFrom))
+			assert.Equal(t, tt.to, formatOptionalDate(q.Options.DateTo))
+		})
+	}
+}
+
+func formatOptionalDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+func TestActivityQuery_Match(t *testing.T) {
+	run := garmin.Activity{
+		ActivityName:  "Tempo Run",
+		ActivityType:  garmin.ActivityType{TypeKey: "running"},
+		Distance:      12000,
+		Duration:      3300,
+		AverageHR:     148,
+		ElevationGain: 120,
+	}
+
+	tests := []struct {
+		query string
+		match bool
+	}{
+		{"distance>10km", true},
+		{"distance>10mi", false},
+		{"distance>=12000m", true},
+		{"duration<1h", true},
+		{"duration>0:50:00", true},
+		{"hr_avg<150", true},
+		{"hr_avg<140", false},
+		{`name~"tempo"`, true},
+		{"name~easy", false},
+		{"tempo", true},
+		{"type!=cycling", true},
+		{"elevation>=100 hr_max=0", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := garmin.ParseActivityQuery(tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.match, q.Match(run))
+		})
+	}
+}
+
+func TestParseActivityQuery_Errors(t *testing.T) {
+	for _, query := range []string{
+		"",
+		"pace<5",
+		"distance>far",
+		"date:2024-13",
+		"type>running",
+		`name~"unterminated`,
+		"hr_avg<",
+	} {
+		_, err := garmin.ParseActivityQuery(query)
+		assert.Error(t, err, query)
+	}
+}
+
+func TestSearchActivities(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{"activityId": 1, "activityName": "Tempo", "distance": 12000, "averageHR": 145},
+			{"activityId": 2, "activityName": "Tempo", "distance": 8000, "averageHR": 140},
+			{"activityId": 3, "activityName": "Tempo", "distance": 15000, "averageHR": 160}
+		]`))
+	}))
+	defer server.Close()
+
+	activities, err := newTestClient(t, server).SearchActivities(`type:running date:2024 distance>10km hr_avg<150 name~tempo`)
+	require.NoError(t, err)
+	require.Len(t, activities, 1)
+	assert.Equal(t, int64(1), activities[0].ActivityID)
+
+	assert.Equal(t, "running", query.Get("activityType"))
+	assert.Equal(t, "2024-01-01", query.Get("startDate"))
+	assert.Equal(t, "2024-12-31", query.Get("endDate"))
+	assert.Equal(t, "tempo", query.Get("search"))
+}
@@ -0,0 +1,224 @@
+package garmin
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// RecordKind is the quantity a personal record measures
+type RecordKind string
+
+const (
+	RecordTime      RecordKind = "time"      // seconds, lower is better
+	RecordDistance  RecordKind = "distance"  // meters
+	RecordElevation RecordKind = "elevation" // meters
+	RecordPower     RecordKind = "power"     // watts
+	RecordCount     RecordKind = "count"     // steps or days
+)
+
+// recordTypes names the personal record types by ID
+var recordTypes = map[int]struct {
+	name string
+	kind RecordKind
+}{
+	1:  {"1K", RecordTime},
+	2:  {"1 mile", RecordTime},
+	3:  {"5K", RecordTime},
+	4:  {"10K", RecordTime},
+	7:  {"Longest run", RecordDistance},
+	8:  {"Longest ride", RecordDistance},
+	9:  {"Total ascent", RecordElevation},
+	10: {"Max avg power (20 min)", RecordPower},
+	12: {"Most steps in a day", RecordCount},
+	13: {"Most steps in a week", RecordCount},
+	14: {"Most steps in a month", RecordCount},
+	15: {"Longest goal streak", RecordCount},
+	16: {"Half marathon", RecordTime},
+	17: {"Marathon", RecordTime},
+}
+
+// Record is a personal record with its type resolved
+type Record struct {
+	TypeID       int        `json:"typeId"`
+	Name         string     `json:"name"`
+	Kind         RecordKind `json:"kind,omitempty"`
+	Value        float64    `json:"value"`
+	ActivityID   int64      `json:"activityId,omitempty"`
+	ActivityName string     `json:"activityName,omitempty"`
+	Date         time.Time  `json:"date"`
+}
+
+// GetRecords retrieves the user's personal records, ordered by type
+func (c *Client) GetRecords() ([]Record, error) {
+	profile, err := c.GetUserProfile()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user profile: %w", err)
+	}
+	prs, err := c.GetPersonalRecords(profile.DisplayName)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]Record, len(prs))
+	for i, pr := range prs {
+		record := Record{
+			TypeID:       pr.TypeID,
+			Name:         fmt.Sprintf("Record type %d", pr.TypeID),
+			Value:        pr.Value,
+			ActivityID:   pr.ActivityID,
+			ActivityName: pr.ActivityName,
+		}
+		if t, ok := recordTypes[pr.TypeID]; ok {
+			record.Name, record.Kind = t.name, t.kind
+		} else if pr.PRTypeLabelKey != nil && *pr.PRTypeLabelKey != "" {
+			record.Name = *pr.PRTypeLabelKey
+		}
+		switch {
+		case pr.PRStartTimeGMT != nil:
+			record.Date = time.UnixMilli(*pr.PRStartTimeGMT).UTC()
+		case pr.ActivityStartDateTimeGMT != nil:
+			record.Date = time.UnixMilli(*pr.ActivityStartDateTimeGMT).UTC()
+		}
+		records[i] = record
+	}
+	sort.SliceStable(records, func(i, j int) bool { return records[i].TypeID < records[j].TypeID })
+	return records, nil
+}
+
+// Effort is the best effort within an activity over a fixed distance or
+// duration
+type Effort struct {
+	ActivityID   int64     `json:"activityId"`
+	ActivityName string    `json:"activityName,omitempty"`
+	Start        time.Time `json:"start"`
+	Seconds      float64   `json:"seconds"`
+	Distance     float64   `json:"distance,omitempty"` // meters
+	Average      float64   `json:"average,omitempty"`  // mean of the metric over a duration effort
+}
+
+// movingTimes returns the moving time in seconds up to each of the first n
+// samples. Intervals longer than maxSampleGap, such as stops with the
+// recording paused, and intervals around samples without a timestamp do not
+// count.
+func movingTimes(samples *ActivitySamples, n int) []float64 {
+	moving := make([]float64, n)
+	for i := 1; i < n; i++ {
+		moving[i] = moving[i-1]
+		t0, t1 := samples.Timestamps[i-1], samples.Timestamps[i]
+		if t0.IsZero() || t1.IsZero() {
+			continue
+		}
+		if gap := t1.Sub(t0); gap > 0 && gap <= maxSampleGap {
+			moving[i] += gap.Seconds()
+		}
+	}
+	return moving
+}
+
+// BestDistanceEffort returns the fastest stretch of an activity covering
+// meters, timed by moving time so that stops do not count. ok is false when
+// the activity is shorter than meters or has no distance samples.
+func BestDistanceEffort(samples *ActivitySamples, meters float64) (effort Effort, ok bool) {
+	if meters <= 0 {
+		return Effort{}, false
+	}
+	moving := movingTimes(samples, samples.Len())
+
+	// Samples with a timestamp and distance, in order
+	var idx []int
+	for i := 0; i < samples.Len() && i < len(samples.Distance); i++ {
+		if !samples.Timestamps[i].IsZero() && !math.IsNaN(samples.Distance[i]) {
+			idx = append(idx, i)
+		}
+	}
+
+	best := math.Inf(1)
+	start := 0
+	for end := range idx {
+		// Advance the start while the stretch still covers the distance
+		for start+1 < end && samples.Distance[idx[end]]-samples.Distance[idx[start+1]] >= meters {
+			start++
+		}
+		covered := samples.Distance[idx[end]] - samples.Distance[idx[start]]
+		if covered < meters {
+			continue
+		}
+		// Scale to the exact distance, as samples rarely fall on it
+		seconds := (moving[idx[end]] - moving[idx[start]]) * meters / covered
+		if seconds > 0 && seconds < best {
+			best = seconds
+			effort = Effort{
+				ActivityID: samples.ActivityID,
+				Start:      samples.Timestamps[idx[start]],
+				Seconds:    seconds,
+				Distance:   meters,
+			}
+		}
+	}
+	return effort, !math.IsInf(best, 1)
+}
+
+// BestDurationEffort returns the stretch of an activity with at least d of
+// moving time and the highest average of values, for example the best
+// 20-minute power. Each sample counts until the next one and missing values
+// count as zero; gaps longer than maxSampleGap, such as stops, are left out.
+// ok is false when the activity has less than d of moving time or values
+// holds no readings.
+func BestDurationEffort(samples *ActivitySamples, values Series, d time.Duration) (effort Effort, ok bool) {
+	n := min(samples.Len(), len(values))
+	if d <= 0 || n < 2 {
+		return Effort{}, false
+	}
+	moving := movingTimes(samples, n)
+
+	// sums[i] is the integral of values over moving time up to sample i
+	sums := make([]float64, n)
+	recorded := false
+	for i := 1; i < n; i++ {
+		sums[i] = sums[i-1]
+		v := values[i-1]
+		dt := moving[i] - moving[i-1]
+		if math.IsNaN(v) || dt == 0 {
+			continue
+		}
+		recorded = true
+		sums[i] += v * dt
+	}
+	if !recorded {
+		return Effort{}, false
+	}
+
+	best := math.Inf(-1)
+	end := 0
+	for start := 0; start < n; start++ {
+		if samples.Timestamps[start].IsZero() {
+			continue
+		}
+		if end < start {
+			end = start
+		}
+		for end < n && moving[end]-moving[start] < d.Seconds() {
+			end++
+		}
+		if end == n {
+			break
+		}
+		seconds := moving[end] - moving[start]
+		average := (sums[end] - sums[start]) / seconds
+		if average > best {
+			best = average
+			effort = Effort{
+				ActivityID: samples.ActivityID,
+				Start:      samples.Timestamps[start],
+				Seconds:    seconds,
+				Average:    average,
+			}
+			if end < len(samples.Distance) && !math.IsNaN(samples.Distance[start]) && !math.IsNaN(samples.Distance[end]) {
+				effort.Distance = samples.Distance[end] - samples.Distance[start]
+			}
+		}
+	}
+	return effort, !math.IsInf(best, -1)
+}
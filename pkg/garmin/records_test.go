@@ -0,0 +1,119 @@
+package garmin_test
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+func TestGetRecords(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/userprofile-service/socialProfile":
+			w.Write([]byte(`{"userName": "runner@example.com", "displayName": "runner42"}`))
+		case "/personalrecord-service/personalrecord/prs/runner42":
+			w.Write([]byte(`[
+				{"id": 2, "typeId": 99, "activityId": 0, "value": 12, "prTypeLabelKey": "pr.label.custom"},
+				{"id": 1, "typeId": 3, "activityId": 555, "activityName": "Parkrun", "value": 1234.5, "prStartTimeGmt": 1714546800000}
+			]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	records, err := newTestClient(t, server).GetRecords()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	assert.Equal(t, "5K", records[0].Name)
+	assert.Equal(t, garmin.RecordTime, records[0].Kind)
+	assert.Equal(t, int64(555), records[0].ActivityID)
+	assert.Equal(t, time.Date(2024, 5, 1, 7, 0, 0, 0, time.UTC), records[0].Date)
+
+	assert.Equal(t, "pr.label.custom", records[1].Name)
+	assert.True(t, records[1].Date.IsZero())
+}
+
+// effortSamples returns samples one second apart with the given distances and
+// power values
+func effortSamples(distance, power garmin.Series) *garmin.ActivitySamples {
+	start := time.Date(2024, 3, 1, 7, 0, 0, 0, time.UTC)
+	samples := &garmin.ActivitySamples{ActivityID: 7, Distance: distance, Power: power}
+	for i := range distance {
+		samples.Timestamps = append(samples.Timestamps, start.Add(time.Duration(i)*time.Second))
+	}
+	return samples
+}
+
+func TestBestDistanceEffort(t *testing.T) {
+	// 3 m/s, then 5 m/s from the fourth second
+	samples := effortSamples(garmin.Series{0, 3, 6, 9, 14, 19, 24, math.NaN(), 29}, nil)
+
+	effort, ok := garmin.BestDistanceEffort(samples, 10)
+	require.True(t, ok)
+	assert.Equal(t, int64(7), effort.ActivityID)
+	assert.InDelta(t, 2.0, effort.Seconds, 1e-9)
+	assert.Equal(t, samples.Timestamps[3], effort.Start)
+
+	_, ok = garmin.BestDistanceEffort(samples, 100)
+	assert.False(t, ok)
+}
+
+func TestBestDurationEffort(t *testing.T) {
+	samples := effortSamples(
+		garmin.Series{0, 1, 2, 3, 4, 5, 6},
+		garmin.Series{100, 100, 300, 320, math.NaN(), 150, 150},
+	)
+
+	effort, ok := garmin.BestDurationEffort(samples, samples.Power, 2*time.Second)
+	require.True(t, ok)
+	assert.Equal(t, samples.Timestamps[2], effort.Start)
+	assert.Equal(t, 2.0, effort.Seconds)
+	assert.Equal(t, 310.0, effort.Average)
+	assert.Equal(t, 2.0, effort.Distance)
+
+	_, ok = garmin.BestDurationEffort(samples, samples.Power, time.Minute)
+	assert.False(t, ok)
+}
+
+func TestBestEfforts_SkipPauses(t *testing.T) {
+	// 10 minutes at 200 W and 5 m/s, a 20-minute stop, then 5 minutes at
+	// 100 W and 2 m/s
+	start := time.Date(2024, 3, 1, 7, 0, 0, 0, time.UTC)
+	samples := &garmin.ActivitySamples{ActivityID: 7}
+	add := func(at time.Duration, distance, power float64) {
+		samples.Timestamps = append(samples.Timestamps, start.Add(at))
+		samples.Distance = append(samples.Distance, distance)
+		samples.Power = append(samples.Power, power)
+	}
+	for i := 0; i <= 600; i++ {
+		add(time.Duration(i)*time.Second, float64(i)*5, 200)
+	}
+	for i := 0; i <= 300; i++ {
+		add(30*time.Minute+time.Duration(i)*time.Second, 3000+float64(i)*2, 100)
+	}
+
+	// Only 15 minutes were ridden
+	_, ok := garmin.BestDurationEffort(samples, samples.Power, 20*time.Minute)
+	assert.False(t, ok)
+
+	effort, ok := garmin.BestDurationEffort(samples, samples.Power, 12*time.Minute)
+	require.True(t, ok)
+	assert.Equal(t, 720.0, effort.Seconds)
+	assert.InDelta(t, (600*200+120*100)/720.0, effort.Average, 1e-9)
+
+	// The stop does not count towards the time over 3.5 km, which takes 600 s
+	// at 5 m/s and 250 s at 2 m/s
+	effort, ok = garmin.BestDistanceEffort(samples, 3500)
+	require.True(t, ok)
+	assert.InDelta(t, 850.0, effort.Seconds, 1e-9)
+}
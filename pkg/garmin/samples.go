@@ -0,0 +1,158 @@
+package garmin
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"time"
+)
+
+// maxSampleRows is the number of rows requested from the activity details
+// endpoint. It is large enough to return per-second samples for activities
+// of well over a day.
+const maxSampleRows = 100000
+
+// maxSampleGap is the longest interval between two samples that is counted
+// as moving time. Longer gaps are pauses and are skipped.
+const maxSampleGap = 30 * time.Second
+
+// Series is a column of activity samples. Missing values are NaN and are
+// encoded as null in JSON.
+type Series []float64
+
+// MarshalJSON implements the json.Marshaler interface
+func (s Series) MarshalJSON() ([]byte, error) {
+	buf := make([]byte, 0, len(s)*8+2)
+	buf = append(buf, '[')
+	for i, v := range s {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			buf = append(buf, "null"...)
+			continue
+		}
+		buf = strconv.AppendFloat(buf, v, 'f', -1, 64)
+	}
+	return append(buf, ']'), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, decoding null as NaN
+func (s *Series) UnmarshalJSON(b []byte) error {
+	var values []*float64
+	if err := json.Unmarshal(b, &values); err != nil {
+		return err
+	}
+	*s = make(Series, len(values))
+	for i, v := range values {
+		if v == nil {
+			(*s)[i] = math.NaN()
+		} else {
+			(*s)[i] = *v
+		}
+	}
+	return nil
+}
+
+// at returns the value at index i, or NaN when i is out of range
+func (s Series) at(i int) float64 {
+	if i < 0 || i >= len(s) {
+		return math.NaN()
+	}
+	return s[i]
+}
+
+// ActivitySamples holds the time-series samples of an activity in columnar
+// form. Every series has the same length as Timestamps; metrics the device
+// did not record are all NaN.
+type ActivitySamples struct {
+	ActivityID  int64       `json:"activityId"`
+	Timestamps  []time.Time `json:"timestamp"`
+	Latitude    Series      `json:"latitude"`    // degrees
+	Longitude   Series      `json:"longitude"`   // degrees
+	Distance    Series      `json:"distance"`    // meters from start
+	HeartRate   Series      `json:"heart_rate"`  // beats per minute
+	Speed       Series      `json:"speed"`       // meters per second
+	Power       Series      `json:"power"`       // watts
+	Cadence     Series      `json:"cadence"`     // steps or revolutions per minute
+	Elevation   Series      `json:"elevation"`   // meters
+	Temperature Series      `json:"temperature"` // degrees Celsius
+}
+
+// Len returns the number of samples
+func (s *ActivitySamples) Len() int {
+	return len(s.Timestamps)
+}
+
+// sampleColumns maps activity detail metric keys onto sample series, in
+// order of preference when several keys feed the same series
+var sampleColumns = []struct {
+	keys   []string
+	series func(*ActivitySamples) *Series
+}{
+	{[]string{"directLatitude"}, func(s *ActivitySamples) *Series { return &s.Latitude }},
+	{[]string{"directLongitude"}, func(s *ActivitySamples) *Series { return &s.Longitude }},
+	{[]string{"sumDistance"}, func(s *ActivitySamples) *Series { return &s.Distance }},
+	{[]string{"directHeartRate"}, func(s *ActivitySamples) *Series { return &s.HeartRate }},
+	{[]string{"directSpeed", "directEnhancedSpeed"}, func(s *ActivitySamples) *Series { return &s.Speed }},
+	{[]string{"directPower"}, func(s *ActivitySamples) *Series { return &s.Power }},
+	{[]string{"directDoubleCadence", "directRunCadence", "directBikeCadence"}, func(s *ActivitySamples) *Series { return &s.Cadence }},
+	{[]string{"directElevation", "directEnhancedElevation"}, func(s *ActivitySamples) *Series { return &s.Elevation }},
+	{[]string{"directAirTemperature"}, func(s *ActivitySamples) *Series { return &s.Temperature }},
+}
+
+// GetActivitySamples retrieves the time-series samples recorded during an
+// activity: timestamps, position, heart rate, speed, power, cadence,
+// elevation and temperature
+func (c *Client) GetActivitySamples(activityID int) (*ActivitySamples, error) {
+	metrics, err := c.Client.GetActivityDetailMetrics(int64(activityID), maxSampleRows)
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]int, len(metrics.MetricDescriptors))
+	for _, d := range metrics.MetricDescriptors {
+		index[d.Key] = d.MetricsIndex
+	}
+
+	rows := metrics.ActivityDetailMetrics
+	samples := &ActivitySamples{
+		ActivityID: metrics.ActivityID,
+		Timestamps: make([]time.Time, len(rows)),
+	}
+	if samples.ActivityID == 0 {
+		samples.ActivityID = int64(activityID)
+	}
+
+	value := func(row []*float64, i int) float64 {
+		if i < 0 || i >= len(row) || row[i] == nil {
+			return math.NaN()
+		}
+		return *row[i]
+	}
+
+	if i, ok := index["directTimestamp"]; ok {
+		for r, row := range rows {
+			if ms := value(row.Metrics, i); !math.IsNaN(ms) {
+				samples.Timestamps[r] = time.UnixMilli(int64(ms)).UTC()
+			}
+		}
+	}
+
+	for _, col := range sampleColumns {
+		column := -1
+		for _, key := range col.keys {
+			if i, ok := index[key]; ok {
+				column = i
+				break
+			}
+		}
+		series := make(Series, len(rows))
+		for r, row := range rows {
+			series[r] = value(row.Metrics, column)
+		}
+		*col.series(samples) = series
+	}
+
+	return samples, nil
+}
@@ -0,0 +1,62 @@
+package garmin_test
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+func TestGetActivitySamples(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/activity-service/activity/42/details", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"activityId": 42,
+			"metricDescriptors": [
+				{"metricsIndex": 2, "key": "directHeartRate", "unit": {"key": "bpm"}},
+				{"metricsIndex": 0, "key": "directTimestamp", "unit": {"key": "gmt"}},
+				{"metricsIndex": 1, "key": "directLatitude", "unit": {"key": "dd"}},
+				{"metricsIndex": 3, "key": "directRunCadence", "unit": {"key": "stepsPerMinute"}}
+			],
+			"activityDetailMetrics": [
+				{"metrics": [1709276400000, 47.6, 120, 80]},
+				{"metrics": [1709276401000, null, 125, 82]}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	samples, err := newTestClient(t, server).GetActivitySamples(42)
+	require.NoError(t, err)
+
+	require.Equal(t, 2, samples.Len())
+	assert.Equal(t, time.Date(2024, 3, 1, 7, 0, 1, 0, time.UTC), samples.Timestamps[1])
+	assert.Equal(t, garmin.Series{120, 125}, samples.HeartRate)
+	assert.Equal(t, 80.0, samples.Cadence[0])
+	assert.Equal(t, 47.6, samples.Latitude[0])
+	assert.True(t, math.IsNaN(samples.Latitude[1]))
+
+	// Unrecorded metrics are full-length NaN columns
+	require.Len(t, samples.Power, 2)
+	assert.True(t, math.IsNaN(samples.Power[0]))
+}
+
+func TestSeries_JSONNulls(t *testing.T) {
+	data, err := json.Marshal(garmin.Series{1.5, math.NaN(), 3})
+	require.NoError(t, err)
+	assert.JSONEq(t, `[1.5, null, 3]`, string(data))
+
+	var s garmin.Series
+	require.NoError(t, json.Unmarshal(data, &s))
+	require.Len(t, s, 3)
+	assert.Equal(t, 1.5, s[0])
+	assert.True(t, math.IsNaN(s[1]))
+}
@@ -0,0 +1,337 @@
+package garmin
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// SegmentStats summarises a stretch of an activity. Averages of metrics the
+// device did not record are zero.
+type SegmentStats struct {
+	Seconds        float64 `json:"seconds"`  // moving time
+	Distance       float64 `json:"distance"` // meters
+	AverageSpeed   float64 `json:"averageSpeed"`
+	AverageHR      float64 `json:"averageHR,omitempty"`
+	AveragePower   float64 `json:"averagePower,omitempty"`
+	AverageCadence float64 `json:"averageCadence,omitempty"`
+	ElevationGain  float64 `json:"elevationGain"`
+	ElevationLoss  float64 `json:"elevationLoss"`
+}
+
+// Split is a fixed-distance split of an activity. The last split is usually
+// shorter than the others.
+type Split struct {
+	Index int `json:"split"`
+	SegmentStats
+}
+
+// IntervalKind tells work intervals from recoveries
+type IntervalKind string
+
+const (
+	IntervalWork IntervalKind = "work"
+	IntervalRest IntervalKind = "rest"
+)
+
+// Interval is a stretch of an activity at a steady work or rest intensity
+type Interval struct {
+	Index int          `json:"interval"`
+	Kind  IntervalKind `json:"kind"`
+	Start time.Time    `json:"start"`
+	SegmentStats
+}
+
+// IntervalOptions tunes interval detection
+type IntervalOptions struct {
+	// Metric is "power" or "speed". Empty uses power when it was recorded
+	// and speed otherwise.
+	Metric string
+	// Smoothing is the width of the moving average applied before
+	// detection (default 20s)
+	Smoothing time.Duration
+	// MinDuration is the shortest interval kept; shorter ones are merged
+	// into their neighbours (default 30s)
+	MinDuration time.Duration
+}
+
+// IntervalAnalysis holds the intervals detected in an activity
+type IntervalAnalysis struct {
+	Metric    string     `json:"metric"`
+	Threshold float64    `json:"threshold"` // work/rest boundary in the metric's unit
+	Intervals []Interval `json:"intervals"`
+}
+
+// mean is a time-weighted running mean
+type mean struct {
+	sum, weight float64
+}
+
+func (m *mean) add(value, weight float64) {
+	if !math.IsNaN(value) {
+		m.sum += value * weight
+		m.weight += weight
+	}
+}
+
+func (m mean) value() float64 {
+	if m.weight == 0 {
+		return 0
+	}
+	return m.sum / m.weight
+}
+
+// segmentAccumulator builds SegmentStats from sample intervals
+type segmentAccumulator struct {
+	stats              SegmentStats
+	hr, power, cadence mean
+}
+
+// add accounts for the part of the interval from sample i to sample i+1
+// between the fractions from and to
+func (a *segmentAccumulator) add(s *ActivitySamples, i int, from, to float64) {
+	t0, t1 := s.Timestamps[i], s.Timestamps[i+1]
+	if t0.IsZero() || t1.IsZero() || to <= from {
+		return
+	}
+	fraction := to - from
+	if d0, d1 := s.Distance.at(i), s.Distance.at(i+1); !math.IsNaN(d0) && !math.IsNaN(d1) {
+		a.stats.Distance += (d1 - d0) * fraction
+	}
+	if e0, e1 := s.Elevation.at(i), s.Elevation.at(i+1); !math.IsNaN(e0) && !math.IsNaN(e1) {
+		if delta := (e1 - e0) * fraction; delta > 0 {
+			a.stats.ElevationGain += delta
+		} else {
+			a.stats.ElevationLoss -= delta
+		}
+	}
+
+	gap := t1.Sub(t0)
+	if gap <= 0 || gap > maxSampleGap {
+		return
+	}
+	seconds := gap.Seconds() * fraction
+	a.stats.Seconds += seconds
+	a.hr.add(s.HeartRate.at(i), seconds)
+	a.power.add(s.Power.at(i), seconds)
+	a.cadence.add(s.Cadence.at(i), seconds)
+}
+
+func (a *segmentAccumulator) result() SegmentStats {
+	stats := a.stats
+	if stats.Seconds > 0 {
+		stats.AverageSpeed = stats.Distance / stats.Seconds
+	}
+	stats.AverageHR = a.hr.value()
+	stats.AveragePower = a.power.value()
+	stats.AverageCadence = a.cadence.value()
+	return stats
+}
+
+// Splits divides an activity into splits of length meters, for example 1000
+// for kilometer splits, interpolating between samples at the boundaries
+func Splits(samples *ActivitySamples, length float64) []Split {
+	if length <= 0 {
+		return nil
+	}
+
+	var splits []Split
+	var acc segmentAccumulator
+	boundary := length
+	for i := 0; i+1 < samples.Len(); i++ {
+		d0, d1 := samples.Distance.at(i), samples.Distance.at(i+1)
+		from := 0.0
+		if !math.IsNaN(d0) && !math.IsNaN(d1) && d1 > d0 {
+			for boundary <= d0 {
+				boundary += length
+			}
+			for boundary <= d1 {
+				to := (boundary - d0) / (d1 - d0)
+				acc.add(samples, i, from, to)
+				splits = append(splits, Split{Index: len(splits) + 1, SegmentStats: acc.result()})
+				acc = segmentAccumulator{}
+				from = to
+				boundary += length
+			}
+		}
+		acc.add(samples, i, from, 1)
+	}
+	if acc.stats.Distance > 0 {
+		splits = append(splits, Split{Index: len(splits) + 1, SegmentStats: acc.result()})
+	}
+	return splits
+}
+
+// DetectIntervals segments an activity into work and rest intervals. The
+// chosen metric is smoothed, split into a low and a high level with a
+// two-means threshold, and intervals shorter than opts.MinDuration are
+// merged into their neighbours.
+func DetectIntervals(samples *ActivitySamples, opts IntervalOptions) (*IntervalAnalysis, error) {
+	if opts.Smoothing <= 0 {
+		opts.Smoothing = 20 * time.Second
+	}
+	if opts.MinDuration <= 0 {
+		opts.MinDuration = 30 * time.Second
+	}
+
+	metric := opts.Metric
+	if metric == "" {
+		metric = "speed"
+		if hasReadings(samples.Power) {
+			metric = "power"
+		}
+	}
+	var values Series
+	switch metric {
+	case "power":
+		values = samples.Power
+	case "speed":
+		values = samples.Speed
+	default:
+		return nil, fmt.Errorf("unsupported interval metric %q (use power or speed)", metric)
+	}
+	if samples.Len() < 2 || !hasReadings(values) {
+		return nil, fmt.Errorf("activity has no %s samples", metric)
+	}
+
+	smoothed := smoothSeries(samples.Timestamps, values, opts.Smoothing)
+	threshold := twoMeansThreshold(smoothed)
+
+	// Label each sample; samples without a reading keep the previous label
+	work := make([]bool, samples.Len())
+	for i, v := range smoothed {
+		switch {
+		case !math.IsNaN(v):
+			work[i] = v >= threshold
+		case i > 0:
+			work[i] = work[i-1]
+		}
+	}
+
+	// Segments are [start, end) ranges of sample indexes with one label
+	type segment struct {
+		start, end int
+		work       bool
+	}
+	segments := func() []segment {
+		var segs []segment
+		for i := range work {
+			if len(segs) > 0 && segs[len(segs)-1].work == work[i] {
+				segs[len(segs)-1].end = i + 1
+				continue
+			}
+			segs = append(segs, segment{start: i, end: i + 1, work: work[i]})
+		}
+		return segs
+	}
+	duration := func(seg segment) time.Duration {
+		end := min(seg.end, samples.Len()-1)
+		return samples.Timestamps[end].Sub(samples.Timestamps[seg.start])
+	}
+
+	// Merge the shortest segment into its neighbours until all are long enough
+	for {
+		segs := segments()
+		shortest := -1
+		for i, seg := range segs {
+			if len(segs) > 1 && duration(seg) < opts.MinDuration && (shortest < 0 || duration(seg) < duration(segs[shortest])) {
+				shortest = i
+			}
+		}
+		if shortest < 0 {
+			break
+		}
+		seg := segs[shortest]
+		for i := seg.start; i < seg.end; i++ {
+			work[i] = !seg.work
+		}
+	}
+
+	analysis := &IntervalAnalysis{Metric: metric, Threshold: threshold}
+	for _, seg := range segments() {
+		var acc segmentAccumulator
+		for i := seg.start; i < seg.end && i+1 < samples.Len(); i++ {
+			acc.add(samples, i, 0, 1)
+		}
+		kind := IntervalRest
+		if seg.work {
+			kind = IntervalWork
+		}
+		analysis.Intervals = append(analysis.Intervals, Interval{
+			Index:        len(analysis.Intervals) + 1,
+			Kind:         kind,
+			Start:        samples.Timestamps[seg.start],
+			SegmentStats: acc.result(),
+		})
+	}
+	return analysis, nil
+}
+
+// hasReadings reports whether a series holds any value
+func hasReadings(values Series) bool {
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// smoothSeries returns the centered moving average of values over a window
+// of the given width
+func smoothSeries(timestamps []time.Time, values Series, width time.Duration) Series {
+	smoothed := make(Series, len(values))
+	half := width / 2
+	lo, hi := 0, 0
+	sum, count := 0.0, 0
+	for i := range values {
+		for hi < len(values) && timestamps[hi].Sub(timestamps[i]) <= half {
+			if !math.IsNaN(values[hi]) {
+				sum += values[hi]
+				count++
+			}
+			hi++
+		}
+		for lo < i && timestamps[i].Sub(timestamps[lo]) > half {
+			if !math.IsNaN(values[lo]) {
+				sum -= values[lo]
+				count--
+			}
+			lo++
+		}
+		smoothed[i] = math.NaN()
+		if count > 0 && !math.IsNaN(values[i]) {
+			smoothed[i] = sum / float64(count)
+		}
+	}
+	return smoothed
+}
+
+// twoMeansThreshold splits values into a low and a high cluster and returns
+// the midpoint between their means
+func twoMeansThreshold(values Series) float64 {
+	var all mean
+	for _, v := range values {
+		all.add(v, 1)
+	}
+	threshold := all.value()
+	for iter := 0; iter < 50; iter++ {
+		var low, high mean
+		for _, v := range values {
+			if v >= threshold {
+				high.add(v, 1)
+			} else {
+				low.add(v, 1)
+			}
+		}
+		if low.weight == 0 || high.weight == 0 {
+			break
+		}
+		next := (low.value() + high.value()) / 2
+		if next == threshold {
+			break
+		}
+		threshold = next
+	}
+	return threshold
+}
@@ -0,0 +1,91 @@
+package garmin_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+// steadySamples returns per-second samples at the given speeds
+func steadySamples(speeds []float64) *garmin.ActivitySamples {
+	start := time.Date(2024, 3, 1, 7, 0, 0, 0, time.UTC)
+	n := len(speeds) + 1
+	s := &garmin.ActivitySamples{ActivityID: 9}
+	nan := func() garmin.Series {
+		series := make(garmin.Series, n)
+		for i := range series {
+			series[i] = math.NaN()
+		}
+		return series
+	}
+	s.Distance, s.Speed, s.HeartRate, s.Power, s.Cadence, s.Elevation = nan(), nan(), nan(), nan(), nan(), nan()
+	distance := 0.0
+	for i := 0; i < n; i++ {
+		s.Timestamps = append(s.Timestamps, start.Add(time.Duration(i)*time.Second))
+		s.Distance[i] = distance
+		s.Elevation[i] = 100 + float64(i%2)
+		if i < len(speeds) {
+			s.Speed[i] = speeds[i]
+			s.HeartRate[i] = 100 + speeds[i]*10
+			distance += speeds[i]
+		}
+	}
+	return s
+}
+
+func TestSplits(t *testing.T) {
+	speeds := make([]float64, 0, 500)
+	for i := 0; i < 250; i++ {
+		speeds = append(speeds, 4)
+	}
+	for i := 0; i < 250; i++ {
+		speeds = append(speeds, 5)
+	}
+	samples := steadySamples(speeds) // 1000 m at 4 m/s, then 1250 m at 5 m/s
+
+	splits := garmin.Splits(samples, 1000)
+	require.Len(t, splits, 3)
+	assert.Equal(t, 1, splits[0].Index)
+	assert.InDelta(t, 1000, splits[0].Distance, 1e-6)
+	assert.InDelta(t, 250, splits[0].Seconds, 1e-6)
+	assert.InDelta(t, 4, splits[0].AverageSpeed, 1e-6)
+	assert.InDelta(t, 140, splits[0].AverageHR, 1e-6)
+	assert.InDelta(t, 200, splits[1].Seconds, 1e-6)
+	assert.InDelta(t, 250, splits[2].Distance, 1e-6)
+	assert.Zero(t, splits[0].AveragePower)
+	assert.Greater(t, splits[0].ElevationGain, 0.0)
+}
+
+func TestDetectIntervals(t *testing.T) {
+	var speeds []float64
+	for rep := 0; rep < 3; rep++ {
+		for i := 0; i < 120; i++ {
+			speeds = append(speeds, 2.5)
+		}
+		for i := 0; i < 60; i++ {
+			speed := 5.0
+			if i == 30 {
+				speed = 2 // a brief stumble is not a recovery
+			}
+			speeds = append(speeds, speed)
+		}
+	}
+	samples := steadySamples(speeds)
+
+	analysis, err := garmin.DetectIntervals(samples, garmin.IntervalOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "speed", analysis.Metric)
+	require.Len(t, analysis.Intervals, 6)
+	assert.Equal(t, garmin.IntervalRest, analysis.Intervals[0].Kind)
+	assert.Equal(t, garmin.IntervalWork, analysis.Intervals[1].Kind)
+	assert.InDelta(t, 60, analysis.Intervals[1].Seconds, 20)
+	assert.Greater(t, analysis.Intervals[1].AverageSpeed, 4.0)
+
+	_, err = garmin.DetectIntervals(samples, garmin.IntervalOptions{Metric: "power"})
+	assert.ErrorContains(t, err, "no power samples")
+}
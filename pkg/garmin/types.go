@@ -88,3 +88,52 @@ type StressData = types.StressData
 
 // BodyBatteryData represents Body Battery data
 type BodyBatteryData = types.BodyBatteryData
+
+// Device represents a device registered to the user's account
+type Device = types.Device
+
+// Goals represents the user's fitness goals
+type Goals = types.Goals
+
+// Badge represents a badge earned by the user
+type Badge = types.Badge
+
+// Gear represents a piece of gear such as shoes or a bike
+type Gear = types.Gear
+
+// GearStats represents the usage totals of a piece of gear
+type GearStats = types.GearStats
+
+// Course represents a course from the course service
+type Course = types.Course
+
+// CourseGeoPoint represents a track point of a course
+type CourseGeoPoint = types.CourseGeoPoint
+
+// Workout represents a structured workout from the workout service
+type Workout = types.Workout
+
+// WorkoutSegment represents a segment of a workout
+type WorkoutSegment = types.WorkoutSegment
+
+// WorkoutStep represents a single workout step or a repeat group
+type WorkoutStep = types.WorkoutStep
+
+// ScheduledWorkout represents a workout scheduled on the calendar
+type ScheduledWorkout = types.ScheduledWorkout
+
+// CalendarMonth represents the items on the calendar for a month or a week
+type CalendarMonth = types.CalendarMonth
+
+// CalendarItem represents an item on the calendar
+type CalendarItem = types.CalendarItem
+
+// ActivityWeather represents the weather report of an activity, in imperial
+// units
+type ActivityWeather = types.ActivityWeather
+
+// PersonalRecord represents a personal record from the personal record service
+type PersonalRecord = types.PersonalRecord
+
+// DailyHydration represents hydration intake for a single day
+type DailyHydration = types.DailyHydration
@@ -0,0 +1,227 @@
+package garmin
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sstent/go-garth/errors"
+	types "github.com/sstent/go-garth/models/types"
+)
+
+// ActivityPatch is a partial activity update. Nil fields are left unchanged.
+type ActivityPatch struct {
+	Name         *string
+	Description  *string
+	ActivityType *string // Type key, e.g. "trail_running"
+	EventType    *string // Event type key, e.g. "race"
+	Privacy      *string // "public", "subscribers" or "private"
+	LinkGear     []string
+	UnlinkGear   []string
+}
+
+// activityTypes maps activity type keys to their IDs. It holds the common
+// types until LoadActivityTypes adds every type Garmin Connect offers.
+var activityTypes = map[string]int{
+	"running":             1,
+	"cycling":             2,
+	"hiking":              3,
+	"other":               4,
+	"mountain_biking":     5,
+	"trail_running":       6,
+	"street_running":      7,
+	"track_running":       8,
+	"walking":             9,
+	"road_biking":         10,
+	"strength_training":   13,
+	"treadmill_running":   18,
+	"indoor_cycling":      25,
+	"swimming":            26,
+	"lap_swimming":        27,
+	"open_water_swimming": 28,
+	"fitness_equipment":   29,
+}
+
+// eventTypes maps event type keys to their IDs
+var eventTypes = map[string]int{
+	"race":           1,
+	"recreation":     2,
+	"special_event":  3,
+	"training":       4,
+	"transportation": 5,
+	"touring":        6,
+	"geocaching":     7,
+	"fitness":        8,
+	"uncategorized":  9,
+}
+
+// privacyRules maps privacy settings to access control rule IDs
+var privacyRules = map[string]int{
+	"public":      1,
+	"private":     2,
+	"subscribers": 3,
+}
+
+// ActivityTypeKeys returns the activity type keys accepted by UpdateActivity
+func ActivityTypeKeys() []string {
+	activityTypesMu.RLock()
+	defer activityTypesMu.RUnlock()
+	return sortedKeys(activityTypes)
+}
+
+// ActivityTypeKey returns the key of an activity type ID, or an empty string
+// for types that are not known
+func ActivityTypeKey(id int) string {
+	activityTypesMu.RLock()
+	defer activityTypesMu.RUnlock()
+	for key, typeID := range activityTypes {
+		if typeID == id {
+			return key
+		}
+	}
+	return ""
+}
+
+// EventTypeKeys returns the event type keys accepted by UpdateActivity
+func EventTypeKeys() []string {
+	return sortedKeys(eventTypes)
+}
+
+// PrivacyKeys returns the privacy settings accepted by UpdateActivity
+func PrivacyKeys() []string {
+	return sortedKeys(privacyRules)
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// IsEmpty reports whether the patch changes nothing
+func (p ActivityPatch) IsEmpty() bool {
+	return p.Name == nil && p.Description == nil && p.ActivityType == nil &&
+		p.EventType == nil && p.Privacy == nil && len(p.LinkGear) == 0 && len(p.UnlinkGear) == 0
+}
+
+// String describes the changes made by the patch
+func (p ActivityPatch) String() string {
+	var changes []string
+	if p.Name != nil {
+		changes = append(changes, fmt.Sprintf("name=%q", *p.Name))
+	}
+	if p.Description != nil {
+		changes = append(changes, fmt.Sprintf("description=%q", *p.Description))
+	}
+	if p.ActivityType != nil {
+		changes = append(changes, "type="+*p.ActivityType)
+	}
+	if p.EventType != nil {
+		changes = append(changes, "event="+*p.EventType)
+	}
+	if p.Privacy != nil {
+		changes = append(changes, "privacy="+*p.Privacy)
+	}
+	for _, uuid := range p.LinkGear {
+		changes = append(changes, "+gear="+uuid)
+	}
+	for _, uuid := range p.UnlinkGear {
+		changes = append(changes, "-gear="+uuid)
+	}
+	return strings.Join(changes, " ")
+}
+
+// Validate checks the type, event type and privacy keys of the patch
+func (p ActivityPatch) Validate() error {
+	_, err := p.update(0)
+	return err
+}
+
+// update converts the patch into the request body of the activity service
+func (p ActivityPatch) update(activityID int64) (*types.ActivityUpdate, error) {
+	update := &types.ActivityUpdate{
+		ActivityID:   activityID,
+		ActivityName: p.Name,
+		Description:  p.Description,
+	}
+
+	if p.ActivityType != nil {
+		key := strings.ToLower(*p.ActivityType)
+		id, ok := lookupActivityType(key)
+		if !ok {
+			return nil, unknownKey("activityType", "activity type", *p.ActivityType, ActivityTypeKeys())
+		}
+		update.ActivityTypeDTO = &types.ActivityType{TypeID: id, TypeKey: key}
+	}
+	if p.EventType != nil {
+		key := strings.ToLower(*p.EventType)
+		id, ok := eventTypes[key]
+		if !ok {
+			return nil, unknownKey("eventType", "event type", *p.EventType, EventTypeKeys())
+		}
+		update.EventTypeDTO = &types.EventType{TypeID: id, TypeKey: key}
+	}
+	if p.Privacy != nil {
+		key := strings.ToLower(*p.Privacy)
+		id, ok := privacyRules[key]
+		if !ok {
+			return nil, unknownKey("privacy", "privacy setting", *p.Privacy, PrivacyKeys())
+		}
+		update.AccessControlRuleDTO = &types.AccessControlRule{TypeID: id, TypeKey: key}
+	}
+
+	return update, nil
+}
+
+func unknownKey(field, kind, value string, known []string) error {
+	return &errors.ValidationError{
+		GarthError: errors.GarthError{
+			Message: fmt.Sprintf("unknown %s %q (known: %s)", kind, value, strings.Join(known, ", ")),
+		},
+		Field: field,
+	}
+}
+
+// UpdateActivity applies a partial update to an activity. Name, description,
+// type, event type and privacy are sent in a single request; gear is then
+// linked and unlinked one item at a time.
+func (c *Client) UpdateActivity(activityID int, patch ActivityPatch) error {
+	if patch.ActivityType != nil {
+		if err := c.ensureActivityType(*patch.ActivityType); err != nil {
+			return err
+		}
+	}
+	update, err := patch.update(int64(activityID))
+	if err != nil {
+		return err
+	}
+
+	if update.ActivityName != nil || update.Description != nil || update.ActivityTypeDTO != nil ||
+		update.EventTypeDTO != nil || update.AccessControlRuleDTO != nil {
+		if err := c.Client.UpdateActivity(update); err != nil {
+			return err
+		}
+	}
+
+	for _, uuid := range patch.UnlinkGear {
+		if err := c.Client.UnlinkGear(uuid, int64(activityID)); err != nil {
+			return err
+		}
+	}
+	for _, uuid := range patch.LinkGear {
+		if err := c.Client.LinkGear(uuid, int64(activityID)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteActivity permanently deletes an activity. Use DownloadActivityFiles
+// with DownloadOptions.Original beforehand to keep a copy.
+func (c *Client) DeleteActivity(activityID int) error {
+	return c.Client.DeleteActivity(int64(activityID))
+}
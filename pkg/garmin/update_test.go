@@ -0,0 +1,77 @@
+package garmin_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+	"github.com/sstent/go-garth/errors"
+)
+
+func TestUpdateActivity_SendsOnlyChangedFields(t *testing.T) {
+	var requests []string
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.URL.Path == "/activity-service/activity/42" {
+			data, _ := io.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(data, &body))
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	name, activityType, privacy := "Long Run", "Trail_Running", "private"
+	err := newTestClient(t, server).UpdateActivity(42, garmin.ActivityPatch{
+		Name:         &name,
+		ActivityType: &activityType,
+		Privacy:      &privacy,
+		LinkGear:     []string{"new-shoes"},
+		UnlinkGear:   []string{"old-shoes"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"PUT /activity-service/activity/42",
+		"PUT /gear-service/gear/unlink/old-shoes/activity/42",
+		"PUT /gear-service/gear/link/new-shoes/activity/42",
+	}, requests)
+	assert.Equal(t, map[string]interface{}{
+		"activityId":           42.0,
+		"activityName":         "Long Run",
+		"activityTypeDTO":      map[string]interface{}{"typeId": 6.0, "typeKey": "trail_running"},
+		"accessControlRuleDTO": map[string]interface{}{"typeId": 2.0, "typeKey": "private"},
+	}, body)
+}
+
+func TestUpdateActivity_RejectsUnknownType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	activityType := "jogging"
+	err := newTestClient(t, server).UpdateActivity(42, garmin.ActivityPatch{ActivityType: &activityType})
+
+	var validationErr *errors.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "activityType", validationErr.Field)
+}
+
+func TestDeleteActivity(t *testing.T) {
+	var request string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request = r.Method + " " + r.URL.Path
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	require.NoError(t, newTestClient(t, server).DeleteActivity(42))
+	assert.Equal(t, "DELETE /activity-service/activity/42", request)
+}
@@ -0,0 +1,172 @@
+package garmin
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// UploadStatus is the outcome of uploading a file
+type UploadStatus string
+
+const (
+	UploadCreated   UploadStatus = "created"
+	UploadDuplicate UploadStatus = "duplicate"
+	UploadFailed    UploadStatus = "failed"
+)
+
+// duplicateImportCode is the message code Garmin uses for duplicate activities
+const duplicateImportCode = 202
+
+// UploadExtensions are the file extensions accepted by the upload service
+var UploadExtensions = []string{".fit", ".gpx", ".tcx"}
+
+// UploadResult describes the upload of one file
+type UploadResult struct {
+	Path       string       `json:"path"`
+	SHA256     string       `json:"sha256"`
+	Status     UploadStatus `json:"status"`
+	ActivityID int64        `json:"activityId,omitempty"`
+	Reason     string       `json:"reason,omitempty"`
+	UploadedAt time.Time    `json:"uploadedAt"`
+}
+
+// HashFile returns the hex-encoded SHA-256 of a file's content
+func HashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// UploadActivityFile uploads a FIT, GPX or TCX file. Files Garmin has
+// already imported are reported as UploadDuplicate with the ID of the
+// existing activity; rejected files are reported as UploadFailed with
+// Garmin's reason. The error is only set when the upload could not be made.
+func (c *Client) UploadActivityFile(path string) (*UploadResult, error) {
+	hash, err := HashFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer f.Close()
+
+	response, err := c.Client.UploadActivity(filepath.Base(path), f)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &UploadResult{
+		Path:       path,
+		SHA256:     hash,
+		Status:     UploadCreated,
+		UploadedAt: time.Now().UTC(),
+	}
+	imported := response.DetailedImportResult
+	switch {
+	case len(imported.Successes) > 0:
+		result.ActivityID = imported.Successes[0].InternalID
+	case len(imported.Failures) > 0:
+		failure := imported.Failures[0]
+		result.Status = UploadFailed
+		result.ActivityID = failure.InternalID
+		var reasons []string
+		for _, message := range failure.Messages {
+			if message.Code == duplicateImportCode {
+				result.Status = UploadDuplicate
+			}
+			reasons = append(reasons, message.Content)
+		}
+		result.Reason = strings.Join(reasons, "; ")
+	default:
+		// The file was accepted but is still being processed
+		result.Reason = "processing"
+	}
+
+	return result, nil
+}
+
+// UploadManifest records uploaded files by content hash, so interrupted or
+// repeated uploads skip files that were already imported. It is safe for
+// concurrent use and saved after every change.
+type UploadManifest struct {
+	path  string
+	mu    sync.Mutex
+	Files map[string]UploadResult `json:"files"` // Keyed by SHA-256
+}
+
+// LoadUploadManifest reads a manifest, returning an empty one if the file
+// does not exist yet
+func LoadUploadManifest(path string) (*UploadManifest, error) {
+	m := &UploadManifest{path: path, Files: map[string]UploadResult{}}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload manifest: %w", err)
+	}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("failed to parse upload manifest %s: %w", path, err)
+	}
+	if m.Files == nil {
+		m.Files = map[string]UploadResult{}
+	}
+	return m, nil
+}
+
+// Lookup returns the recorded upload of a file with the given hash
+func (m *UploadManifest) Lookup(hash string) (UploadResult, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	result, ok := m.Files[hash]
+	return result, ok
+}
+
+// Record stores a created or duplicate upload and saves the manifest.
+// Failed uploads are not recorded, so they are retried next time.
+func (m *UploadManifest) Record(result UploadResult) error {
+	if result.Status == UploadFailed {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Files[result.SHA256] = result
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(m.path), 0700); err != nil {
+		return fmt.Errorf("failed to create manifest directory: %w", err)
+	}
+	// Write to a temporary file first so an interrupted save keeps the old manifest
+	tmp := m.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write upload manifest: %w", err)
+	}
+	if err := os.Rename(tmp, m.path); err != nil {
+		return fmt.Errorf("failed to write upload manifest: %w", err)
+	}
+	return nil
+}
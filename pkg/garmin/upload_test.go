@@ -0,0 +1,86 @@
+package garmin_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+func TestUploadActivityFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/upload-service/upload", r.URL.Path)
+		assert.True(t, strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data"))
+
+		file, header, err := r.FormFile("file")
+		require.NoError(t, err)
+		content, _ := io.ReadAll(file)
+
+		w.Header().Set("Content-Type", "application/json")
+		switch string(content) {
+		case "new":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"detailedImportResult": {"uploadId": 1, "fileName": "` + header.Filename + `",
+				"successes": [{"internalId": 101, "messages": []}], "failures": []}}`))
+		case "dup":
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"detailedImportResult": {"uploadId": 2, "successes": [],
+				"failures": [{"internalId": 55, "messages": [{"code": 202, "content": "Duplicate Activity."}]}]}}`))
+		default:
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"detailedImportResult": {"uploadId": 3, "successes": [],
+				"failures": [{"internalId": -1, "messages": [{"code": 400, "content": "Invalid file."}]}]}}`))
+		}
+	}))
+	defer server.Close()
+	client := newTestClient(t, server)
+
+	dir := t.TempDir()
+	for name, content := range map[string]string{"new.fit": "new", "dup.fit": "dup", "bad.gpx": "bad"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	created, err := client.UploadActivityFile(filepath.Join(dir, "new.fit"))
+	require.NoError(t, err)
+	assert.Equal(t, garmin.UploadCreated, created.Status)
+	assert.Equal(t, int64(101), created.ActivityID)
+	assert.Len(t, created.SHA256, 64)
+
+	duplicate, err := client.UploadActivityFile(filepath.Join(dir, "dup.fit"))
+	require.NoError(t, err)
+	assert.Equal(t, garmin.UploadDuplicate, duplicate.Status)
+	assert.Equal(t, int64(55), duplicate.ActivityID)
+	assert.Equal(t, "Duplicate Activity.", duplicate.Reason)
+
+	failed, err := client.UploadActivityFile(filepath.Join(dir, "bad.gpx"))
+	require.NoError(t, err)
+	assert.Equal(t, garmin.UploadFailed, failed.Status)
+	assert.Equal(t, "Invalid file.", failed.Reason)
+}
+
+func TestUploadManifest_Resume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "uploads.json")
+
+	manifest, err := garmin.LoadUploadManifest(path)
+	require.NoError(t, err)
+	require.NoError(t, manifest.Record(garmin.UploadResult{SHA256: "aaa", Status: garmin.UploadCreated, ActivityID: 1}))
+	require.NoError(t, manifest.Record(garmin.UploadResult{SHA256: "bbb", Status: garmin.UploadFailed}))
+
+	reloaded, err := garmin.LoadUploadManifest(path)
+	require.NoError(t, err)
+	result, ok := reloaded.Lookup("aaa")
+	require.True(t, ok)
+	assert.Equal(t, int64(1), result.ActivityID)
+
+	// Failed uploads are retried
+	_, ok = reloaded.Lookup("bbb")
+	assert.False(t, ok)
+}
@@ -0,0 +1,77 @@
+package garmin
+
+import (
+	stderrors "errors"
+	"net/http"
+	"strings"
+
+	"github.com/sstent/go-garth/errors"
+)
+
+// Weather holds the conditions during an activity in metric units. Values
+// the weather service did not report are nil.
+type Weather struct {
+	Temperature         *float64 `json:"temperature,omitempty"`         // °C
+	ApparentTemperature *float64 `json:"apparentTemperature,omitempty"` // °C
+	DewPoint            *float64 `json:"dewPoint,omitempty"`            // °C
+	Humidity            *float64 `json:"humidity,omitempty"`            // percent
+	WindSpeed           *float64 `json:"windSpeed,omitempty"`           // m/s
+	WindGust            *float64 `json:"windGust,omitempty"`            // m/s
+	WindDirection       *float64 `json:"windDirection,omitempty"`       // degrees from north
+	WindCompass         string   `json:"windCompass,omitempty"`         // e.g. "SSW"
+	Conditions          string   `json:"conditions,omitempty"`
+	Station             string   `json:"station,omitempty"`
+}
+
+// WeatherFromReport converts a weather report from imperial to metric units
+func WeatherFromReport(report *ActivityWeather) *Weather {
+	if report == nil {
+		return nil
+	}
+	celsius := func(f *float64) *float64 {
+		if f == nil {
+			return nil
+		}
+		c := (*f - 32) * 5 / 9
+		return &c
+	}
+	metersPerSecond := func(mph *float64) *float64 {
+		if mph == nil {
+			return nil
+		}
+		ms := *mph * 0.44704
+		return &ms
+	}
+
+	weather := &Weather{
+		Temperature:         celsius(report.Temp),
+		ApparentTemperature: celsius(report.ApparentTemp),
+		DewPoint:            celsius(report.DewPoint),
+		Humidity:            report.RelativeHumidity,
+		WindSpeed:           metersPerSecond(report.WindSpeed),
+		WindGust:            metersPerSecond(report.WindGust),
+		WindDirection:       report.WindDirection,
+		WindCompass:         strings.ToUpper(report.WindDirectionCompassPoint),
+	}
+	if report.WeatherTypeDTO != nil {
+		weather.Conditions = report.WeatherTypeDTO.Desc
+	}
+	if report.WeatherStationDTO != nil {
+		weather.Station = report.WeatherStationDTO.Name
+	}
+	return weather
+}
+
+// GetWeather retrieves the weather during an activity. Activities without a
+// weather report, such as indoor activities, return nil.
+func (c *Client) GetWeather(activityID int) (*Weather, error) {
+	report, err := c.GetActivityWeather(int64(activityID))
+	var apiErr *errors.APIError
+	if stderrors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return WeatherFromReport(report), nil
+}
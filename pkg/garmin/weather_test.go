@@ -0,0 +1,47 @@
+package garmin_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetWeather(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/activity-service/activity/42/weather":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{
+				"temp": 50, "apparentTemp": 41, "relativeHumidity": 81,
+				"windDirection": 200, "windDirectionCompassPoint": "ssw", "windSpeed": 10, "windGust": null,
+				"weatherStationDTO": {"id": "EGLL", "name": "London Heathrow"},
+				"weatherTypeDTO": {"weatherTypePk": 3, "desc": "Light Rain"}
+			}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	client := newTestClient(t, server)
+
+	weather, err := client.GetWeather(42)
+	require.NoError(t, err)
+	require.NotNil(t, weather)
+	assert.InDelta(t, 10.0, *weather.Temperature, 1e-9)
+	assert.InDelta(t, 5.0, *weather.ApparentTemperature, 1e-9)
+	assert.Equal(t, 81.0, *weather.Humidity)
+	assert.InDelta(t, 4.4704, *weather.WindSpeed, 1e-9)
+	assert.Nil(t, weather.WindGust)
+	assert.Nil(t, weather.DewPoint)
+	assert.Equal(t, "SSW", weather.WindCompass)
+	assert.Equal(t, "Light Rain", weather.Conditions)
+	assert.Equal(t, "London Heathrow", weather.Station)
+
+	// Indoor activities have no weather report
+	weather, err = client.GetWeather(43)
+	require.NoError(t, err)
+	assert.Nil(t, weather)
+}
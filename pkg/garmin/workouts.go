@@ -0,0 +1,428 @@
+package garmin
+
+import (
+	stderrors "errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/sstent/go-garth/errors"
+	types "github.com/sstent/go-garth/models/types"
+)
+
+// Workout step types
+const (
+	StepWarmup   = "warmup"
+	StepCooldown = "cooldown"
+	StepInterval = "interval"
+	StepRecovery = "recovery"
+	StepRest     = "rest"
+	StepRepeat   = "repeat"
+	StepOther    = "other"
+)
+
+// Workout step target types
+const (
+	TargetNone      = "no.target"
+	TargetPower     = "power.zone"
+	TargetHeartRate = "heart.rate.zone"
+	TargetPace      = "pace.zone"
+)
+
+// Workout step end conditions
+const (
+	EndLapButton  = "lap.button"
+	EndTime       = "time"
+	EndDistance   = "distance"
+	EndIterations = "iterations"
+)
+
+// Step DTO types of the workout service
+const (
+	executableStep = "ExecutableStepDTO"
+	repeatGroup    = "RepeatGroupDTO"
+)
+
+// workoutSports maps the sports of a workout to their IDs
+var workoutSports = map[string]int{
+	"running":           1,
+	"cycling":           2,
+	"other":             3,
+	"swimming":          4,
+	"strength_training": 5,
+	"cardio_training":   6,
+}
+
+// stepTypes maps workout step types to their IDs
+var stepTypes = map[string]int{
+	StepWarmup:   1,
+	StepCooldown: 2,
+	StepInterval: 3,
+	StepRecovery: 4,
+	StepRest:     5,
+	StepRepeat:   6,
+	StepOther:    7,
+}
+
+// endConditions maps what ends a workout step to the condition IDs
+var endConditions = map[string]int{
+	EndLapButton:  1,
+	EndTime:       2,
+	EndDistance:   3,
+	EndIterations: 7,
+}
+
+// targetTypes maps workout step targets to their IDs
+var targetTypes = map[string]int{
+	TargetNone:      1,
+	TargetPower:     2,
+	TargetHeartRate: 4,
+	TargetPace:      6,
+}
+
+// WorkoutSportKeys returns the sports accepted in a workout spec
+func WorkoutSportKeys() []string {
+	return sortedKeys(workoutSports)
+}
+
+// WorkoutStepKeys returns the step types accepted in a workout spec
+func WorkoutStepKeys() []string {
+	return sortedKeys(stepTypes)
+}
+
+// WorkoutSpec is a workout as written by hand, typically in a YAML file:
+//
+//	name: 5x1K
+//	sport: running
+//	steps:
+//	  - type: warmup
+//	    duration: 15m
+//	  - type: repeat
+//	    count: 5
+//	    steps:
+//	      - type: interval
+//	        distance: 1km
+//	        pace: 4:00-4:10/km
+//	      - type: recovery
+//	        duration: 2m
+//	        hr: z2
+//	  - type: cooldown
+//
+// A step ends after its duration or distance, or when the lap button is
+// pressed if it has neither.
+type WorkoutSpec struct {
+	Name        string     `yaml:"name" json:"name"`
+	Sport       string     `yaml:"sport" json:"sport"`
+	Description string     `yaml:"description,omitempty" json:"description,omitempty"`
+	Steps       []StepSpec `yaml:"steps" json:"steps"`
+}
+
+// StepSpec is a step of a WorkoutSpec. Repeat steps hold Count and Steps;
+// the other step types may have a duration or a distance and at most one
+// target.
+type StepSpec struct {
+	Type        string     `yaml:"type" json:"type"`
+	Description string     `yaml:"description,omitempty" json:"description,omitempty"`
+	Duration    string     `yaml:"duration,omitempty" json:"duration,omitempty"` // e.g. "90s", "10m", "1:30"
+	Distance    string     `yaml:"distance,omitempty" json:"distance,omitempty"` // e.g. "400m", "1km"
+	Count       int        `yaml:"count,omitempty" json:"count,omitempty"`
+	Steps       []StepSpec `yaml:"steps,omitempty" json:"steps,omitempty"`
+	// Targets are a range such as "140-155" (bpm), "250-280" (watts) and
+	// "4:00-4:10/km", or a zone such as "z2" for heart rate and power
+	HeartRate string `yaml:"hr,omitempty" json:"hr,omitempty"`
+	Power     string `yaml:"power,omitempty" json:"power,omitempty"`
+	Pace      string `yaml:"pace,omitempty" json:"pace,omitempty"`
+}
+
+// ReadWorkoutSpec decodes a workout spec from YAML and validates it. Unknown
+// keys are rejected so that typos do not silently drop targets.
+func ReadWorkoutSpec(r io.Reader) (*WorkoutSpec, error) {
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	var spec WorkoutSpec
+	if err := decoder.Decode(&spec); err != nil {
+		return nil, &errors.ValidationError{
+			GarthError: errors.GarthError{
+				Message: fmt.Sprintf("invalid workout file: %v", err),
+				Cause:   err,
+			},
+		}
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// Validate checks the spec against the step schema and reports every
+// problem found
+func (s WorkoutSpec) Validate() error {
+	_, err := s.Workout()
+	return err
+}
+
+// Workout converts the spec into the request body of the workout service
+func (s WorkoutSpec) Workout() (*Workout, error) {
+	var errs []error
+	if strings.TrimSpace(s.Name) == "" {
+		errs = append(errs, workoutError("name", "workout name is required"))
+	}
+	sportKey := strings.ToLower(s.Sport)
+	sportID, ok := workoutSports[sportKey]
+	if !ok {
+		errs = append(errs, unknownKey("sport", "sport", s.Sport, WorkoutSportKeys()))
+	}
+	if len(s.Steps) == 0 {
+		errs = append(errs, workoutError("steps", "workout has no steps"))
+	}
+
+	b := &workoutBuilder{}
+	steps := b.steps(s.Steps, "steps", false)
+	errs = append(errs, b.errs...)
+	if len(errs) > 0 {
+		return nil, stderrors.Join(errs...)
+	}
+
+	sport := types.WorkoutSportType{SportTypeID: sportID, SportTypeKey: sportKey}
+	return &Workout{
+		WorkoutName: strings.TrimSpace(s.Name),
+		Description: s.Description,
+		SportType:   sport,
+		WorkoutSegments: []WorkoutSegment{{
+			SegmentOrder: 1,
+			SportType:    sport,
+			WorkoutSteps: steps,
+		}},
+	}, nil
+}
+
+// workoutBuilder numbers steps depth-first, as the workout service expects,
+// and collects the problems found on the way
+type workoutBuilder struct {
+	order  int
+	groups int
+	errs   []error
+}
+
+func (b *workoutBuilder) fail(field, format string, args ...interface{}) {
+	b.errs = append(b.errs, workoutError(field, fmt.Sprintf(format, args...)))
+}
+
+func (b *workoutBuilder) steps(specs []StepSpec, field string, nested bool) []WorkoutStep {
+	var steps []WorkoutStep
+	for i, spec := range specs {
+		steps = append(steps, b.step(spec, fmt.Sprintf("%s[%d]", field, i), nested))
+	}
+	return steps
+}
+
+func (b *workoutBuilder) step(spec StepSpec, field string, nested bool) WorkoutStep {
+	b.order++
+	key := strings.ToLower(spec.Type)
+	id, ok := stepTypes[key]
+	if !ok {
+		b.errs = append(b.errs, unknownKey(field+".type", "step type", spec.Type, WorkoutStepKeys()))
+	}
+	step := WorkoutStep{
+		Type:        executableStep,
+		StepOrder:   b.order,
+		StepType:    types.WorkoutStepType{StepTypeID: id, StepTypeKey: key},
+		Description: spec.Description,
+	}
+
+	if key == StepRepeat {
+		if nested {
+			b.fail(field, "repeat steps cannot be nested")
+		}
+		if spec.Count < 1 {
+			b.fail(field+".count", "repeat count must be at least 1")
+		}
+		if len(spec.Steps) == 0 {
+			b.fail(field+".steps", "repeat has no steps")
+		}
+		if spec.Duration != "" || spec.Distance != "" || spec.HeartRate != "" || spec.Power != "" || spec.Pace != "" {
+			b.fail(field, "repeat steps take only count and steps")
+		}
+		b.groups++
+		group := b.groups
+		count := spec.Count
+		step.Type = repeatGroup
+		step.ChildStepID = &group
+		step.NumberOfIterations = &count
+		step.EndCondition = endCondition(EndIterations)
+		step.EndConditionValue = float64Ptr(float64(count))
+		step.WorkoutSteps = b.steps(spec.Steps, field+".steps", true)
+		for i := range step.WorkoutSteps {
+			step.WorkoutSteps[i].ChildStepID = &group
+		}
+		return step
+	}
+
+	if spec.Count != 0 || len(spec.Steps) > 0 {
+		b.fail(field, "only repeat steps take count and steps")
+	}
+
+	switch {
+	case spec.Duration != "" && spec.Distance != "":
+		b.fail(field, "step has both a duration and a distance")
+	case spec.Duration != "":
+		d, err := ParseDuration(spec.Duration)
+		if err != nil || d <= 0 {
+			b.fail(field+".duration", "invalid duration %q", spec.Duration)
+		}
+		step.EndCondition = endCondition(EndTime)
+		step.EndConditionValue = float64Ptr(d.Seconds())
+	case spec.Distance != "":
+		meters, err := ParseDistance(spec.Distance)
+		if err != nil || meters <= 0 {
+			b.fail(field+".distance", "invalid distance %q", spec.Distance)
+		}
+		step.EndCondition = endCondition(EndDistance)
+		step.EndConditionValue = float64Ptr(meters)
+	default:
+		step.EndCondition = endCondition(EndLapButton)
+	}
+
+	var targets int
+	for _, target := range []struct {
+		name, key, value string
+		parse            func(string) (float64, error)
+		zones            bool
+	}{
+		{"hr", TargetHeartRate, spec.HeartRate, parseTargetNumber, true},
+		{"power", TargetPower, spec.Power, parseTargetNumber, true},
+		{"pace", TargetPace, spec.Pace, ParsePace, false},
+	} {
+		if target.value == "" {
+			continue
+		}
+		targets++
+		step.TargetType = targetType(target.key)
+		zone, low, high, err := parseTarget(target.value, target.parse, target.zones)
+		if err != nil {
+			b.fail(field+"."+target.name, "%v", err)
+			continue
+		}
+		if zone > 0 {
+			step.ZoneNumber = &zone
+		} else {
+			step.TargetValueOne = float64Ptr(low)
+			step.TargetValueTwo = float64Ptr(high)
+		}
+	}
+	switch {
+	case targets > 1:
+		b.fail(field, "step has more than one target")
+	case targets == 0:
+		step.TargetType = targetType(TargetNone)
+	}
+	return step
+}
+
+// parseTarget parses a target range such as "140-155" or "4:00-4:10/km", or
+// a zone such as "z3" or "zone 3" when zones are allowed. Ranges are returned
+// in ascending order of the parsed values.
+func parseTarget(value string, parse func(string) (float64, error), zones bool) (int, float64, float64, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if strings.HasPrefix(value, "z") {
+		if !zones {
+			return 0, 0, 0, fmt.Errorf("zones are not supported for this target: %q", value)
+		}
+		number := strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(value, "zone"), "z"))
+		zone, err := strconv.Atoi(number)
+		if err != nil || zone < 1 || zone > 5 {
+			return 0, 0, 0, fmt.Errorf("invalid zone %q (use z1 to z5)", value)
+		}
+		return zone, 0, 0, nil
+	}
+
+	parts := strings.Split(value, "-")
+	if len(parts) != 2 {
+		return 0, 0, 0, fmt.Errorf("invalid target %q: use a range such as 140-155", value)
+	}
+	// A unit written once, as in "4:00-4:10/km", applies to both ends
+	if i := strings.Index(parts[1], "/"); i >= 0 && !strings.Contains(parts[0], "/") {
+		parts[0] += parts[1][i:]
+	}
+	low, err := parse(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid target %q: %w", value, err)
+	}
+	high, err := parse(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid target %q: %w", value, err)
+	}
+	if low > high {
+		low, high = high, low
+	}
+	return 0, low, high, nil
+}
+
+func parseTargetNumber(value string) (float64, error) {
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid number %q", value)
+	}
+	return n, nil
+}
+
+func endCondition(key string) *types.WorkoutEndCondition {
+	return &types.WorkoutEndCondition{ConditionTypeID: endConditions[key], ConditionTypeKey: key}
+}
+
+func targetType(key string) *types.WorkoutTargetType {
+	return &types.WorkoutTargetType{WorkoutTargetTypeID: targetTypes[key], WorkoutTargetTypeKey: key}
+}
+
+func float64Ptr(v float64) *float64 {
+	return &v
+}
+
+func workoutError(field, message string) error {
+	return &errors.ValidationError{
+		GarthError: errors.GarthError{
+			Message: message,
+		},
+		Field: field,
+	}
+}
+
+// ListWorkouts retrieves all of the user's workouts, newest first
+func (c *Client) ListWorkouts() ([]Workout, error) {
+	var workouts []Workout
+	for start := 0; ; start += activityPageSize {
+		page, err := c.GetWorkouts(start, activityPageSize)
+		if err != nil {
+			return nil, err
+		}
+		workouts = append(workouts, page...)
+		if len(page) < activityPageSize {
+			break
+		}
+	}
+	sort.SliceStable(workouts, func(i, j int) bool {
+		return workouts[i].WorkoutID > workouts[j].WorkoutID
+	})
+	return workouts, nil
+}
+
+// CreateWorkoutFromSpec validates a workout spec and creates the workout
+func (c *Client) CreateWorkoutFromSpec(spec WorkoutSpec) (*Workout, error) {
+	workout, err := spec.Workout()
+	if err != nil {
+		return nil, err
+	}
+	return c.CreateWorkout(workout)
+}
+
+// ScheduleWorkout puts a workout on the calendar on the given date
+func (c *Client) ScheduleWorkout(workoutID int64, date time.Time) (*ScheduledWorkout, error) {
+	return c.CreateWorkoutSchedule(workoutID, map[string]string{
+		"date": date.Format("2006-01-02"),
+	})
+}
@@ -0,0 +1,126 @@
+package garmin_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+const testWorkoutYAML = `
+name: 5x1K
+sport: running
+steps:
+  - type: warmup
+    duration: 15m
+  - type: repeat
+    count: 5
+    steps:
+      - type: interval
+        distance: 1km
+        pace: 4:00-4:10/km
+      - type: recovery
+        duration: "1:30"
+        hr: z2
+  - type: cooldown
+    power: 150-180
+`
+
+func TestReadWorkoutSpec(t *testing.T) {
+	spec, err := garmin.ReadWorkoutSpec(strings.NewReader(testWorkoutYAML))
+	require.NoError(t, err)
+
+	workout, err := spec.Workout()
+	require.NoError(t, err)
+	assert.Equal(t, "5x1K", workout.WorkoutName)
+	assert.Equal(t, 1, workout.SportType.SportTypeID)
+	require.Len(t, workout.WorkoutSegments, 1)
+	steps := workout.WorkoutSegments[0].WorkoutSteps
+	require.Len(t, steps, 3)
+
+	warmup := steps[0]
+	assert.Equal(t, "ExecutableStepDTO", warmup.Type)
+	assert.Equal(t, 1, warmup.StepType.StepTypeID)
+	assert.Equal(t, garmin.EndTime, warmup.EndCondition.ConditionTypeKey)
+	assert.Equal(t, 900.0, *warmup.EndConditionValue)
+	assert.Equal(t, garmin.TargetNone, warmup.TargetType.WorkoutTargetTypeKey)
+
+	repeat := steps[1]
+	assert.Equal(t, "RepeatGroupDTO", repeat.Type)
+	assert.Equal(t, 5, *repeat.NumberOfIterations)
+	require.Len(t, repeat.WorkoutSteps, 2)
+
+	interval := repeat.WorkoutSteps[0]
+	assert.Equal(t, 3, interval.StepOrder)
+	assert.Equal(t, *repeat.ChildStepID, *interval.ChildStepID)
+	assert.Equal(t, 1000.0, *interval.EndConditionValue)
+	assert.Equal(t, garmin.TargetPace, interval.TargetType.WorkoutTargetTypeKey)
+	assert.InDelta(t, 1000.0/250, *interval.TargetValueOne, 1e-9)
+	assert.InDelta(t, 1000.0/240, *interval.TargetValueTwo, 1e-9)
+
+	recovery := repeat.WorkoutSteps[1]
+	assert.Equal(t, 90.0, *recovery.EndConditionValue)
+	assert.Equal(t, garmin.TargetHeartRate, recovery.TargetType.WorkoutTargetTypeKey)
+	assert.Equal(t, 2, *recovery.ZoneNumber)
+
+	cooldown := steps[2]
+	assert.Equal(t, 5, cooldown.StepOrder)
+	assert.Equal(t, garmin.EndLapButton, cooldown.EndCondition.ConditionTypeKey)
+	assert.Equal(t, 150.0, *cooldown.TargetValueOne)
+	assert.Equal(t, 180.0, *cooldown.TargetValueTwo)
+}
+
+func TestReadWorkoutSpec_Invalid(t *testing.T) {
+	_, err := garmin.ReadWorkoutSpec(strings.NewReader("name: x\nsport: running\nsteps:\n  - type: warmup\n    durration: 5m\n"))
+	assert.ErrorContains(t, err, "durration")
+
+	_, err = garmin.ReadWorkoutSpec(strings.NewReader(`
+sport: rowing
+steps:
+  - type: sprint
+  - type: interval
+    duration: 1m
+    distance: 400m
+  - type: repeat
+    steps:
+      - type: repeat
+        count: 2
+        steps: [{type: interval}]
+  - type: interval
+    hr: 150
+    pace: z3
+`))
+	require.Error(t, err)
+	for _, problem := range []string{
+		"name", "unknown sport", "steps[0].type", "both a duration and a distance",
+		"steps[2].count", "steps[2].steps[0]: repeat steps cannot be nested",
+		"steps[3].hr", "steps[3].pace", "more than one target",
+	} {
+		assert.ErrorContains(t, err, problem)
+	}
+}
+
+func TestScheduleWorkout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST /workout-service/schedule/42", r.Method+" "+r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		var request map[string]string
+		require.NoError(t, json.Unmarshal(body, &request))
+		assert.Equal(t, "2024-06-03", request["date"])
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"workoutScheduleId": 9, "calendarDate": "2024-06-03"}`))
+	}))
+	defer server.Close()
+
+	scheduled, err := newTestClient(t, server).ScheduleWorkout(42, time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, int64(9), scheduled.WorkoutScheduleID)
+}
@@ -0,0 +1,213 @@
+package garmin
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Zone is a training zone covering values from Min up to, but not including,
+// Max. A Max of zero means the zone has no upper bound.
+type Zone struct {
+	Name string  `json:"name"`
+	Min  float64 `json:"min"`
+	Max  float64 `json:"max,omitempty"`
+}
+
+// Contains reports whether value falls within the zone
+func (z Zone) Contains(value float64) bool {
+	return value >= z.Min && (z.Max == 0 || value < z.Max)
+}
+
+// ZoneTime is the time spent in a zone
+type ZoneTime struct {
+	Zone    Zone    `json:"zone"`
+	Seconds float64 `json:"seconds"`
+	Percent float64 `json:"percent"` // share of the time spent in any zone
+}
+
+// Duration returns the time spent in the zone
+func (z ZoneTime) Duration() time.Duration {
+	return time.Duration(z.Seconds * float64(time.Second))
+}
+
+// ZoneSet holds the zones to analyse activities against. Pace zones are
+// expressed as speeds in meters per second; see ParsePace.
+type ZoneSet struct {
+	HeartRate []Zone
+	Power     []Zone
+	Pace      []Zone
+}
+
+// ActivityZones holds the time an activity spent in each heart rate, power
+// and pace zone. Metrics without zones or samples are left empty.
+type ActivityZones struct {
+	ActivityID int64      `json:"activityId,omitempty"`
+	Activities int        `json:"activities"`
+	HeartRate  []ZoneTime `json:"heartRate,omitempty"`
+	Power      []ZoneTime `json:"power,omitempty"`
+	Pace       []ZoneTime `json:"pace,omitempty"`
+}
+
+// HeartRateZoneSet converts the user's heart rate zones from Garmin Connect
+// into zones. The top zone has no upper bound.
+func HeartRateZoneSet(hrZones *HeartRateZones) []Zone {
+	if hrZones == nil {
+		return nil
+	}
+	sorted := append([]HRZone(nil), hrZones.Zones...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].MinBPM < sorted[j].MinBPM })
+
+	zones := make([]Zone, len(sorted))
+	for i, z := range sorted {
+		name := z.Name
+		if name == "" {
+			name = fmt.Sprintf("Zone %d", z.Zone)
+		}
+		zones[i] = Zone{Name: name, Min: float64(z.MinBPM)}
+		if i+1 < len(sorted) {
+			// Zones end where the next begins, so no reading falls between two
+			zones[i].Max = float64(sorted[i+1].MinBPM)
+		}
+	}
+	return zones
+}
+
+// ParsePace parses a pace such as "4:30" or "4:30/km" into a speed in meters
+// per second. Paces per mile are written as "7:15/mi".
+func ParsePace(value string) (float64, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	meters := 1000.0
+	switch {
+	case strings.HasSuffix(value, "/km"):
+		value = strings.TrimSuffix(value, "/km")
+	case strings.HasSuffix(value, "/mi"):
+		value = strings.TrimSuffix(value, "/mi")
+		meters = 1609.344
+	}
+	seconds, err := parseQueryDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if seconds <= 0 {
+		return 0, fmt.Errorf("invalid pace %q", value)
+	}
+	return meters / seconds, nil
+}
+
+// TimeInZones sums the time spent in each zone. Each sample counts for the
+// interval up to the next sample; missing values and pauses are skipped.
+// It returns nil when values holds no readings.
+func TimeInZones(timestamps []time.Time, values Series, zones []Zone) []ZoneTime {
+	if len(zones) == 0 {
+		return nil
+	}
+	times := make([]ZoneTime, len(zones))
+	for i, zone := range zones {
+		times[i].Zone = zone
+	}
+
+	recorded := false
+	for i := 0; i+1 < len(timestamps) && i < len(values); i++ {
+		value := values[i]
+		if math.IsNaN(value) || timestamps[i].IsZero() || timestamps[i+1].IsZero() {
+			continue
+		}
+		recorded = true
+		gap := timestamps[i+1].Sub(timestamps[i])
+		if gap <= 0 || gap > maxSampleGap {
+			continue
+		}
+		for z := range times {
+			if times[z].Zone.Contains(value) {
+				times[z].Seconds += gap.Seconds()
+				break
+			}
+		}
+	}
+	if !recorded {
+		return nil
+	}
+	setZonePercents(times)
+	return times
+}
+
+// setZonePercents sets each zone's share of the total time in zones
+func setZonePercents(times []ZoneTime) {
+	total := 0.0
+	for _, t := range times {
+		total += t.Seconds
+	}
+	for i := range times {
+		times[i].Percent = 0
+		if total > 0 {
+			times[i].Percent = times[i].Seconds / total * 100
+		}
+	}
+}
+
+// GetActivityZones computes the time an activity spent in each of the given
+// zones from its samples
+func (c *Client) GetActivityZones(activityID int, zones ZoneSet) (*ActivityZones, error) {
+	samples, err := c.GetActivitySamples(activityID)
+	if err != nil {
+		return nil, err
+	}
+	return &ActivityZones{
+		ActivityID: samples.ActivityID,
+		Activities: 1,
+		HeartRate:  TimeInZones(samples.Timestamps, samples.HeartRate, zones.HeartRate),
+		Power:      TimeInZones(samples.Timestamps, samples.Power, zones.Power),
+		Pace:       TimeInZones(samples.Timestamps, samples.Speed, zones.Pace),
+	}, nil
+}
+
+// SumActivityZones adds up the time in zone of several activities analysed
+// against the same zones
+func SumActivityZones(activities []*ActivityZones) *ActivityZones {
+	total := &ActivityZones{}
+	add := func(sum, times []ZoneTime) []ZoneTime {
+		if len(times) == 0 {
+			return sum
+		}
+		if sum == nil {
+			sum = make([]ZoneTime, len(times))
+			for i, t := range times {
+				sum[i].Zone = t.Zone
+			}
+		}
+		for i := range sum {
+			if i < len(times) {
+				sum[i].Seconds += times[i].Seconds
+			}
+		}
+		return sum
+	}
+
+	for _, a := range activities {
+		if a == nil {
+			continue
+		}
+		total.Activities += a.Activities
+		total.HeartRate = add(total.HeartRate, a.HeartRate)
+		total.Power = add(total.Power, a.Power)
+		total.Pace = add(total.Pace, a.Pace)
+	}
+	setZonePercents(total.HeartRate)
+	setZonePercents(total.Power)
+	setZonePercents(total.Pace)
+	return total
+}
+
+// PolarizedDistribution groups five-zone time in zone into the three
+// intensity domains used to check polarized training: low (zones 1-2),
+// threshold (zone 3) and high (zones 4-5), as percentages. ok is false when
+// the zones are not a five-zone model.
+func PolarizedDistribution(times []ZoneTime) (low, threshold, high float64, ok bool) {
+	if len(times) != 5 {
+		return 0, 0, 0, false
+	}
+	return times[0].Percent + times[1].Percent, times[2].Percent, times[3].Percent + times[4].Percent, true
+}
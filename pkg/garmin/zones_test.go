@@ -0,0 +1,78 @@
+package garmin_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sstent/go-garth-cli/pkg/garmin"
+)
+
+func TestTimeInZones(t *testing.T) {
+	start := time.Date(2024, 3, 1, 7, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time { return start.Add(time.Duration(seconds) * time.Second) }
+
+	timestamps := []time.Time{at(0), at(10), at(20), at(30), at(300), at(310)}
+	hr := garmin.Series{110, 150, math.NaN(), 175, 120, 121}
+	zones := garmin.HeartRateZoneSet(&garmin.HeartRateZones{Zones: []garmin.HRZone{
+		{Zone: 2, MinBPM: 140, MaxBPM: 160},
+		{Zone: 1, MinBPM: 100, MaxBPM: 139},
+		{Zone: 3, MinBPM: 160, MaxBPM: 180},
+	}})
+	require.Len(t, zones, 3)
+	assert.Equal(t, garmin.Zone{Name: "Zone 1", Min: 100, Max: 140}, zones[0])
+	assert.Equal(t, 0.0, zones[2].Max)
+
+	times := garmin.TimeInZones(timestamps, hr, zones)
+	require.Len(t, times, 3)
+	// The missing reading and the pause before the 300s sample are skipped
+	assert.Equal(t, 20.0, times[0].Seconds)
+	assert.Equal(t, 10.0, times[1].Seconds)
+	assert.Equal(t, 0.0, times[2].Seconds)
+	assert.InDelta(t, 66.7, times[0].Percent, 0.1)
+
+	assert.Nil(t, garmin.TimeInZones(timestamps, garmin.Series{math.NaN(), math.NaN()}, zones))
+}
+
+func TestSumActivityZones(t *testing.T) {
+	zone := func(seconds ...float64) []garmin.ZoneTime {
+		times := make([]garmin.ZoneTime, len(seconds))
+		for i, s := range seconds {
+			times[i] = garmin.ZoneTime{Zone: garmin.Zone{Min: float64(i)}, Seconds: s}
+		}
+		return times
+	}
+
+	total := garmin.SumActivityZones([]*garmin.ActivityZones{
+		{Activities: 1, HeartRate: zone(600, 200, 100, 50, 50)},
+		{Activities: 1, HeartRate: zone(1000, 200, 100, 50, 50), Power: zone(60, 60)},
+	})
+	assert.Equal(t, 2, total.Activities)
+	assert.Equal(t, 1600.0, total.HeartRate[0].Seconds)
+	assert.Equal(t, 50.0, total.Power[0].Percent)
+
+	low, threshold, high, ok := garmin.PolarizedDistribution(total.HeartRate)
+	require.True(t, ok)
+	assert.InDelta(t, 83.33, low, 0.01)
+	assert.InDelta(t, 8.33, threshold, 0.01)
+	assert.InDelta(t, 8.33, high, 0.01)
+
+	_, _, _, ok = garmin.PolarizedDistribution(total.Power)
+	assert.False(t, ok)
+}
+
+func TestParsePace(t *testing.T) {
+	speed, err := garmin.ParsePace("5:00/km")
+	require.NoError(t, err)
+	assert.InDelta(t, 3.333, speed, 0.001)
+
+	speed, err = garmin.ParsePace("8:00/mi")
+	require.NoError(t, err)
+	assert.InDelta(t, 3.353, speed, 0.001)
+
+	_, err = garmin.ParsePace("fast")
+	assert.Error(t, err)
+}
@@ -0,0 +1,477 @@
+// Command endpointgen generates typed Garmin Connect client methods, CLI
+// subcommands and documentation from the declarative endpoint catalog in
+// pkg/garmin/endpoints.yaml.
+//
+// It is normally invoked through go generate:
+//
+//	go generate ./pkg/garmin
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Catalog is the top-level structure of the endpoint spec file
+type Catalog struct {
+	Endpoints []Endpoint `yaml:"endpoints"`
+}
+
+// Endpoint describes a single Garmin Connect endpoint
+type Endpoint struct {
+	Name     string  `yaml:"name"`
+	Command  string  `yaml:"command"`
+	Summary  string  `yaml:"summary"`
+	Method   string  `yaml:"method"`
+	Path     string  `yaml:"path"`
+	Params   []Param `yaml:"params"`
+	Body     bool    `yaml:"body"`
+	Response string  `yaml:"response"`
+}
+
+// Param describes a path or query parameter of an endpoint
+type Param struct {
+	Name        string `yaml:"name"`
+	In          string `yaml:"in"`
+	Type        string `yaml:"type"`
+	Description string `yaml:"description"`
+}
+
+var placeholderRe = regexp.MustCompile(`\{([A-Za-z0-9_]+)\}`)
+
+func main() {
+	specFile := flag.String("spec", "endpoints.yaml", "endpoint catalog spec file")
+	clientOut := flag.String("client", "", "output file for generated client methods")
+	cliOut := flag.String("cli", "", "output file for generated CLI subcommands")
+	docsOut := flag.String("docs", "", "output file for generated markdown documentation")
+	flag.Parse()
+
+	catalog, err := loadCatalog(*specFile)
+	if err != nil {
+		log.Fatalf("endpointgen: %v", err)
+	}
+
+	outputs := []struct {
+		path     string
+		generate func(*Catalog) ([]byte, error)
+	}{
+		{*clientOut, generateClient},
+		{*cliOut, generateCLI},
+		{*docsOut, generateDocs},
+	}
+
+	for _, out := range outputs {
+		if out.path == "" {
+			continue
+		}
+		data, err := out.generate(catalog)
+		if err != nil {
+			log.Fatalf("endpointgen: %s: %v", out.path, err)
+		}
+		if err := os.WriteFile(out.path, data, 0644); err != nil {
+			log.Fatalf("endpointgen: %v", err)
+		}
+	}
+}
+
+// loadCatalog reads, defaults and validates the spec file
+func loadCatalog(path string) (*Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var catalog Catalog
+	if err := yaml.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	names := make(map[string]bool)
+	commands := make(map[string]bool)
+	for i := range catalog.Endpoints {
+		ep := &catalog.Endpoints[i]
+		if ep.Method == "" {
+			ep.Method = "GET"
+		}
+		ep.Method = strings.ToUpper(ep.Method)
+		if ep.Response == "" {
+			ep.Response = "json.RawMessage"
+		}
+		if err := ep.validate(); err != nil {
+			return nil, fmt.Errorf("endpoint %q: %w", ep.Name, err)
+		}
+		if names[ep.Name] {
+			return nil, fmt.Errorf("duplicate endpoint name %q", ep.Name)
+		}
+		if commands[ep.Command] {
+			return nil, fmt.Errorf("duplicate command %q", ep.Command)
+		}
+		names[ep.Name] = true
+		commands[ep.Command] = true
+	}
+
+	return &catalog, nil
+}
+
+func (ep *Endpoint) validate() error {
+	if ep.Name == "" || ep.Command == "" || ep.Path == "" {
+		return fmt.Errorf("name, command and path are required")
+	}
+	switch ep.Method {
+	case "GET", "POST", "PUT", "DELETE":
+	default:
+		return fmt.Errorf("unsupported method %s", ep.Method)
+	}
+
+	pathParams := make(map[string]bool)
+	for _, p := range ep.Params {
+		switch p.Type {
+		case "string", "int", "int64", "bool", "date":
+		default:
+			return fmt.Errorf("param %s: unsupported type %q", p.Name, p.Type)
+		}
+		switch p.In {
+		case "path":
+			pathParams[p.Name] = true
+		case "query":
+		default:
+			return fmt.Errorf("param %s: unsupported location %q", p.Name, p.In)
+		}
+		if p.Name == "body" {
+			return fmt.Errorf("param name %q is reserved", p.Name)
+		}
+	}
+
+	for _, m := range placeholderRe.FindAllStringSubmatch(ep.Path, -1) {
+		if !pathParams[m[1]] {
+			return fmt.Errorf("path placeholder {%s} has no matching path param", m[1])
+		}
+		delete(pathParams, m[1])
+	}
+	for name := range pathParams {
+		return fmt.Errorf("path param %s does not appear in path", name)
+	}
+	return nil
+}
+
+// goIdent converts a camelCase parameter name into an idiomatic Go identifier
+func goIdent(name string) string {
+	if strings.HasSuffix(name, "Id") {
+		return strings.TrimSuffix(name, "Id") + "ID"
+	}
+	return name
+}
+
+// flagName converts a camelCase parameter name into a kebab-case flag name
+func flagName(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('-')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// action turns a method name like GetDeviceSettings into "get device settings"
+func action(name string) string {
+	return strings.ReplaceAll(flagName(name), "-", " ")
+}
+
+// subject drops the leading verb from action, e.g. "device settings"
+func subject(name string) string {
+	words := strings.SplitN(action(name), " ", 2)
+	return words[len(words)-1]
+}
+
+func goType(p Param) string {
+	if p.Type == "date" {
+		return "time.Time"
+	}
+	return p.Type
+}
+
+func (ep *Endpoint) signature() string {
+	var args []string
+	for _, p := range ep.Params {
+		args = append(args, goIdent(p.Name)+" "+goType(p))
+	}
+	if ep.Body {
+		args = append(args, "body interface{}")
+	}
+	return fmt.Sprintf("%s(%s) (%s, error)", ep.Name, strings.Join(args, ", "), ep.Response)
+}
+
+func (ep *Endpoint) param(name string) Param {
+	for _, p := range ep.Params {
+		if p.Name == name {
+			return p
+		}
+	}
+	return Param{}
+}
+
+func (ep *Endpoint) hasQuery() bool {
+	for _, p := range ep.Params {
+		if p.In == "query" {
+			return true
+		}
+	}
+	return false
+}
+
+// pathExpr returns a Go expression that builds the request path
+func (ep *Endpoint) pathExpr() string {
+	var args []string
+	tmpl := placeholderRe.ReplaceAllStringFunc(ep.Path, func(m string) string {
+		p := ep.param(m[1 : len(m)-1])
+		ident := goIdent(p.Name)
+		switch p.Type {
+		case "int", "int64":
+			args = append(args, ident)
+			return "%d"
+		case "bool":
+			args = append(args, ident)
+			return "%t"
+		case "date":
+			args = append(args, ident+`.Format("2006-01-02")`)
+			return "%s"
+		default:
+			// Strings such as display names and gear UUIDs come from the user
+			args = append(args, "url.PathEscape("+ident+")")
+			return "%s"
+		}
+	})
+	if len(args) == 0 {
+		return fmt.Sprintf("%q", tmpl)
+	}
+	return fmt.Sprintf("fmt.Sprintf(%q, %s)", tmpl, strings.Join(args, ", "))
+}
+
+func queryStmt(p Param) string {
+	ident := goIdent(p.Name)
+	switch p.Type {
+	case "int":
+		return fmt.Sprintf("if %s != 0 {\nparams.Set(%q, strconv.Itoa(%s))\n}\n", ident, p.Name, ident)
+	case "int64":
+		return fmt.Sprintf("if %s != 0 {\nparams.Set(%q, strconv.FormatInt(%s, 10))\n}\n", ident, p.Name, ident)
+	case "bool":
+		return fmt.Sprintf("if %s {\nparams.Set(%q, \"true\")\n}\n", ident, p.Name)
+	case "date":
+		return fmt.Sprintf("if !%s.IsZero() {\nparams.Set(%q, %s.Format(\"2006-01-02\"))\n}\n", ident, p.Name, ident)
+	default:
+		return fmt.Sprintf("if %s != \"\" {\nparams.Set(%q, %s)\n}\n", ident, p.Name, ident)
+	}
+}
+
+const header = "// Code generated by endpointgen from pkg/garmin/endpoints.yaml. DO NOT EDIT.\n\n"
+
+func generateClient(catalog *Catalog) ([]byte, error) {
+	imports := map[string]bool{"encoding/json": true}
+	var body bytes.Buffer
+
+	for _, ep := range catalog.Endpoints {
+		fmt.Fprintf(&body, "// %s implements the %q catalog endpoint.\n", ep.Name, ep.Command)
+		fmt.Fprintf(&body, "// %s\n//\n//\t%s %s\n", ep.Summary, ep.Method, ep.Path)
+		fmt.Fprintf(&body, "func (c *Client) %s {\n", ep.signature())
+		fmt.Fprintf(&body, "var result %s\n", ep.Response)
+
+		pathExpr := ep.pathExpr()
+		if strings.HasPrefix(pathExpr, "fmt.") {
+			imports["fmt"] = true
+		}
+		if strings.Contains(pathExpr, "url.PathEscape") {
+			imports["net/url"] = true
+		}
+		fmt.Fprintf(&body, "path := %s\n", pathExpr)
+
+		paramsArg := "nil"
+		if ep.hasQuery() {
+			imports["net/url"] = true
+			paramsArg = "params"
+			body.WriteString("params := url.Values{}\n")
+			for _, p := range ep.Params {
+				if p.In != "query" {
+					continue
+				}
+				if p.Type == "int" || p.Type == "int64" {
+					imports["strconv"] = true
+				}
+				body.WriteString(queryStmt(p))
+			}
+		}
+
+		bodyArg := "nil"
+		if ep.Body {
+			imports["bytes"] = true
+			bodyArg = "bytes.NewReader(payload)"
+			fmt.Fprintf(&body, "payload, err := json.Marshal(body)\nif err != nil {\nreturn result, fmt.Errorf(\"failed to encode %s request: %%w\", err)\n}\n", action(ep.Name))
+		}
+
+		imports["fmt"] = true
+		fmt.Fprintf(&body, "data, err := c.Client.ConnectAPI(path, %q, %s, %s)\n", ep.Method, paramsArg, bodyArg)
+		fmt.Fprintf(&body, "if err != nil {\nreturn result, fmt.Errorf(\"failed to %s: %%w\", err)\n}\n", action(ep.Name))
+		body.WriteString("if len(data) == 0 {\nreturn result, nil\n}\n")
+		fmt.Fprintf(&body, "if err := json.Unmarshal(data, &result); err != nil {\nreturn result, fmt.Errorf(\"failed to parse %s response: %%w\", err)\n}\n", subject(ep.Name))
+		body.WriteString("return result, nil\n}\n\n")
+
+		for _, p := range ep.Params {
+			if p.Type == "date" {
+				imports["time"] = true
+			}
+		}
+	}
+
+	var out bytes.Buffer
+	out.WriteString(header)
+	out.WriteString("package garmin\n\n")
+	writeImports(&out, imports, nil)
+	out.Write(body.Bytes())
+	return format.Source(out.Bytes())
+}
+
+func generateCLI(catalog *Catalog) ([]byte, error) {
+	var body bytes.Buffer
+
+	for _, ep := range catalog.Endpoints {
+		body.WriteString("func init() {\n")
+		if len(ep.Params) > 0 || ep.Body {
+			body.WriteString("var (\n")
+			for _, p := range ep.Params {
+				t := goType(p)
+				if p.Type == "date" {
+					t = "string"
+				}
+				fmt.Fprintf(&body, "%s %s\n", goIdent(p.Name), t)
+			}
+			if ep.Body {
+				body.WriteString("bodyFile string\n")
+			}
+			body.WriteString(")\n")
+		}
+
+		fmt.Fprintf(&body, "cmd := &cobra.Command{\n")
+		fmt.Fprintf(&body, "Use: %q,\n", ep.Command)
+		fmt.Fprintf(&body, "Short: %q,\n", strings.TrimSuffix(ep.Summary, "."))
+		fmt.Fprintf(&body, "Long: %q,\n", fmt.Sprintf("%s\n\nEndpoint: %s %s", ep.Summary, ep.Method, ep.Path))
+		body.WriteString("Args: cobra.NoArgs,\n")
+		body.WriteString("RunE: func(cmd *cobra.Command, args []string) error {\n")
+
+		var callArgs []string
+		for _, p := range ep.Params {
+			ident := goIdent(p.Name)
+			if p.Type == "date" {
+				fmt.Fprintf(&body, "%sValue, err := parseAPIDate(%q, %s)\nif err != nil {\nreturn err\n}\n", ident, flagName(p.Name), ident)
+				callArgs = append(callArgs, ident+"Value")
+				continue
+			}
+			callArgs = append(callArgs, ident)
+		}
+		if ep.Body {
+			body.WriteString("body, err := readAPIBody(bodyFile)\nif err != nil {\nreturn err\n}\n")
+			callArgs = append(callArgs, "body")
+		}
+
+		body.WriteString("garminClient, err := newGarminClient()\nif err != nil {\nreturn err\n}\n")
+		fmt.Fprintf(&body, "result, err := garminClient.%s(%s)\n", ep.Name, strings.Join(callArgs, ", "))
+		fmt.Fprintf(&body, "if err != nil {\nreturn fmt.Errorf(\"failed to %s: %%w\", err)\n}\n", action(ep.Name))
+		body.WriteString("return printAPIResult(result)\n},\n}\n")
+
+		for _, p := range ep.Params {
+			ident := goIdent(p.Name)
+			flag := flagName(p.Name)
+			desc := p.Description
+			switch p.Type {
+			case "int":
+				fmt.Fprintf(&body, "cmd.Flags().IntVar(&%s, %q, 0, %q)\n", ident, flag, desc)
+			case "int64":
+				fmt.Fprintf(&body, "cmd.Flags().Int64Var(&%s, %q, 0, %q)\n", ident, flag, desc)
+			case "bool":
+				fmt.Fprintf(&body, "cmd.Flags().BoolVar(&%s, %q, false, %q)\n", ident, flag, desc)
+			default:
+				fmt.Fprintf(&body, "cmd.Flags().StringVar(&%s, %q, \"\", %q)\n", ident, flag, desc)
+			}
+			if p.In == "path" {
+				fmt.Fprintf(&body, "_ = cmd.MarkFlagRequired(%q)\n", flag)
+			}
+		}
+		if ep.Body {
+			body.WriteString("cmd.Flags().StringVar(&bodyFile, \"body\", \"\", \"JSON file with the request body (- for stdin)\")\n")
+			body.WriteString("_ = cmd.MarkFlagRequired(\"body\")\n")
+		}
+		body.WriteString("apiCmd.AddCommand(cmd)\n}\n\n")
+	}
+
+	var out bytes.Buffer
+	out.WriteString(header)
+	out.WriteString("package main\n\n")
+	writeImports(&out, map[string]bool{"fmt": true}, []string{"github.com/spf13/cobra"})
+	out.Write(body.Bytes())
+	return format.Source(out.Bytes())
+}
+
+func generateDocs(catalog *Catalog) ([]byte, error) {
+	var out bytes.Buffer
+	out.WriteString("# Garmin Connect Endpoint Catalog\n\n")
+	out.WriteString("<!-- Code generated by endpointgen from pkg/garmin/endpoints.yaml. DO NOT EDIT. -->\n\n")
+	out.WriteString("Endpoints declared in `pkg/garmin/endpoints.yaml`. Each one is available as a typed\n")
+	out.WriteString("method on `garmin.Client` and as a `garth api` subcommand that prints the decoded response.\n\n")
+
+	out.WriteString("| Endpoint | Method | Path | CLI |\n|---|---|---|---|\n")
+	for _, ep := range catalog.Endpoints {
+		fmt.Fprintf(&out, "| [%s](#%s) | %s | `%s` | `garth api %s` |\n",
+			ep.Name, strings.ToLower(ep.Name), ep.Method, ep.Path, ep.Command)
+	}
+
+	for _, ep := range catalog.Endpoints {
+		fmt.Fprintf(&out, "\n## %s\n\n%s\n\n", ep.Name, ep.Summary)
+		fmt.Fprintf(&out, "- **Endpoint**: `%s %s`\n", ep.Method, ep.Path)
+		fmt.Fprintf(&out, "- **Go**: `func (c *Client) %s`\n", ep.signature())
+		fmt.Fprintf(&out, "- **CLI**: `garth api %s", ep.Command)
+		for _, p := range ep.Params {
+			fmt.Fprintf(&out, " --%s <%s>", flagName(p.Name), p.Type)
+		}
+		if ep.Body {
+			out.WriteString(" --body <file>")
+		}
+		out.WriteString("`\n")
+
+		if len(ep.Params) > 0 {
+			out.WriteString("\n| Parameter | In | Type | Description |\n|---|---|---|---|\n")
+			for _, p := range ep.Params {
+				fmt.Fprintf(&out, "| `%s` | %s | %s | %s |\n", p.Name, p.In, p.Type, p.Description)
+			}
+		}
+	}
+
+	return out.Bytes(), nil
+}
+
+func writeImports(out *bytes.Buffer, std map[string]bool, thirdParty []string) {
+	out.WriteString("import (\n")
+	for _, pkg := range []string{"bytes", "encoding/json", "fmt", "net/url", "strconv", "time"} {
+		if std[pkg] {
+			fmt.Fprintf(out, "%q\n", pkg)
+		}
+	}
+	if len(thirdParty) > 0 {
+		out.WriteString("\n")
+		for _, pkg := range thirdParty {
+			fmt.Fprintf(out, "%q\n", pkg)
+		}
+	}
+	out.WriteString(")\n\n")
+}